@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -78,11 +82,93 @@ func main() {
 	userRepo := repository.NewUserRepository(db)
 	profileRepo := repository.NewProfileRepository(db)
 	mentorshipRepo := repository.NewMentorshipRepository(db)
+	calendarRepo := repository.NewCalendarRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	sessionContentRepo := repository.NewSessionContentRepository(db)
+	feedbackRepo := repository.NewFeedbackRepository(db)
+	goalRepo := repository.NewGoalRepository(db)
+	cohortRepo := repository.NewCohortRepository(db)
+	curriculumRepo := repository.NewCurriculumRepository(db)
+	assignmentRepo := repository.NewAssignmentRepository(db)
+	agreementRepo := repository.NewAgreementRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	sponsorRepo := repository.NewSponsorRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	recommendationRepo := repository.NewRecommendationRepository(db)
+	matchingRepo := repository.NewMatchingRepository(db)
+	featuredRepo := repository.NewFeaturedRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	jobAlertRepo := repository.NewJobAlertRepository(db)
+
+	// Public address for absolute links in feeds and structured data
+	baseURL, err := parseBaseURL(getEnv("BASE_URL", "http://localhost:8080"))
+	if err != nil {
+		logger.Fatalf("Invalid BASE_URL: %v", err)
+	}
 
 	// Initialize services
 	emailSvc := email.NewEmailService("noreply@nexusmentors.org")
-	userService := services.NewUserService(userRepo, profileRepo, emailSvc)
-	mentorshipService := services.NewMentorshipService(mentorshipRepo, profileRepo, userRepo)
+	skillService := services.NewSkillService(skillRepo)
+	userService := services.NewUserService(userRepo, profileRepo, feedbackRepo, skillService, emailSvc)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, emailSvc)
+	meetingProvider := services.NewJitsiMeetingProvider(getEnv("MEETING_BASE_URL", "https://meet.jit.si"))
+	platformFeePercent, err := strconv.Atoi(getEnv("PLATFORM_FEE_PERCENT", "10"))
+	if err != nil || platformFeePercent < 0 || platformFeePercent > 100 {
+		logger.Fatalf("Invalid PLATFORM_FEE_PERCENT: %q", getEnv("PLATFORM_FEE_PERCENT", "10"))
+	}
+	allowLocalPayments, err := strconv.ParseBool(getEnv("PAYMENT_ALLOW_LOCAL_PROVIDER", "false"))
+	if err != nil {
+		logger.Fatalf("Invalid PAYMENT_ALLOW_LOCAL_PROVIDER: %q", getEnv("PAYMENT_ALLOW_LOCAL_PROVIDER", "false"))
+	}
+	paymentProvider, err := newPaymentProvider(getEnv("PAYMENT_PROVIDER", ""), allowLocalPayments)
+	if err != nil {
+		logger.Fatalf("Failed to configure payments: %v", err)
+	}
+	sponsorService := services.NewSponsorService(sponsorRepo, mentorshipRepo, notificationService)
+	paymentService := services.NewPaymentService(paymentRepo, mentorshipRepo, sponsorRepo, paymentProvider, notificationService, getEnv("CURRENCY", "USD"), platformFeePercent)
+	mentorshipService := services.NewMentorshipService(mentorshipRepo, profileRepo, userRepo, calendarRepo, agreementRepo, meetingProvider, paymentService, sponsorService, skillService, notificationService)
+	reminderService := services.NewReminderService(mentorshipRepo, notificationService, 24*time.Hour)
+	// Only for local testing: lets calendar feeds point at localhost and private networks
+	calendarAllowPrivate, err := strconv.ParseBool(getEnv("CALENDAR_ALLOW_PRIVATE_ADDRESSES", "false"))
+	if err != nil {
+		logger.Fatalf("Invalid CALENDAR_ALLOW_PRIVATE_ADDRESSES: %q", getEnv("CALENDAR_ALLOW_PRIVATE_ADDRESSES", "false"))
+	}
+	calendarService := services.NewCalendarService(calendarRepo, profileRepo, userRepo, nil, calendarAllowPrivate)
+	sessionContentService := services.NewSessionContentService(sessionContentRepo, mentorshipRepo)
+	reviewFilters := []services.ContentFilter{services.NewProfanityFilter(nil), services.NewPIIFilter()}
+	feedbackService := services.NewFeedbackService(feedbackRepo, mentorshipRepo, profileRepo, notificationService, reviewFilters)
+	goalService := services.NewGoalService(goalRepo, mentorshipRepo, notificationService)
+	cohortService := services.NewCohortService(cohortRepo, mentorshipRepo, userRepo, notificationService)
+	fileStore := services.NewLocalFileStore(getEnv("UPLOAD_DIR", "uploads"))
+	curriculumService := services.NewCurriculumService(curriculumRepo, mentorshipRepo, fileStore)
+	assignmentService := services.NewAssignmentService(assignmentRepo, mentorshipRepo, fileStore, notificationService)
+	recommendationService := services.NewRecommendationService(recommendationRepo, profileRepo, userRepo)
+	matchingService := services.NewMatchingService(matchingRepo, mentorshipRepo, mentorshipService, notificationService)
+	featuredCacheTTL, err := time.ParseDuration(getEnv("FEATURED_MENTORS_CACHE_TTL", "5m"))
+	if err != nil {
+		logger.Fatalf("Invalid FEATURED_MENTORS_CACHE_TTL: %v", err)
+	}
+	featuredService := services.NewFeaturedService(featuredRepo, userRepo, featuredCacheTTL)
+	jobPostingTTL, err := time.ParseDuration(getEnv("JOB_POSTING_TTL", "720h"))
+	if err != nil {
+		logger.Fatalf("Invalid JOB_POSTING_TTL: %v", err)
+	}
+	jobAlertService := services.NewJobAlertService(jobAlertRepo, jobRepo, profileRepo, skillRepo, notificationService)
+	jobService := services.NewJobService(jobRepo, userRepo, mentorshipRepo, fileStore, notificationService, jobAlertService, jobPostingTTL)
+	agreementService := services.NewAgreementService(agreementRepo, mentorshipRepo, paymentService, notificationService)
+
+	// Background jobs stop when the server shuts down
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	calendarSyncInterval, err := time.ParseDuration(getEnv("CALENDAR_SYNC_INTERVAL", "15m"))
+	if err != nil {
+		logger.Fatalf("Invalid CALENDAR_SYNC_INTERVAL: %v", err)
+	}
+	go calendarService.Run(bgCtx, calendarSyncInterval)
+	go reminderService.Run(bgCtx, 5*time.Minute)
+	go jobService.RunExpiry(bgCtx, 15*time.Minute)
+	go jobAlertService.RunDigests(bgCtx, time.Hour)
 
 	// Initialize templates with recursive glob
 	var allTemplates []string
@@ -117,8 +203,25 @@ func main() {
 	userHandler := handlers.NewUserHandler(userService)
 	mentorshipHandler := handlers.NewMentorshipHandler(mentorshipService)
 	profileHandler := handlers.NewProfileHandler(userService)
-	homeHandler := handlers.NewHomeHandler(userService, mentorshipService)
+	homeHandler := handlers.NewHomeHandler(userService, mentorshipService, goalService, recommendationService, featuredService, jobService, baseURL)
 	adminHandler := handlers.NewAdminHandler(db, userRepo, profileRepo, templates)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	sessionContentHandler := handlers.NewSessionContentHandler(sessionContentService)
+	feedbackHandler := handlers.NewFeedbackHandler(feedbackService)
+	goalHandler := handlers.NewGoalHandler(goalService)
+	cohortHandler := handlers.NewCohortHandler(cohortService)
+	curriculumHandler := handlers.NewCurriculumHandler(curriculumService)
+	assignmentHandler := handlers.NewAssignmentHandler(assignmentService)
+	agreementHandler := handlers.NewAgreementHandler(agreementService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, templates)
+	sponsorHandler := handlers.NewSponsorHandler(sponsorService)
+	skillHandler := handlers.NewSkillHandler(skillService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	matchingHandler := handlers.NewMatchingHandler(matchingService)
+	featuredHandler := handlers.NewFeaturedHandler(featuredService)
+	jobHandler := handlers.NewJobHandler(jobService, baseURL)
+	jobAlertHandler := handlers.NewJobAlertHandler(jobAlertService)
 
 	// Initialize router
 	r := chi.NewRouter()
@@ -138,11 +241,11 @@ func main() {
 	}))
 
 	// Setup routes
-	routes.SetupRoutes(r, userHandler, mentorshipHandler, profileHandler, homeHandler, adminHandler)
+	routes.SetupRoutes(r, userHandler, mentorshipHandler, profileHandler, homeHandler, adminHandler, calendarHandler, notificationHandler, sessionContentHandler, feedbackHandler, goalHandler, cohortHandler, curriculumHandler, assignmentHandler, agreementHandler, paymentHandler, sponsorHandler, skillHandler, recommendationHandler, matchingHandler, featuredHandler, jobHandler, jobAlertHandler)
 
 	// Server configuration
 	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%s", getEnv("HOST", "10.20.0.1"), getEnv("PORT", "8080")),
+		Addr:         fmt.Sprintf("%s:%s", getEnv("HOST", "10.20.0.1"), getEnv("PORT", "8080")),
 		Handler:      r,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
@@ -157,6 +260,7 @@ func main() {
 	go func() {
 		<-quit
 		logger.Println("Server is shutting down...")
+		stopBackground()
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -213,6 +317,34 @@ func initDatabase(config DatabaseConfig) (*sql.DB, error) {
 	return db, nil
 }
 
+// parseBaseURL checks the site's public address and strips any trailing slash
+func parseBaseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%q must be an absolute http or https URL", raw)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// newPaymentProvider returns the provider named by PAYMENT_PROVIDER. The local
+// provider accepts every card, so it is refused unless explicitly allowed for
+// development and tests.
+func newPaymentProvider(name string, allowLocal bool) (services.PaymentProvider, error) {
+	switch name {
+	case "":
+		return nil, errors.New("PAYMENT_PROVIDER is not set")
+	case "local":
+		if !allowLocal {
+			return nil, errors.New("the local payment provider accepts every charge; set PAYMENT_ALLOW_LOCAL_PROVIDER=true to use it for development or tests")
+		}
+		return services.NewLocalPaymentProvider(), nil
+	}
+	return nil, fmt.Errorf("unsupported PAYMENT_PROVIDER %q", name)
+}
+
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -175,16 +175,18 @@ func (h *AdminHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 		NewUsersToday    int
 		ActiveSessions   int
 		OpenJobs         int
+		PendingJobs      int
 	}
 
 	// Collect statistics
 	h.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&stats.TotalUsers)
 	h.db.QueryRow("SELECT COUNT(*) FROM users WHERE is_approved = false AND is_mentor = true").Scan(&stats.PendingApprovals)
 	h.db.QueryRow("SELECT COUNT(*) FROM users WHERE is_mentor = true AND is_approved = true").Scan(&stats.ActiveMentors)
-	h.db.QueryRow("SELECT COUNT(*) FROM users WHERE is_mentor = false").Scan(&stats.ActiveMentees)
+	h.db.QueryRow("SELECT COUNT(*) FROM users WHERE is_mentor = false AND role <> $1", models.UserRole.Employer).Scan(&stats.ActiveMentees)
 	h.db.QueryRow("SELECT COUNT(*) FROM users WHERE DATE(created_at) = CURRENT_DATE").Scan(&stats.NewUsersToday)
 	h.db.QueryRow("SELECT COUNT(*) FROM mentorship_sessions WHERE status = 'scheduled'").Scan(&stats.ActiveSessions)
-	h.db.QueryRow("SELECT COUNT(*) FROM jobs WHERE status = 'active'").Scan(&stats.OpenJobs)
+	h.db.QueryRow("SELECT COUNT(*) FROM jobs WHERE status = $1", models.JobStatus.Active).Scan(&stats.OpenJobs)
+	h.db.QueryRow("SELECT COUNT(*) FROM jobs WHERE status = $1", models.JobStatus.Pending).Scan(&stats.PendingJobs)
 
 	// Get pending mentors
 	query := `
@@ -257,6 +259,16 @@ func (h *AdminHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) ApproveMentor(w http.ResponseWriter, r *http.Request) {
+	h.setApproval(w, r, "is_mentor = true", "Mentor")
+}
+
+// ApproveEmployer approves or revokes an employer account's right to post jobs
+func (h *AdminHandler) ApproveEmployer(w http.ResponseWriter, r *http.Request) {
+	h.setApproval(w, r, "role = '"+models.UserRole.Employer+"'", "Employer")
+}
+
+// setApproval updates the approval of the user in the URL, provided they match condition
+func (h *AdminHandler) setApproval(w http.ResponseWriter, r *http.Request, condition, kind string) {
 	userID, err := strconv.Atoi(chi.URLParam(r, "userId"))
 	if err != nil {
 		log.Printf("Invalid user ID: %v", err)
@@ -276,12 +288,12 @@ func (h *AdminHandler) ApproveMentor(w http.ResponseWriter, r *http.Request) {
 	result, err := h.db.ExecContext(r.Context(), `
         UPDATE users
         SET is_approved = $1, email_verified = $1
-        WHERE id = $2 AND is_mentor = true`,
+        WHERE id = $2 AND `+condition,
 		req.Approved, userID)
 
 	if err != nil {
-		log.Printf("Failed to update mentor status: %v", err)
-		http.Error(w, "Failed to update mentor status", http.StatusInternalServerError)
+		log.Printf("Failed to update %s status: %v", strings.ToLower(kind), err)
+		http.Error(w, "Failed to update "+strings.ToLower(kind)+" status", http.StatusInternalServerError)
 		return
 	}
 
@@ -293,107 +305,11 @@ func (h *AdminHandler) ApproveMentor(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if rows == 0 {
-		http.Error(w, "Mentor not found", http.StatusNotFound)
+		http.Error(w, kind+" not found", http.StatusNotFound)
 		return
 	}
 
 	common.RespondJSON(w, http.StatusOK, map[string]string{
-		"message": "Mentor status updated successfully",
+		"message": kind + " status updated successfully",
 	})
 }
-
-// Job management methods
-func (h *AdminHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
-	rows, err := h.db.Query(`
-        SELECT id, title, company, location, description, salary_range, 
-               remote_policy, status, is_featured, created_at
-        FROM jobs
-        WHERE status != $1
-        ORDER BY created_at DESC`,
-		models.JobStatus.Closed)
-	if err != nil {
-		http.Error(w, "Failed to fetch jobs", http.StatusInternalServerError)
-		return
-	}
-	defer rows.Close()
-
-	var jobs []*models.Job
-	for rows.Next() {
-		job := &models.Job{}
-		if err := rows.Scan(
-			&job.ID, &job.Title, &job.Company, &job.Location, &job.Description,
-			&job.SalaryRange, &job.RemotePolicy, &job.Status, &job.IsFeatured,
-			&job.CreatedAt); err != nil {
-			continue
-		}
-		jobs = append(jobs, job)
-	}
-
-	common.RespondJSON(w, http.StatusOK, jobs)
-}
-
-func (h *AdminHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
-	var job models.Job
-	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	job.Status = models.JobStatus.Active
-
-	err := h.db.QueryRow(`
-        INSERT INTO jobs (title, company, location, description, salary_range, remote_policy, status)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
-        RETURNING id`,
-		job.Title, job.Company, job.Location, job.Description,
-		job.SalaryRange, job.RemotePolicy, job.Status).Scan(&job.ID)
-
-	if err != nil {
-		http.Error(w, "Failed to create job", http.StatusInternalServerError)
-		return
-	}
-
-	common.RespondJSON(w, http.StatusCreated, job)
-}
-
-func (h *AdminHandler) DeleteJob(w http.ResponseWriter, r *http.Request) {
-	jobID, err := strconv.Atoi(chi.URLParam(r, "jobId"))
-	if err != nil {
-		http.Error(w, "Invalid job ID", http.StatusBadRequest)
-		return
-	}
-
-	_, err = h.db.Exec("UPDATE jobs SET status = $1 WHERE id = $2",
-		models.JobStatus.Closed, jobID)
-	if err != nil {
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusNoContent)
-}
-
-func (h *AdminHandler) FeatureJob(w http.ResponseWriter, r *http.Request) {
-	jobID, err := strconv.Atoi(chi.URLParam(r, "jobId"))
-	if err != nil {
-		http.Error(w, "Invalid job ID", http.StatusBadRequest)
-		return
-	}
-
-	var req struct {
-		Featured bool `json:"featured"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	_, err = h.db.Exec("UPDATE jobs SET is_featured = $1 WHERE id = $2",
-		req.Featured, jobID)
-	if err != nil {
-		http.Error(w, "Failed to update job", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
-}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AgreementHandler struct {
+	service services.IAgreementService
+}
+
+func NewAgreementHandler(service services.IAgreementService) *AgreementHandler {
+	return &AgreementHandler{
+		service: service,
+	}
+}
+
+type agreementTemplateRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// ListDefaultTemplates returns the version history of the platform-wide agreement
+func (h *AgreementHandler) ListDefaultTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.ListTemplates(r.Context(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, templates)
+}
+
+// PublishDefaultTemplate publishes a new version of the platform-wide agreement
+func (h *AgreementHandler) PublishDefaultTemplate(w http.ResponseWriter, r *http.Request) {
+	h.publishTemplate(w, r, nil)
+}
+
+// ListProgramTemplates returns the version history of a program's agreement override
+func (h *AgreementHandler) ListProgramTemplates(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	templates, err := h.service.ListTemplates(r.Context(), &programID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, templates)
+}
+
+// PublishProgramTemplate publishes a new version of a program's agreement override
+func (h *AgreementHandler) PublishProgramTemplate(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	h.publishTemplate(w, r, &programID)
+}
+
+func (h *AgreementHandler) publishTemplate(w http.ResponseWriter, r *http.Request, programID *int) {
+	var req agreementTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	template, err := h.service.PublishTemplate(r.Context(), adminID, programID, req.Title, req.Body)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, template)
+}
+
+// GetAcceptanceRecord returns the agreement a mentorship accepted, with who accepted it, when and from where
+func (h *AgreementHandler) GetAcceptanceRecord(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	agreement, err := h.service.GetAcceptanceRecord(r.Context(), requestID)
+	if errors.Is(err, repository.ErrAgreementNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, agreement)
+}
+
+// GetAgreement returns the agreement a mentorship must accept before it starts
+func (h *AgreementHandler) GetAgreement(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	agreement, err := h.service.GetAgreement(r.Context(), userID, requestID)
+	if errors.Is(err, repository.ErrAgreementNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, agreement)
+}
+
+// AcceptAgreement records the caller's acceptance of the mentorship agreement
+func (h *AgreementHandler) AcceptAgreement(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	agreement, err := h.service.Accept(r.Context(), userID, requestID, clientIP(r), r.UserAgent())
+	if errors.Is(err, repository.ErrAgreementNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrAgreementNotPending) || errors.Is(err, repository.ErrAgreementAlreadySigned) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, agreement)
+}
+
+// clientIP returns the address of the connecting client without its port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
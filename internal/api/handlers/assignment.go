@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AssignmentHandler struct {
+	service services.IAssignmentService
+}
+
+func NewAssignmentHandler(service services.IAssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{
+		service: service,
+	}
+}
+
+type assignmentRequest struct {
+	ProgramID        *int                     `json:"program_id"`
+	RequestID        *int                     `json:"request_id"`
+	Title            string                   `json:"title"`
+	Instructions     string                   `json:"instructions"`
+	DueAt            string                   `json:"due_at"` // RFC3339, optional
+	Rubric           []models.RubricCriterion `json:"rubric"`
+	MaxResubmissions *int                     `json:"max_resubmissions"`
+}
+
+func (req *assignmentRequest) toAssignment() (*models.Assignment, error) {
+	assignment := &models.Assignment{
+		ProgramID:        req.ProgramID,
+		RequestID:        req.RequestID,
+		Title:            req.Title,
+		Instructions:     req.Instructions,
+		Rubric:           req.Rubric,
+		MaxResubmissions: 2,
+	}
+	if req.MaxResubmissions != nil {
+		assignment.MaxResubmissions = *req.MaxResubmissions
+	}
+	if req.DueAt != "" {
+		dueAt, err := time.Parse(time.RFC3339, req.DueAt)
+		if err != nil {
+			return nil, errors.New("invalid due_at, expected RFC3339")
+		}
+		assignment.DueAt = &dueAt
+	}
+	return assignment, nil
+}
+
+type submissionRequest struct {
+	Body  string   `json:"body"`
+	Links []string `json:"links"`
+}
+
+type reviewRequest struct {
+	Scores   []models.RubricScore `json:"scores"`
+	Comment  string               `json:"comment"`
+	Decision string               `json:"decision"`
+}
+
+// CreateAssignment sets an assignment for a program or a single mentorship
+func (h *AssignmentHandler) CreateAssignment(w http.ResponseWriter, r *http.Request) {
+	var req assignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := req.toAssignment()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.CreateAssignment(r.Context(), mentorID, assignment)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, assignment)
+}
+
+// UpdateAssignment edits an assignment the mentor created
+func (h *AssignmentHandler) UpdateAssignment(w http.ResponseWriter, r *http.Request) {
+	assignmentID, err := strconv.Atoi(chi.URLParam(r, "assignmentId"))
+	if err != nil {
+		http.Error(w, "Invalid assignment ID", http.StatusBadRequest)
+		return
+	}
+
+	var req assignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	update, err := req.toAssignment()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	assignment, err := h.service.UpdateAssignment(r.Context(), mentorID, assignmentID, update)
+	if errors.Is(err, repository.ErrAssignmentNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, assignment)
+}
+
+// DeleteAssignment removes an assignment the mentor created
+func (h *AssignmentHandler) DeleteAssignment(w http.ResponseWriter, r *http.Request) {
+	assignmentID, err := strconv.Atoi(chi.URLParam(r, "assignmentId"))
+	if err != nil {
+		http.Error(w, "Invalid assignment ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.DeleteAssignment(r.Context(), mentorID, assignmentID)
+	if errors.Is(err, repository.ErrAssignmentNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListProgramAssignments returns the program-wide assignments of a mentor's program
+func (h *AssignmentHandler) ListProgramAssignments(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	assignments, err := h.service.ListProgramAssignments(r.Context(), mentorID, programID)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, assignments)
+}
+
+// ListMentorshipAssignments returns the assignments of a mentorship with their latest submissions
+func (h *AssignmentHandler) ListMentorshipAssignments(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	assignments, err := h.service.ListMentorshipAssignments(r.Context(), userID, requestID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, assignments)
+}
+
+// Submit hands in work for an assignment. Accepts either a JSON body or a
+// multipart form with "body", repeated "links" fields and an optional "file" part.
+func (h *AssignmentHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+	assignmentID, err := strconv.Atoi(chi.URLParam(r, "assignmentId"))
+	if err != nil {
+		http.Error(w, "Invalid assignment ID", http.StatusBadRequest)
+		return
+	}
+
+	submission := &models.Submission{}
+	var fileName, contentType string
+	var file io.Reader
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+		if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+			http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+			return
+		}
+		submission.Body = r.FormValue("body")
+		submission.Links = r.MultipartForm.Value["links"]
+
+		upload, header, err := r.FormFile("file")
+		if err != nil && !errors.Is(err, http.ErrMissingFile) {
+			http.Error(w, "Invalid file", http.StatusBadRequest)
+			return
+		}
+		if err == nil {
+			defer upload.Close()
+			file = upload
+			fileName = header.Filename
+			contentType = header.Header.Get("Content-Type")
+		}
+	} else {
+		var req submissionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		submission.Body = req.Body
+		submission.Links = req.Links
+	}
+
+	menteeID := r.Context().Value("userID").(int)
+
+	err = h.service.Submit(r.Context(), menteeID, assignmentID, requestID, submission, fileName, contentType, file)
+	if errors.Is(err, repository.ErrAssignmentNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrSubmissionConflict) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, submission)
+}
+
+// ListSubmissions returns every submission round for an assignment in a mentorship
+func (h *AssignmentHandler) ListSubmissions(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+	assignmentID, err := strconv.Atoi(chi.URLParam(r, "assignmentId"))
+	if err != nil {
+		http.Error(w, "Invalid assignment ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	submissions, err := h.service.ListSubmissions(r.Context(), userID, assignmentID, requestID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, submissions)
+}
+
+// DownloadSubmissionFile streams the file attached to a submission
+func (h *AssignmentHandler) DownloadSubmissionFile(w http.ResponseWriter, r *http.Request) {
+	submissionID, err := strconv.Atoi(chi.URLParam(r, "submissionId"))
+	if err != nil {
+		http.Error(w, "Invalid submission ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	submission, content, err := h.service.OpenSubmissionFile(r.Context(), userID, submissionID)
+	if errors.Is(err, repository.ErrSubmissionNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", submission.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", submission.FileName))
+	w.Header().Set("Content-Length", strconv.FormatInt(submission.SizeBytes, 10))
+	io.Copy(w, content)
+}
+
+// Review scores a submission against its rubric and approves it or requests changes
+func (h *AssignmentHandler) Review(w http.ResponseWriter, r *http.Request) {
+	submissionID, err := strconv.Atoi(chi.URLParam(r, "submissionId"))
+	if err != nil {
+		http.Error(w, "Invalid submission ID", http.StatusBadRequest)
+		return
+	}
+
+	var req reviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	submission, err := h.service.Review(r.Context(), mentorID, submissionID, &models.SubmissionReview{
+		Scores:   req.Scores,
+		Comment:  req.Comment,
+		Decision: req.Decision,
+	})
+	if errors.Is(err, repository.ErrSubmissionNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrAlreadyReviewed) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, submission)
+}
+
+// ListPendingReviews returns the submissions waiting for the mentor's review
+func (h *AssignmentHandler) ListPendingReviews(w http.ResponseWriter, r *http.Request) {
+	mentorID := r.Context().Value("userID").(int)
+
+	pending, err := h.service.ListPendingReviews(r.Context(), mentorID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, pending)
+}
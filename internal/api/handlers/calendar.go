@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+)
+
+type CalendarHandler struct {
+	service services.ICalendarService
+}
+
+func NewCalendarHandler(service services.ICalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+	}
+}
+
+// GetFeed returns the mentor's connected calendar and its sync status
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	mentorID := r.Context().Value("userID").(int)
+
+	feed, err := h.service.GetFeed(r.Context(), mentorID)
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, redactFeed(feed))
+}
+
+// SetFeed connects (or replaces) the CalDAV or ICS calendar used to block availability
+func (h *CalendarHandler) SetFeed(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		URL      string `json:"url"`
+		FeedType string `json:"feed_type"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	feed, err := h.service.SetFeed(r.Context(), mentorID, req.URL, req.FeedType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, redactFeed(feed))
+}
+
+// RemoveFeed disconnects the mentor's calendar
+func (h *CalendarHandler) RemoveFeed(w http.ResponseWriter, r *http.Request) {
+	mentorID := r.Context().Value("userID").(int)
+
+	err := h.service.RemoveFeed(r.Context(), mentorID)
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SyncFeed triggers an immediate re-import of the mentor's calendar
+func (h *CalendarHandler) SyncFeed(w http.ResponseWriter, r *http.Request) {
+	mentorID := r.Context().Value("userID").(int)
+
+	err := h.service.SyncMentor(r.Context(), mentorID)
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]string{"message": "Calendar synced successfully"})
+}
+
+// redactFeed hides any credentials embedded in the feed URL before it is returned
+func redactFeed(feed *models.CalendarFeed) *models.CalendarFeed {
+	redacted := *feed
+	if u, err := url.Parse(feed.URL); err == nil {
+		redacted.URL = u.Redacted()
+	}
+	return &redacted
+}
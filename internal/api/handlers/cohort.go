@@ -0,0 +1,469 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CohortHandler struct {
+	service services.ICohortService
+}
+
+func NewCohortHandler(service services.ICohortService) *CohortHandler {
+	return &CohortHandler{
+		service: service,
+	}
+}
+
+// CreateCohort schedules a new cohort of one of the logged-in mentor's programs
+func (h *CohortHandler) CreateCohort(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Name               string `json:"name"`
+		EnrollmentOpensAt  string `json:"enrollment_opens_at"`  // RFC3339
+		EnrollmentClosesAt string `json:"enrollment_closes_at"` // RFC3339
+		StartsOn           string `json:"starts_on"`            // YYYY-MM-DD
+		EndsOn             string `json:"ends_on"`              // YYYY-MM-DD
+		Capacity           int    `json:"capacity"`             // Defaults to the program's max mentees
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	opensAt, err := time.Parse(time.RFC3339, req.EnrollmentOpensAt)
+	if err != nil {
+		http.Error(w, "Invalid enrollment open time format", http.StatusBadRequest)
+		return
+	}
+	closesAt, err := time.Parse(time.RFC3339, req.EnrollmentClosesAt)
+	if err != nil {
+		http.Error(w, "Invalid enrollment close time format", http.StatusBadRequest)
+		return
+	}
+	startsOn, err := time.Parse(dueDateLayout, req.StartsOn)
+	if err != nil {
+		http.Error(w, "Invalid start date format", http.StatusBadRequest)
+		return
+	}
+	endsOn, err := time.Parse(dueDateLayout, req.EndsOn)
+	if err != nil {
+		http.Error(w, "Invalid end date format", http.StatusBadRequest)
+		return
+	}
+
+	cohort := &models.Cohort{
+		Name:               req.Name,
+		EnrollmentOpensAt:  opensAt,
+		EnrollmentClosesAt: closesAt,
+		StartsOn:           startsOn,
+		EndsOn:             endsOn,
+		Capacity:           req.Capacity,
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.CreateCohort(r.Context(), mentorID, programID, cohort)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, cohort)
+}
+
+// ListProgramCohorts returns the cohorts of a program
+func (h *CohortHandler) ListProgramCohorts(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	cohorts, err := h.service.ListProgramCohorts(r.Context(), programID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, cohorts)
+}
+
+// GetCohort returns a cohort's schedule and enrollment count
+func (h *CohortHandler) GetCohort(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	cohort, err := h.service.GetCohort(r.Context(), cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, cohort)
+}
+
+// CancelCohort calls off one of the logged-in mentor's cohorts
+func (h *CohortHandler) CancelCohort(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.CancelCohort(r.Context(), mentorID, cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]string{"message": "Cohort cancelled successfully"})
+}
+
+// Enroll takes a seat in a cohort for the logged-in mentee
+func (h *CohortHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	menteeID := r.Context().Value("userID").(int)
+
+	err = h.service.Enroll(r.Context(), menteeID, cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrCohortFull) || errors.Is(err, repository.ErrAlreadyEnrolled) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, map[string]string{"message": "Enrolled successfully"})
+}
+
+// Withdraw gives up the logged-in mentee's seat in a cohort
+func (h *CohortHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	menteeID := r.Context().Value("userID").(int)
+
+	err = h.service.Withdraw(r.Context(), menteeID, cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) || errors.Is(err, repository.ErrNotEnrolled) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMenteeCohorts returns the cohorts the logged-in mentee is enrolled in
+func (h *CohortHandler) ListMenteeCohorts(w http.ResponseWriter, r *http.Request) {
+	menteeID := r.Context().Value("userID").(int)
+
+	cohorts, err := h.service.ListMenteeCohorts(r.Context(), menteeID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, cohorts)
+}
+
+// GetRoster returns a cohort's members and their attendance
+func (h *CohortHandler) GetRoster(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	roster, err := h.service.GetRoster(r.Context(), userID, cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, roster)
+}
+
+// ScheduleSession adds a group session to one of the logged-in mentor's cohorts
+func (h *CohortHandler) ScheduleSession(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title      string `json:"title"`
+		Topic      string `json:"topic"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
+		MeetingURL string `json:"meeting_url"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		http.Error(w, "Invalid start time format", http.StatusBadRequest)
+		return
+	}
+
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		http.Error(w, "Invalid end time format", http.StatusBadRequest)
+		return
+	}
+
+	session := &models.CohortSession{
+		Title:      req.Title,
+		Topic:      req.Topic,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		MeetingURL: req.MeetingURL,
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.ScheduleSession(r.Context(), mentorID, cohortID, session)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, session)
+}
+
+// CancelSession calls off an upcoming group session
+func (h *CohortHandler) CancelSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.CancelSession(r.Context(), mentorID, sessionID)
+	if errors.Is(err, repository.ErrCohortSessionNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]string{"message": "Session cancelled successfully"})
+}
+
+// ListSessions returns a cohort's group sessions
+func (h *CohortHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	sessions, err := h.service.ListSessions(r.Context(), userID, cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, sessions)
+}
+
+// GetSession returns a group session with its attendance
+func (h *CohortHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	session, err := h.service.GetSession(r.Context(), userID, sessionID)
+	if errors.Is(err, repository.ErrCohortSessionNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, session)
+}
+
+// RecordAttendance saves who attended a group session
+func (h *CohortHandler) RecordAttendance(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Attendance []struct {
+			MenteeID int    `json:"mentee_id"`
+			Status   string `json:"status"` // present, absent or excused
+		} `json:"attendance"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	records := make([]*models.AttendanceRecord, 0, len(req.Attendance))
+	for _, entry := range req.Attendance {
+		records = append(records, &models.AttendanceRecord{
+			MenteeID: entry.MenteeID,
+			Status:   entry.Status,
+		})
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	session, err := h.service.RecordAttendance(r.Context(), mentorID, sessionID, records)
+	if errors.Is(err, repository.ErrCohortSessionNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, session)
+}
+
+// PostAnnouncement sends a message to everyone in one of the logged-in mentor's cohorts
+func (h *CohortHandler) PostAnnouncement(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	announcement := &models.CohortAnnouncement{
+		Title: req.Title,
+		Body:  req.Body,
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.PostAnnouncement(r.Context(), mentorID, cohortID, announcement)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, announcement)
+}
+
+// ListAnnouncements returns a cohort's announcements, newest first
+func (h *CohortHandler) ListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(chi.URLParam(r, "cohortId"))
+	if err != nil {
+		http.Error(w, "Invalid cohort ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	announcements, err := h.service.ListAnnouncements(r.Context(), userID, cohortID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, announcements)
+}
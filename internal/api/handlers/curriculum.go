@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxUploadSize caps the size of a single uploaded file
+const maxUploadSize = 20 << 20
+
+type CurriculumHandler struct {
+	service services.ICurriculumService
+}
+
+func NewCurriculumHandler(service services.ICurriculumService) *CurriculumHandler {
+	return &CurriculumHandler{
+		service: service,
+	}
+}
+
+type moduleRequest struct {
+	Title      string   `json:"title"`
+	Summary    string   `json:"summary"`
+	Objectives []string `json:"objectives"`
+	Assignment string   `json:"assignment"`
+	Position   int      `json:"position"`
+}
+
+func (req *moduleRequest) toModule() *models.CurriculumModule {
+	return &models.CurriculumModule{
+		Title:      req.Title,
+		Summary:    req.Summary,
+		Objectives: req.Objectives,
+		Assignment: req.Assignment,
+		Position:   req.Position,
+	}
+}
+
+// GetDraft returns the editable draft of a program's curriculum, creating it if needed
+func (h *CurriculumHandler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	draft, err := h.service.GetDraft(r.Context(), mentorID, programID)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, draft)
+}
+
+// ListVersions returns the version history of a program's curriculum
+func (h *CurriculumHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	versions, err := h.service.ListVersions(r.Context(), mentorID, programID)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, versions)
+}
+
+// GetVersion returns one version of a curriculum with its modules
+func (h *CurriculumHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	versionID, err := strconv.Atoi(chi.URLParam(r, "versionId"))
+	if err != nil {
+		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	version, err := h.service.GetVersion(r.Context(), mentorID, versionID)
+	if errors.Is(err, repository.ErrCurriculumNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, version)
+}
+
+// Publish freezes a draft as the curriculum new mentorships will follow
+func (h *CurriculumHandler) Publish(w http.ResponseWriter, r *http.Request) {
+	versionID, err := strconv.Atoi(chi.URLParam(r, "versionId"))
+	if err != nil {
+		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	version, err := h.service.Publish(r.Context(), mentorID, versionID)
+	if errors.Is(err, repository.ErrCurriculumNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, version)
+}
+
+// AddModule appends a module to a draft curriculum
+func (h *CurriculumHandler) AddModule(w http.ResponseWriter, r *http.Request) {
+	versionID, err := strconv.Atoi(chi.URLParam(r, "versionId"))
+	if err != nil {
+		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		return
+	}
+
+	var req moduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	module := req.toModule()
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.AddModule(r.Context(), mentorID, versionID, module)
+	if errors.Is(err, repository.ErrCurriculumNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, module)
+}
+
+// ReorderModules sets the order of a draft's modules
+func (h *CurriculumHandler) ReorderModules(w http.ResponseWriter, r *http.Request) {
+	versionID, err := strconv.Atoi(chi.URLParam(r, "versionId"))
+	if err != nil {
+		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		ModuleIDs []int `json:"module_ids"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.ReorderModules(r.Context(), mentorID, versionID, req.ModuleIDs)
+	if errors.Is(err, repository.ErrCurriculumNotFound) || errors.Is(err, repository.ErrModuleNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateModule replaces the content of a draft module
+func (h *CurriculumHandler) UpdateModule(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := strconv.Atoi(chi.URLParam(r, "moduleId"))
+	if err != nil {
+		http.Error(w, "Invalid module ID", http.StatusBadRequest)
+		return
+	}
+
+	var req moduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	module, err := h.service.UpdateModule(r.Context(), mentorID, moduleID, req.toModule())
+	if errors.Is(err, repository.ErrModuleNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, module)
+}
+
+// DeleteModule removes a module from a draft curriculum
+func (h *CurriculumHandler) DeleteModule(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := strconv.Atoi(chi.URLParam(r, "moduleId"))
+	if err != nil {
+		http.Error(w, "Invalid module ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.DeleteModule(r.Context(), mentorID, moduleID)
+	if errors.Is(err, repository.ErrModuleNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddLink attaches an external link to a draft module
+func (h *CurriculumHandler) AddLink(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := strconv.Atoi(chi.URLParam(r, "moduleId"))
+	if err != nil {
+		http.Error(w, "Invalid module ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	resource := &models.ModuleResource{
+		Title: req.Title,
+		URL:   req.URL,
+	}
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.AddLink(r.Context(), mentorID, moduleID, resource)
+	if errors.Is(err, repository.ErrModuleNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, resource)
+}
+
+// UploadFile attaches an uploaded file to a draft module. Expects a multipart
+// form with a "file" part and an optional "title" field.
+func (h *CurriculumHandler) UploadFile(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := strconv.Atoi(chi.URLParam(r, "moduleId"))
+	if err != nil {
+		http.Error(w, "Invalid module ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Invalid or missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	mentorID := r.Context().Value("userID").(int)
+
+	resource, err := h.service.UploadFile(r.Context(), mentorID, moduleID,
+		r.FormValue("title"), header.Filename, header.Header.Get("Content-Type"), file)
+	if errors.Is(err, repository.ErrModuleNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, resource)
+}
+
+// DeleteResource detaches a resource from a draft module
+func (h *CurriculumHandler) DeleteResource(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.Atoi(chi.URLParam(r, "resourceId"))
+	if err != nil {
+		http.Error(w, "Invalid resource ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.DeleteResource(r.Context(), mentorID, resourceID)
+	if errors.Is(err, repository.ErrResourceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DownloadResource streams an uploaded module file
+func (h *CurriculumHandler) DownloadResource(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.Atoi(chi.URLParam(r, "resourceId"))
+	if err != nil {
+		http.Error(w, "Invalid resource ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	resource, content, err := h.service.OpenResource(r.Context(), userID, resourceID)
+	if errors.Is(err, repository.ErrResourceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", resource.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resource.FileName))
+	w.Header().Set("Content-Length", strconv.FormatInt(resource.SizeBytes, 10))
+	io.Copy(w, content)
+}
+
+// GetProgress returns the curriculum a mentorship follows and the mentee's progress through it
+func (h *CurriculumHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	progress, err := h.service.GetProgress(r.Context(), userID, requestID)
+	if errors.Is(err, repository.ErrNoPublishedCurriculum) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, progress)
+}
+
+// SetModuleCompletion marks a curriculum module done or not done for the logged-in mentee
+func (h *CurriculumHandler) SetModuleCompletion(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+	moduleID, err := strconv.Atoi(chi.URLParam(r, "moduleId"))
+	if err != nil {
+		http.Error(w, "Invalid module ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Done bool `json:"done"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	menteeID := r.Context().Value("userID").(int)
+
+	progress, err := h.service.SetModuleCompletion(r.Context(), menteeID, requestID, moduleID, req.Done)
+	if errors.Is(err, repository.ErrModuleNotFound) || errors.Is(err, repository.ErrNoPublishedCurriculum) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, progress)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type FeaturedHandler struct {
+	service services.IFeaturedService
+}
+
+func NewFeaturedHandler(service services.IFeaturedService) *FeaturedHandler {
+	return &FeaturedHandler{
+		service: service,
+	}
+}
+
+// featureRequest is the body of featured mentor create and update requests
+type featureRequest struct {
+	MentorID int        `json:"mentor_id"`
+	Headline string     `json:"headline"`
+	Position int        `json:"position"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+func (req *featureRequest) feature() *models.FeaturedMentor {
+	feature := &models.FeaturedMentor{
+		MentorID: req.MentorID,
+		Headline: req.Headline,
+		Position: req.Position,
+		EndsAt:   req.EndsAt,
+	}
+	if req.StartsAt != nil {
+		feature.StartsAt = *req.StartsAt
+	}
+	return feature
+}
+
+// GetFeaturedMentors returns the mentors currently shown on the home page
+func (h *FeaturedHandler) GetFeaturedMentors(w http.ResponseWriter, r *http.Request) {
+	mentors, err := h.service.GetFeaturedMentors(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, mentors)
+}
+
+// ListFeatures returns every curated entry, including past and scheduled ones
+func (h *FeaturedHandler) ListFeatures(w http.ResponseWriter, r *http.Request) {
+	features, err := h.service.ListFeatures(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, features)
+}
+
+// CreateFeature curates a mentor onto the home page
+func (h *FeaturedHandler) CreateFeature(w http.ResponseWriter, r *http.Request) {
+	var req featureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	feature, err := h.service.CreateFeature(r.Context(), adminID, req.feature())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, feature)
+}
+
+// UpdateFeature changes a curated entry's headline, position or display window
+func (h *FeaturedHandler) UpdateFeature(w http.ResponseWriter, r *http.Request) {
+	featureID, err := strconv.Atoi(chi.URLParam(r, "featureId"))
+	if err != nil {
+		http.Error(w, "Invalid featured mentor ID", http.StatusBadRequest)
+		return
+	}
+
+	var req featureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	feature, err := h.service.UpdateFeature(r.Context(), featureID, req.feature())
+	if errors.Is(err, repository.ErrFeaturedMentorNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, feature)
+}
+
+// DeleteFeature removes a curated entry
+func (h *FeaturedHandler) DeleteFeature(w http.ResponseWriter, r *http.Request) {
+	featureID, err := strconv.Atoi(chi.URLParam(r, "featureId"))
+	if err != nil {
+		http.Error(w, "Invalid featured mentor ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.DeleteFeature(r.Context(), featureID)
+	if errors.Is(err, repository.ErrFeaturedMentorNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMode returns the featured mentors mode
+func (h *FeaturedHandler) GetMode(w http.ResponseWriter, r *http.Request) {
+	mode, err := h.service.GetMode(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]string{"mode": mode})
+}
+
+// SetMode switches between curated-only and automatic rotation
+func (h *FeaturedHandler) SetMode(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Mode string `json:"mode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetMode(r.Context(), req.Mode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]string{"mode": req.Mode})
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type FeedbackHandler struct {
+	service services.IFeedbackService
+}
+
+func NewFeedbackHandler(service services.IFeedbackService) *FeedbackHandler {
+	return &FeedbackHandler{
+		service: service,
+	}
+}
+
+// SubmitFeedback records the logged-in participant's feedback on a completed session
+func (h *FeedbackHandler) SubmitFeedback(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Rating  int    `json:"rating"`
+		Comment string `json:"comment"`
+		Public  bool   `json:"public"` // Offer the comment as a public testimonial
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	feedback, err := h.service.SubmitFeedback(r.Context(), userID, sessionID, req.Rating, req.Comment, req.Public)
+	if errors.Is(err, repository.ErrFeedbackExists) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, feedback)
+}
+
+// UpdateFeedback edits the caller's feedback while the edit window is open
+func (h *FeedbackHandler) UpdateFeedback(w http.ResponseWriter, r *http.Request) {
+	feedbackID, err := strconv.Atoi(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		http.Error(w, "Invalid feedback ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Rating  int    `json:"rating"`
+		Comment string `json:"comment"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	feedback, err := h.service.UpdateFeedback(r.Context(), userID, feedbackID, req.Rating, req.Comment)
+	if errors.Is(err, repository.ErrFeedbackNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, feedback)
+}
+
+// GetSessionFeedback returns the feedback both participants left on a session
+func (h *FeedbackHandler) GetSessionFeedback(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	feedback, err := h.service.GetSessionFeedback(r.Context(), userID, sessionID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, feedback)
+}
+
+// ListMentorFeedback returns the logged-in mentor's rating summary and a page of feedback
+func (h *FeedbackHandler) ListMentorFeedback(w http.ResponseWriter, r *http.Request) {
+	mentorID := r.Context().Value("userID").(int)
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+	summary, err := h.service.GetRatingSummary(r.Context(), mentorID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	feedback, err := h.service.ListMentorFeedback(r.Context(), mentorID, page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"summary":  summary,
+		"feedback": feedback,
+	})
+}
+
+// GetMentorRating returns a mentor's aggregated rating
+func (h *FeedbackHandler) GetMentorRating(w http.ResponseWriter, r *http.Request) {
+	mentorID, err := strconv.Atoi(chi.URLParam(r, "mentorId"))
+	if err != nil {
+		http.Error(w, "Invalid mentor ID", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.service.GetRatingSummary(r.Context(), mentorID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, summary)
+}
+
+// SetVisibility lets a mentee opt their review in or out of public display
+func (h *FeedbackHandler) SetVisibility(w http.ResponseWriter, r *http.Request) {
+	feedbackID, err := strconv.Atoi(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		http.Error(w, "Invalid feedback ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Public bool `json:"public"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	feedback, err := h.service.SetVisibility(r.Context(), userID, feedbackID, req.Public)
+	if errors.Is(err, repository.ErrFeedbackNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, feedback)
+}
+
+// ReplyToFeedback stores the logged-in mentor's public reply to a review
+func (h *FeedbackHandler) ReplyToFeedback(w http.ResponseWriter, r *http.Request) {
+	feedbackID, err := strconv.Atoi(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		http.Error(w, "Invalid feedback ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Reply string `json:"reply"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	feedback, err := h.service.ReplyToFeedback(r.Context(), mentorID, feedbackID, req.Reply)
+	if errors.Is(err, repository.ErrFeedbackNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, feedback)
+}
+
+// ListModerationQueue returns public reviews awaiting moderation; ?status= narrows the queue
+func (h *FeedbackHandler) ListModerationQueue(w http.ResponseWriter, r *http.Request) {
+	reviews, err := h.service.ListModerationQueue(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, reviews)
+}
+
+// ModerateReview approves, hides or flags a review
+func (h *FeedbackHandler) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	feedbackID, err := strconv.Atoi(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		http.Error(w, "Invalid feedback ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Action string `json:"action"` // approve, hide or flag
+		Note   string `json:"note"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	err = h.service.ModerateReview(r.Context(), adminID, feedbackID, req.Action, req.Note)
+	if errors.Is(err, repository.ErrFeedbackNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]string{"message": "Review moderated successfully"})
+}
+
+// ListTestimonials returns a mentor's approved public reviews
+func (h *FeedbackHandler) ListTestimonials(w http.ResponseWriter, r *http.Request) {
+	mentorID, err := strconv.Atoi(chi.URLParam(r, "mentorId"))
+	if err != nil {
+		http.Error(w, "Invalid mentor ID", http.StatusBadRequest)
+		return
+	}
+
+	testimonials, err := h.service.ListTestimonials(r.Context(), mentorID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, testimonials)
+}
@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type GoalHandler struct {
+	service services.IGoalService
+}
+
+func NewGoalHandler(service services.IGoalService) *GoalHandler {
+	return &GoalHandler{
+		service: service,
+	}
+}
+
+type goalRequest struct {
+	Title      string `json:"title"`
+	Specific   string `json:"specific"`
+	Measurable string `json:"measurable"`
+	Achievable string `json:"achievable"`
+	Relevant   string `json:"relevant"`
+	TimeBound  string `json:"time_bound"`
+	TargetDate string `json:"target_date"` // YYYY-MM-DD
+	Status     string `json:"status"`
+}
+
+func (req *goalRequest) toGoal() (*models.Goal, error) {
+	goal := &models.Goal{
+		Title:      req.Title,
+		Specific:   req.Specific,
+		Measurable: req.Measurable,
+		Achievable: req.Achievable,
+		Relevant:   req.Relevant,
+		TimeBound:  req.TimeBound,
+		Status:     req.Status,
+	}
+	if req.TargetDate != "" {
+		target, err := time.Parse(dueDateLayout, req.TargetDate)
+		if err != nil {
+			return nil, errors.New("Invalid target date format")
+		}
+		goal.TargetDate = &target
+	}
+	return goal, nil
+}
+
+// ListGoals returns a mentorship's goals with their milestones and progress
+func (h *GoalHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	goals, err := h.service.ListGoals(r.Context(), userID, requestID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, goals)
+}
+
+// CreateGoal adds a SMART goal to an active mentorship
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	var req goalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	goal, err := req.toGoal()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.CreateGoal(r.Context(), userID, requestID, goal); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, goal)
+}
+
+// UpdateGoal replaces a goal's details or changes its status
+func (h *GoalHandler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(chi.URLParam(r, "goalId"))
+	if err != nil {
+		http.Error(w, "Invalid goal ID", http.StatusBadRequest)
+		return
+	}
+
+	var req goalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	update, err := req.toGoal()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	goal, err := h.service.UpdateGoal(r.Context(), userID, goalID, update)
+	if errors.Is(err, repository.ErrGoalNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, goal)
+}
+
+// AddMilestone adds a milestone to a goal
+func (h *GoalHandler) AddMilestone(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(chi.URLParam(r, "goalId"))
+	if err != nil {
+		http.Error(w, "Invalid goal ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Progress    int    `json:"progress"`
+		DueDate     string `json:"due_date"` // YYYY-MM-DD
+		Position    int    `json:"position"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	milestone := &models.Milestone{
+		Title:       req.Title,
+		Description: req.Description,
+		Progress:    req.Progress,
+		Position:    req.Position,
+	}
+	if req.DueDate != "" {
+		due, err := time.Parse(dueDateLayout, req.DueDate)
+		if err != nil {
+			http.Error(w, "Invalid due date format", http.StatusBadRequest)
+			return
+		}
+		milestone.DueDate = &due
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	err = h.service.AddMilestone(r.Context(), userID, goalID, milestone)
+	if errors.Is(err, repository.ErrGoalNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, milestone)
+}
+
+// UpdateMilestone edits a milestone or records its progress; omitted fields are left unchanged
+func (h *GoalHandler) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	milestoneID, err := strconv.Atoi(chi.URLParam(r, "milestoneId"))
+	if err != nil {
+		http.Error(w, "Invalid milestone ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title       *string `json:"title"`
+		Description *string `json:"description"`
+		Progress    *int    `json:"progress"`
+		DueDate     *string `json:"due_date"` // YYYY-MM-DD, or "" to clear
+		Position    *int    `json:"position"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	update := &models.MilestoneUpdate{
+		Title:       req.Title,
+		Description: req.Description,
+		Progress:    req.Progress,
+		Position:    req.Position,
+	}
+	if req.DueDate != nil {
+		if *req.DueDate == "" {
+			update.ClearDue = true
+		} else {
+			due, err := time.Parse(dueDateLayout, *req.DueDate)
+			if err != nil {
+				http.Error(w, "Invalid due date format", http.StatusBadRequest)
+				return
+			}
+			update.DueDate = &due
+		}
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	milestone, err := h.service.UpdateMilestone(r.Context(), userID, milestoneID, update)
+	if errors.Is(err, repository.ErrMilestoneNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, milestone)
+}
+
+// DeleteMilestone removes a milestone
+func (h *GoalHandler) DeleteMilestone(w http.ResponseWriter, r *http.Request) {
+	milestoneID, err := strconv.Atoi(chi.URLParam(r, "milestoneId"))
+	if err != nil {
+		http.Error(w, "Invalid milestone ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	err = h.service.DeleteMilestone(r.Context(), userID, milestoneID)
+	if errors.Is(err, repository.ErrMilestoneNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckIn records progress on a goal, optionally linked to a session
+func (h *GoalHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(chi.URLParam(r, "goalId"))
+	if err != nil {
+		http.Error(w, "Invalid goal ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		SessionID *int   `json:"session_id"`
+		Progress  int    `json:"progress"`
+		Note      string `json:"note"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	checkIn := &models.CheckIn{
+		SessionID: req.SessionID,
+		Progress:  req.Progress,
+		Note:      req.Note,
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	err = h.service.CheckIn(r.Context(), userID, goalID, checkIn)
+	if errors.Is(err, repository.ErrGoalNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, checkIn)
+}
+
+// GetTimeline returns a mentorship's progress events, newest first
+func (h *GoalHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	events, err := h.service.GetTimeline(r.Context(), userID, requestID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, events)
+}
@@ -6,18 +6,27 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"time"
 
+	"mentorApp/internal/models"
 	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type HomeHandler struct {
 	templates     *template.Template
 	userService   services.IUserService
 	mentorService services.IMentorshipService
+	goalService   services.IGoalService
+	recommender   services.IRecommendationService
+	featured      services.IFeaturedService
+	jobs          services.IJobService
+	baseURL       string // public address for absolute links in structured data
 }
 
-func NewHomeHandler(userService services.IUserService, mentorService services.IMentorshipService) *HomeHandler {
+func NewHomeHandler(userService services.IUserService, mentorService services.IMentorshipService, goalService services.IGoalService, recommender services.IRecommendationService, featured services.IFeaturedService, jobs services.IJobService, baseURL string) *HomeHandler {
 	// Create a new template instance
 	tmpl := template.New("")
 
@@ -57,6 +66,11 @@ func NewHomeHandler(userService services.IUserService, mentorService services.IM
 		templates:     tmpl,
 		userService:   userService,
 		mentorService: mentorService,
+		goalService:   goalService,
+		recommender:   recommender,
+		featured:      featured,
+		jobs:          jobs,
+		baseURL:       baseURL,
 	}
 }
 
@@ -82,37 +96,117 @@ func (h *HomeHandler) Get(w http.ResponseWriter, r *http.Request) {
 		"Email":   "contact@nexusmentors.org",
 	}
 
-	if userID := r.Context().Value("userID"); userID != nil {
-		featuredMentors, err := h.mentorService.GetFeaturedMentors(r.Context())
-		if err == nil {
-			data["FeaturedMentors"] = featuredMentors
-		}
+	featuredMentors, err := h.featured.GetFeaturedMentors(r.Context())
+	if err != nil {
+		log.Printf("Failed to load featured mentors: %v", err)
+	} else {
+		data["FeaturedMentors"] = featuredMentors
 	}
 
 	h.renderTemplate(w, "index.html", data)
 }
 
+// GetJobBoard renders one page of active jobs, filtered by the query parameters
 func (h *HomeHandler) GetJobBoard(w http.ResponseWriter, r *http.Request) {
-	jobs, err := h.mentorService.GetAvailableJobs(r.Context())
+	query, err := parseJobSearchQuery(r.URL.Query())
 	if err != nil {
-		http.Error(w, "Error fetching jobs", http.StatusInternalServerError)
+		h.renderError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.jobs.SearchJobs(r.Context(), query)
+	if err != nil {
+		h.renderError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	data := map[string]interface{}{
-		"Website":     "NEXUS Mentorship Platform",
-		"Jobs":        jobs,
-		"LastUpdated": time.Now().Format(time.RFC822),
+		"Website":          "NEXUS Mentorship Platform",
+		"Jobs":             results.Jobs,
+		"Results":          results,
+		"Query":            query,
+		"JobTypes":         []string{models.JobType.FullTime, models.JobType.PartTime, models.JobType.Contract},
+		"ExperienceLevels": []string{models.ExperienceLevel.Entry, models.ExperienceLevel.Mid, models.ExperienceLevel.Senior},
+		"RemotePolicies":   []string{models.RemotePolicy.Remote, models.RemotePolicy.Hybrid, models.RemotePolicy.OnSite},
+		"AtomFeedURL":      jobFeedURL(query, jobFeedAtom),
+		"RSSFeedURL":       jobFeedURL(query, jobFeedRSS),
+		"JSONFeedURL":      jobFeedURL(query, jobFeedJSON),
+		"LastUpdated":      time.Now().Format(time.RFC822),
+	}
+	if results.Page > 1 {
+		data["PrevURL"] = jobBoardPageURL(query, results.Page-1)
+	}
+	if results.Page < results.TotalPages {
+		data["NextURL"] = jobBoardPageURL(query, results.Page+1)
 	}
 
 	h.renderTemplate(w, "job_board.html", data)
 }
 
+// GetJobDetail renders an active job's public page with its schema.org
+// JobPosting structured data
+func (h *HomeHandler) GetJobDetail(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "jobId"))
+	if err != nil {
+		h.renderError(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobs.GetJob(r.Context(), jobID)
+	if err != nil {
+		h.renderError(w, err.Error(), jobErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	data := map[string]interface{}{
+		"Website":    "NEXUS Mentorship Platform",
+		"Job":        job,
+		"JobPosting": newJobPosting(h.baseURL, job),
+	}
+
+	h.renderTemplate(w, "job_detail.html", data)
+}
+
+// GetJobApplication renders the application form for an active job, or the
+// status of the current user's live application to it
+func (h *HomeHandler) GetJobApplication(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "jobId"))
+	if err != nil {
+		h.renderError(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobs.GetJob(r.Context(), jobID)
+	if err != nil {
+		h.renderError(w, err.Error(), jobErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	data := map[string]interface{}{
+		"Website": "NEXUS Mentorship Platform",
+		"Job":     job,
+	}
+
+	userID := r.Context().Value("userID").(int)
+	applications, err := h.jobs.ListMyApplications(r.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to load applications of user %d: %v", userID, err)
+	}
+	for _, app := range applications {
+		if app.JobID == jobID && app.Status != models.ApplicationStatus.Withdrawn {
+			data["Application"] = app
+			break
+		}
+	}
+
+	h.renderTemplate(w, "job_apply.html", data)
+}
+
 func (h *HomeHandler) GetMenteeDashboard(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
 
-	dataChan := make(chan map[string]interface{}, 4)
-	errChan := make(chan error, 4)
+	dataChan := make(chan map[string]interface{}, 5)
+	errChan := make(chan error, 5)
 
 	go func() {
 		profile, err := h.userService.GetUserProfile(r.Context(), userID)
@@ -142,7 +236,7 @@ func (h *HomeHandler) GetMenteeDashboard(w http.ResponseWriter, r *http.Request)
 	}()
 
 	go func() {
-		mentors, err := h.mentorService.GetRecommendedMentors(r.Context(), userID)
+		mentors, err := h.recommender.GetRecommendations(r.Context(), userID, 0)
 		if err != nil {
 			errChan <- err
 			return
@@ -150,10 +244,19 @@ func (h *HomeHandler) GetMenteeDashboard(w http.ResponseWriter, r *http.Request)
 		dataChan <- map[string]interface{}{"RecommendedMentors": mentors}
 	}()
 
+	go func() {
+		timeline, err := h.goalService.GetDashboardTimeline(r.Context(), userID)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		dataChan <- map[string]interface{}{"ProgressTimeline": timeline}
+	}()
+
 	dashboardData := make(map[string]interface{})
 	dashboardData["Website"] = "NEXUS Mentorship Platform"
 
-	for i := 0; i < 4; i++ {
+	for i := 0; i < 5; i++ {
 		select {
 		case data := <-dataChan:
 			for k, v := range data {
@@ -172,8 +275,8 @@ func (h *HomeHandler) GetMenteeDashboard(w http.ResponseWriter, r *http.Request)
 func (h *HomeHandler) GetMentorDashboard(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
 
-	dataChan := make(chan map[string]interface{}, 5)
-	errChan := make(chan error, 5)
+	dataChan := make(chan map[string]interface{}, 7)
+	errChan := make(chan error, 7)
 
 	go func() {
 		profile, err := h.userService.GetUserProfile(r.Context(), userID)
@@ -220,10 +323,28 @@ func (h *HomeHandler) GetMentorDashboard(w http.ResponseWriter, r *http.Request)
 		dataChan <- map[string]interface{}{"Analytics": analytics}
 	}()
 
+	go func() {
+		timeline, err := h.goalService.GetDashboardTimeline(r.Context(), userID)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		dataChan <- map[string]interface{}{"ProgressTimeline": timeline}
+	}()
+
+	go func() {
+		referrals, err := h.jobs.ListMyReferrals(r.Context(), userID)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		dataChan <- map[string]interface{}{"Referrals": referrals}
+	}()
+
 	dashboardData := make(map[string]interface{})
 	dashboardData["Website"] = "NEXUS Mentorship Platform"
 
-	for i := 0; i < 5; i++ {
+	for i := 0; i < 7; i++ {
 		select {
 		case data := <-dataChan:
 			for k, v := range data {
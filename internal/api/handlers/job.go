@@ -0,0 +1,603 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JobHandler struct {
+	service services.IJobService
+	// baseURL is the configured public address absolute feed links are built
+	// from; the request's Host header cannot be trusted in cached responses
+	baseURL string
+}
+
+func NewJobHandler(service services.IJobService, baseURL string) *JobHandler {
+	return &JobHandler{
+		service: service,
+		baseURL: baseURL,
+	}
+}
+
+// jobErrorStatus maps job and application errors to HTTP statuses, falling back to fallback
+func jobErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, repository.ErrJobNotFound),
+		errors.Is(err, repository.ErrJobApplicationNotFound),
+		errors.Is(err, repository.ErrSavedJobSearchNotFound),
+		errors.Is(err, repository.ErrJobReferralNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrJobApplicationExists),
+		errors.Is(err, repository.ErrJobApplicationChanged),
+		errors.Is(err, repository.ErrJobReferralExists):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+func jobIDParam(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "jobId"))
+}
+
+func applicationIDParam(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "applicationId"))
+}
+
+// parseJobSearchQuery reads job board filters from query parameters
+func parseJobSearchQuery(params url.Values) (*models.JobSearchQuery, error) {
+	query := &models.JobSearchQuery{
+		Text:            params.Get("q"),
+		Location:        params.Get("location"),
+		JobType:         params.Get("job_type"),
+		ExperienceLevel: params.Get("experience_level"),
+		RemotePolicy:    params.Get("remote_policy"),
+	}
+
+	var err error
+	if value := params.Get("page"); value != "" {
+		if query.Page, err = strconv.Atoi(value); err != nil {
+			return nil, errors.New("Invalid page")
+		}
+	}
+	if value := params.Get("page_size"); value != "" {
+		if query.PageSize, err = strconv.Atoi(value); err != nil {
+			return nil, errors.New("Invalid page_size")
+		}
+	}
+
+	return query, nil
+}
+
+// jobBoardPageURL links to another page of the job board keeping its filters
+func jobBoardPageURL(query *models.JobSearchQuery, page int) string {
+	params := jobFilterParams(query)
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	if encoded := params.Encode(); encoded != "" {
+		return "/jobs?" + encoded
+	}
+	return "/jobs"
+}
+
+// jobFilterParams encodes a query's filters as the query parameters
+// parseJobSearchQuery reads
+func jobFilterParams(query *models.JobSearchQuery) url.Values {
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"q":                query.Text,
+		"location":         query.Location,
+		"job_type":         query.JobType,
+		"experience_level": query.ExperienceLevel,
+		"remote_policy":    query.RemotePolicy,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	return params
+}
+
+// SearchJobs returns one page of active jobs as JSON, filtered by keyword,
+// location, job type, experience level and remote policy
+func (h *JobHandler) SearchJobs(w http.ResponseWriter, r *http.Request) {
+	query, err := parseJobSearchQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.service.SearchJobs(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, results)
+}
+
+// CreatePosting submits a job posting. Postings by mentors and employers wait
+// for moderation; postings by admins go live straight away.
+func (h *JobHandler) CreatePosting(w http.ResponseWriter, r *http.Request) {
+	var req models.Job
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	job, err := h.service.CreatePosting(r.Context(), userID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, job)
+}
+
+// ListMyPostings returns the current user's postings in every status
+func (h *JobHandler) ListMyPostings(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	jobs, err := h.service.ListPostings(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, jobs)
+}
+
+// UpdatePosting edits a posting's details
+func (h *JobHandler) UpdatePosting(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.Job
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	job, err := h.service.UpdatePosting(r.Context(), userID, jobID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, job)
+}
+
+// ListRevisions returns a posting's edit history
+func (h *JobHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	revisions, err := h.service.ListRevisions(r.Context(), userID, jobID)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusForbidden))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, revisions)
+}
+
+// RenewPosting keeps a posting on the board for another term
+func (h *JobHandler) RenewPosting(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	job, err := h.service.RenewJob(r.Context(), userID, jobID)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, job)
+}
+
+// ClosePosting takes a posting off the board for good
+func (h *JobHandler) ClosePosting(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.CloseJob(r.Context(), userID, jobID); err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusForbidden))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListJobs returns every posting for admins, optionally filtered by status;
+// status=pending is the moderation queue
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.ListJobs(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, jobs)
+}
+
+// ApproveJob publishes a pending posting
+func (h *JobHandler) ApproveJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	job, err := h.service.ApproveJob(r.Context(), adminID, jobID)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusConflict))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, job)
+}
+
+// RejectJob turns down a pending posting with a reason
+func (h *JobHandler) RejectJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	job, err := h.service.RejectJob(r.Context(), adminID, jobID, req.Reason)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, job)
+}
+
+// FeatureJob pins a posting to the top of the board or unpins it
+func (h *JobHandler) FeatureJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Featured bool `json:"featured"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.service.SetFeatured(r.Context(), jobID, req.Featured)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, job)
+}
+
+// ImportJobs creates and updates postings in bulk from a CSV or JSON file,
+// sent as the "file" part of a multipart form or as the request body. The
+// format comes from the "format" parameter, the file extension or the content
+// type; "mapping" is an optional JSON object mapping columns to job fields.
+// With dry_run=true it only reports what the import would change.
+func (h *JobHandler) ImportJobs(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+
+	format := r.URL.Query().Get("format")
+	var data io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+			http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+			return
+		}
+		upload, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "An import file is required", http.StatusBadRequest)
+			return
+		}
+		defer upload.Close()
+		data = upload
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+	} else if format == "" {
+		switch mediaType := r.Header.Get("Content-Type"); {
+		case strings.HasPrefix(mediaType, "text/csv"):
+			format = services.JobImportCSV
+		case strings.HasPrefix(mediaType, "application/json"):
+			format = services.JobImportJSON
+		}
+	}
+
+	var mapping map[string]string
+	if value := r.FormValue("mapping"); value != "" {
+		if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+			http.Error(w, "Invalid column mapping", http.StatusBadRequest)
+			return
+		}
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "Invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	result, err := h.service.ImportJobs(r.Context(), adminID, format, data, mapping, dryRun)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Valid rows are imported even when others fail; only an import where
+	// every row failed is an error. The report says which rows and why.
+	status := http.StatusOK
+	if !result.DryRun && result.Failed == len(result.Rows) {
+		status = http.StatusUnprocessableEntity
+	}
+	common.RespondJSON(w, status, result)
+}
+
+// Apply submits the current user's application to a job. Accepts either a JSON
+// body or a multipart form with "cover_letter", "resume_url" and an optional
+// "resume" file part.
+func (h *JobHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	app := &models.JobApplication{}
+	var fileName, contentType string
+	var file io.Reader
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+		if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+			http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+			return
+		}
+		app.CoverLetter = r.FormValue("cover_letter")
+		app.ResumeURL = r.FormValue("resume_url")
+
+		upload, header, err := r.FormFile("resume")
+		if err != nil && !errors.Is(err, http.ErrMissingFile) {
+			http.Error(w, "Invalid resume file", http.StatusBadRequest)
+			return
+		}
+		if err == nil {
+			defer upload.Close()
+			file = upload
+			fileName = header.Filename
+			contentType = header.Header.Get("Content-Type")
+		}
+	} else {
+		var req struct {
+			CoverLetter string `json:"cover_letter"`
+			ResumeURL   string `json:"resume_url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		app.CoverLetter = req.CoverLetter
+		app.ResumeURL = req.ResumeURL
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	created, err := h.service.Apply(r.Context(), userID, jobID, app, fileName, contentType, file)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, created)
+}
+
+// ListMyApplications returns the current user's job applications
+func (h *JobHandler) ListMyApplications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	applications, err := h.service.ListMyApplications(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, applications)
+}
+
+// ListJobApplications returns a job's applications to its poster or an admin,
+// optionally filtered by status
+func (h *JobHandler) ListJobApplications(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	applications, err := h.service.ListJobApplications(r.Context(), userID, jobID, r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusForbidden))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, applications)
+}
+
+// GetApplication returns an application with its history
+func (h *JobHandler) GetApplication(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := applicationIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid application ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	app, err := h.service.GetApplication(r.Context(), userID, applicationID)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, app)
+}
+
+// UpdateApplicationStatus moves an application through the review pipeline
+func (h *JobHandler) UpdateApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := applicationIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid application ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Status string `json:"status"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	app, err := h.service.UpdateApplicationStatus(r.Context(), userID, applicationID, req.Status, req.Note)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, app)
+}
+
+// AddApplicationNote records a reviewer note on an application
+func (h *JobHandler) AddApplicationNote(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := applicationIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid application ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	app, err := h.service.AddApplicationNote(r.Context(), userID, applicationID, req.Note)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, app)
+}
+
+// WithdrawApplication lets the applicant pull their application
+func (h *JobHandler) WithdrawApplication(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := applicationIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid application ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	app, err := h.service.WithdrawApplication(r.Context(), userID, applicationID)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, app)
+}
+
+// DownloadResume streams the resume file attached to an application
+func (h *JobHandler) DownloadResume(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := applicationIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid application ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	app, content, err := h.service.OpenResume(r.Context(), userID, applicationID)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusForbidden))
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", app.ResumeContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", app.ResumeFileName))
+	w.Header().Set("Content-Length", strconv.FormatInt(app.ResumeSizeBytes, 10))
+	io.Copy(w, content)
+}
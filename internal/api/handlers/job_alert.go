@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JobAlertHandler struct {
+	service services.IJobAlertService
+}
+
+func NewJobAlertHandler(service services.IJobAlertService) *JobAlertHandler {
+	return &JobAlertHandler{
+		service: service,
+	}
+}
+
+func savedSearchIDParam(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "searchId"))
+}
+
+// ListSavedSearches returns the current user's saved job searches
+func (h *JobAlertHandler) ListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	searches, err := h.service.ListSavedSearches(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, searches)
+}
+
+// CreateSavedSearch saves job board filters with an instant or daily alert
+func (h *JobAlertHandler) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	var req models.SavedJobSearch
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	search, err := h.service.CreateSavedSearch(r.Context(), userID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, search)
+}
+
+// UpdateSavedSearch changes a saved search's name, filters or alert frequency
+func (h *JobAlertHandler) UpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	searchID, err := savedSearchIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid saved search ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SavedJobSearch
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	search, err := h.service.UpdateSavedSearch(r.Context(), userID, searchID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, search)
+}
+
+// DeleteSavedSearch removes a saved search and its alerts
+func (h *JobAlertHandler) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	searchID, err := savedSearchIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid saved search ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.DeleteSavedSearch(r.Context(), userID, searchID); err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSavedJobs returns the current user's bookmarked jobs
+func (h *JobAlertHandler) ListSavedJobs(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	saved, err := h.service.ListSavedJobs(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, saved)
+}
+
+// SaveJob bookmarks an active job for the current user
+func (h *JobAlertHandler) SaveJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.SaveJob(r.Context(), userID, jobID); err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnsaveJob removes one of the current user's bookmarks
+func (h *JobAlertHandler) UnsaveJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.UnsaveJob(r.Context(), userID, jobID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SimilarJobs suggests jobs matching the current user's profile skills
+func (h *JobAlertHandler) SimilarJobs(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	jobs, err := h.service.SimilarJobs(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, jobs)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/pkg/utils/feed"
+)
+
+// jobFeedSize is how many of the most recently published jobs a feed carries
+const jobFeedSize = 50
+
+// Feed formats served under /jobs/feed.<format>
+const (
+	jobFeedAtom = "atom"
+	jobFeedRSS  = "rss"
+	jobFeedJSON = "json"
+)
+
+// employmentTypes maps job types to schema.org employmentType values
+var employmentTypes = map[string]string{
+	models.JobType.FullTime: "FULL_TIME",
+	models.JobType.PartTime: "PART_TIME",
+	models.JobType.Contract: "CONTRACTOR",
+}
+
+// jobFeedURL links to a feed of the jobs matching a query's filters
+func jobFeedURL(query *models.JobSearchQuery, format string) string {
+	feedURL := "/jobs/feed." + format
+	if encoded := jobFilterParams(query).Encode(); encoded != "" {
+		feedURL += "?" + encoded
+	}
+	return feedURL
+}
+
+// AtomFeed serves the active jobs matching the board's filters as Atom
+func (h *JobHandler) AtomFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, jobFeedAtom)
+}
+
+// RSSFeed serves the active jobs matching the board's filters as RSS 2.0
+func (h *JobHandler) RSSFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, jobFeedRSS)
+}
+
+// JSONFeed serves the active jobs matching the board's filters as JSON Feed
+func (h *JobHandler) JSONFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, jobFeedJSON)
+}
+
+func (h *JobHandler) serveFeed(w http.ResponseWriter, r *http.Request, format string) {
+	query, err := parseJobSearchQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query.Page = 1
+	query.PageSize = jobFeedSize
+	query.NewestFirst = true
+
+	results, err := h.service.SearchJobs(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	jobFeed := buildJobFeed(h.baseURL, query, format, results.Jobs)
+
+	var body []byte
+	var contentType string
+	switch format {
+	case jobFeedAtom:
+		body, err = jobFeed.Atom()
+		contentType = feed.AtomContentType
+	case jobFeedRSS:
+		body, err = jobFeed.RSS()
+		contentType = feed.RSSContentType
+	default:
+		body, err = jobFeed.JSON()
+		contentType = feed.JSONContentType
+	}
+	if err != nil {
+		http.Error(w, "Failed to render feed", http.StatusInternalServerError)
+		return
+	}
+
+	// ServeContent answers conditional requests from the newest update
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	http.ServeContent(w, r, "", jobFeed.Updated(), bytes.NewReader(body))
+}
+
+func buildJobFeed(baseURL string, query *models.JobSearchQuery, format string, jobs []*models.Job) *feed.Feed {
+	title := "NEXUS Mentorship Platform Jobs"
+	if query.Text != "" {
+		title += ": " + query.Text
+	}
+
+	jobFeed := &feed.Feed{
+		Title:       title,
+		Description: "The latest job postings on the NEXUS Mentorship Platform job board",
+		HomeURL:     baseURL + jobBoardPageURL(query, 1),
+		FeedURL:     baseURL + jobFeedURL(query, format),
+	}
+	for _, job := range jobs {
+		jobURL := fmt.Sprintf("%s/jobs/%d", baseURL, job.ID)
+
+		content := job.Description
+		if job.Requirements != "" {
+			content += "\n\nRequirements:\n" + job.Requirements
+		}
+
+		jobFeed.Items = append(jobFeed.Items, feed.Item{
+			ID:         jobURL,
+			URL:        jobURL,
+			Title:      fmt.Sprintf("%s at %s", job.Title, job.Company),
+			Author:     job.Company,
+			Summary:    jobSummary(job),
+			Content:    content,
+			Categories: []string{job.JobType, job.ExperienceLevel, job.RemotePolicy},
+			Published:  job.PublishedAt(),
+			Updated:    job.UpdatedAt,
+		})
+	}
+	return jobFeed
+}
+
+// jobSummary is the one-line description of a job: where, what and for how much
+func jobSummary(job *models.Job) string {
+	parts := []string{job.Company}
+	if job.Location != "" {
+		parts = append(parts, job.Location)
+	}
+	parts = append(parts, job.JobType, job.ExperienceLevel, job.RemotePolicy)
+	if job.SalaryRange != "" {
+		parts = append(parts, job.SalaryRange)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// jobPosting is the schema.org JobPosting structured data of a job detail
+// page. The salary range is free text, so it is left out rather than guessed
+// into a MonetaryAmount.
+type jobPosting struct {
+	Context            string          `json:"@context"`
+	Type               string          `json:"@type"`
+	Title              string          `json:"title"`
+	Description        string          `json:"description"`
+	Qualifications     string          `json:"qualifications,omitempty"`
+	Identifier         ldPropertyValue `json:"identifier"`
+	URL                string          `json:"url"`
+	DatePosted         string          `json:"datePosted"`
+	ValidThrough       string          `json:"validThrough,omitempty"`
+	EmploymentType     string          `json:"employmentType,omitempty"`
+	HiringOrganization ldOrganization  `json:"hiringOrganization"`
+	JobLocation        *ldPlace        `json:"jobLocation,omitempty"`
+	JobLocationType    string          `json:"jobLocationType,omitempty"`
+	DirectApply        bool            `json:"directApply"`
+}
+
+type ldPropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ldOrganization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type ldPlace struct {
+	Type    string          `json:"@type"`
+	Address ldPostalAddress `json:"address"`
+}
+
+type ldPostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality"`
+}
+
+// newJobPosting describes a job as schema.org JobPosting structured data
+func newJobPosting(baseURL string, job *models.Job) *jobPosting {
+	posting := &jobPosting{
+		Context:        "https://schema.org",
+		Type:           "JobPosting",
+		Title:          job.Title,
+		Description:    job.Description,
+		Qualifications: job.Requirements,
+		Identifier: ldPropertyValue{
+			Type:  "PropertyValue",
+			Name:  job.Company,
+			Value: strconv.Itoa(job.ID),
+		},
+		URL:                fmt.Sprintf("%s/jobs/%d", baseURL, job.ID),
+		DatePosted:         job.PublishedAt().UTC().Format(time.RFC3339),
+		EmploymentType:     employmentTypes[job.JobType],
+		HiringOrganization: ldOrganization{Type: "Organization", Name: job.Company},
+		DirectApply:        true,
+	}
+	if job.ExpiresAt != nil {
+		posting.ValidThrough = job.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if job.Location != "" {
+		posting.JobLocation = &ldPlace{
+			Type:    "Place",
+			Address: ldPostalAddress{Type: "PostalAddress", AddressLocality: job.Location},
+		}
+	}
+	if job.RemotePolicy == models.RemotePolicy.Remote {
+		posting.JobLocationType = "TELECOMMUTE"
+	}
+	return posting
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"mentorApp/internal/api/handlers/common"
+)
+
+// ReferMentee lets a mentor refer one of their mentees to a job
+func (h *JobHandler) ReferMentee(w http.ResponseWriter, r *http.Request) {
+	jobID, err := jobIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		MenteeID int    `json:"mentee_id"`
+		Note     string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	referral, err := h.service.ReferMentee(r.Context(), userID, jobID, req.MenteeID, req.Note)
+	if err != nil {
+		http.Error(w, err.Error(), jobErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, referral)
+}
+
+// ListMyReferrals returns the current mentor's referrals and their outcomes
+func (h *JobHandler) ListMyReferrals(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	history, err := h.service.ListMyReferrals(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, history)
+}
+
+// ListReceivedReferrals returns the referrals the current user received from mentors
+func (h *JobHandler) ListReceivedReferrals(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	referrals, err := h.service.ListReceivedReferrals(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, referrals)
+}
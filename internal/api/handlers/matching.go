@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MatchingHandler struct {
+	service services.IMatchingService
+}
+
+func NewMatchingHandler(service services.IMatchingService) *MatchingHandler {
+	return &MatchingHandler{
+		service: service,
+	}
+}
+
+// matchingErrorStatus maps matching round errors to HTTP statuses, falling back to fallback
+func matchingErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, repository.ErrMatchingRoundNotFound),
+		errors.Is(err, repository.ErrMatchingPairNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrMatchingRoundClosed),
+		errors.Is(err, repository.ErrMatchingRoundNotMatched),
+		errors.Is(err, repository.ErrProgramFull):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+func roundIDParam(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "roundId"))
+}
+
+// CreateRound opens a matching round
+func (h *MatchingHandler) CreateRound(w http.ResponseWriter, r *http.Request) {
+	var req models.MatchingRound
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	round, err := h.service.CreateRound(r.Context(), adminID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, round)
+}
+
+// ListRounds returns matching rounds, optionally filtered by status
+func (h *MatchingHandler) ListRounds(w http.ResponseWriter, r *http.Request) {
+	rounds, err := h.service.ListRounds(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, rounds)
+}
+
+// ListOpenRounds returns the rounds participants can currently join
+func (h *MatchingHandler) ListOpenRounds(w http.ResponseWriter, r *http.Request) {
+	rounds, err := h.service.ListRounds(r.Context(), models.MatchingRoundStatus.Open)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, rounds)
+}
+
+// GetRound returns a round with its participants and proposed pairs
+func (h *MatchingHandler) GetRound(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.GetRoundDetail(r.Context(), roundID)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, detail)
+}
+
+// ListRoundMentors returns the mentors a mentee can rank in a round
+func (h *MatchingHandler) ListRoundMentors(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	mentors, err := h.service.ListRoundMentors(r.Context(), roundID)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, mentors)
+}
+
+// JoinAsMentor enters the current mentor into a round with their capacity
+func (h *MatchingHandler) JoinAsMentor(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MatchingMentor
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	entry, err := h.service.JoinAsMentor(r.Context(), mentorID, roundID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, entry)
+}
+
+// SubmitPreferences enters the current mentee into a round with their ranked mentors
+func (h *MatchingHandler) SubmitPreferences(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MatchingMentee
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	menteeID := r.Context().Value("userID").(int)
+
+	entry, err := h.service.SubmitPreferences(r.Context(), menteeID, roundID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, entry)
+}
+
+// RunMatching proposes pairs for a round with the stable-matching algorithm
+func (h *MatchingHandler) RunMatching(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.RunMatching(r.Context(), roundID)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, detail)
+}
+
+// AssignPair matches a mentee with a mentor of the admin's choosing
+func (h *MatchingHandler) AssignPair(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	menteeID, err := strconv.Atoi(chi.URLParam(r, "menteeId"))
+	if err != nil {
+		http.Error(w, "Invalid mentee ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		MentorID int `json:"mentor_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.AssignPair(r.Context(), roundID, menteeID, req.MentorID)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, detail)
+}
+
+// UnassignPair leaves a mentee unmatched
+func (h *MatchingHandler) UnassignPair(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	menteeID, err := strconv.Atoi(chi.URLParam(r, "menteeId"))
+	if err != nil {
+		http.Error(w, "Invalid mentee ID", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.UnassignPair(r.Context(), roundID, menteeID)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, detail)
+}
+
+// PublishRound turns a round's pairs into mentorships
+func (h *MatchingHandler) PublishRound(w http.ResponseWriter, r *http.Request) {
+	roundID, err := roundIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid round ID", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.PublishRound(r.Context(), roundID)
+	if err != nil {
+		http.Error(w, err.Error(), matchingErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, detail)
+}
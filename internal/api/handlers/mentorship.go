@@ -2,13 +2,16 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"mentorApp/internal/api/handlers/common"
 	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
 	"mentorApp/internal/services"
 
 	"github.com/go-chi/chi/v5"
@@ -96,7 +99,13 @@ func (h *MentorshipHandler) GetProgramDetails(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	program, err := h.service.GetProgramDetails(r.Context(), programID)
+	userID := r.Context().Value("userID").(int)
+
+	program, err := h.service.GetProgramDetails(r.Context(), userID, programID)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -105,9 +114,142 @@ func (h *MentorshipHandler) GetProgramDetails(w http.ResponseWriter, r *http.Req
 	common.RespondJSON(w, http.StatusOK, program)
 }
 
+// UpdateProgram edits the details of one of the mentor's programs
+func (h *MentorshipHandler) UpdateProgram(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		Price       float64 `json:"price"`
+		Duration    string  `json:"duration"`
+		MaxMentees  int     `json:"max_mentees"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	program, err := h.service.UpdateProgram(r.Context(), mentorID, programID, &models.MentorshipProgram{
+		Title:       req.Title,
+		Description: req.Description,
+		Price:       req.Price,
+		Duration:    req.Duration,
+		MaxMentees:  req.MaxMentees,
+	})
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, program)
+}
+
+// PauseProgram stops a program from taking new requests
+func (h *MentorshipHandler) PauseProgram(w http.ResponseWriter, r *http.Request) {
+	h.setProgramStatus(w, r, models.ProgramStatus.Paused)
+}
+
+// ArchiveProgram retires a program with no pending or active mentorships
+func (h *MentorshipHandler) ArchiveProgram(w http.ResponseWriter, r *http.Request) {
+	h.setProgramStatus(w, r, models.ProgramStatus.Archived)
+}
+
+// ReactivateProgram opens a paused or archived program to new requests again
+func (h *MentorshipHandler) ReactivateProgram(w http.ResponseWriter, r *http.Request) {
+	h.setProgramStatus(w, r, models.ProgramStatus.Active)
+}
+
+func (h *MentorshipHandler) setProgramStatus(w http.ResponseWriter, r *http.Request, status string) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	program, err := h.service.SetProgramStatus(r.Context(), mentorID, programID, status)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrInvalidStatus) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, program)
+}
+
+// DuplicateProgram copies one of the mentor's programs as a paused template
+func (h *MentorshipHandler) DuplicateProgram(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	program, err := h.service.DuplicateProgram(r.Context(), mentorID, programID)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, program)
+}
+
+// DeleteProgram removes a program that has never received a request
+func (h *MentorshipHandler) DeleteProgram(w http.ResponseWriter, r *http.Request) {
+	programID, err := strconv.Atoi(chi.URLParam(r, "programId"))
+	if err != nil {
+		http.Error(w, "Invalid program ID", http.StatusBadRequest)
+		return
+	}
+
+	mentorID := r.Context().Value("userID").(int)
+
+	err = h.service.DeleteProgram(r.Context(), mentorID, programID)
+	if errors.Is(err, repository.ErrProgramNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrProgramInUse) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *MentorshipHandler) ScheduleSession(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		RequestId int    `json:"request_id"`
+		Title     string `json:"title"`
 		StartTime string `json:"start_time"`
 		EndTime   string `json:"end_time"`
 		Topic     string `json:"topic"`
@@ -134,12 +276,22 @@ func (h *MentorshipHandler) ScheduleSession(w http.ResponseWriter, r *http.Reque
 
 	session := &models.MentorshipSession{
 		RequestID: req.RequestId,
+		Title:     req.Title,
 		StartTime: startTime,
 		EndTime:   endTime,
 		Topic:     req.Topic,
 	}
 
-	if err := h.service.ScheduleSession(r.Context(), userID, session); err != nil {
+	err = h.service.ScheduleSession(r.Context(), userID, session)
+	if errors.Is(err, repository.ErrInvalidStatus) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if errors.Is(err, repository.ErrInvoiceUnpaid) || errors.Is(err, repository.ErrSponsorCreditsUsed) {
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+		return
+	}
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -147,6 +299,70 @@ func (h *MentorshipHandler) ScheduleSession(w http.ResponseWriter, r *http.Reque
 	common.RespondJSON(w, http.StatusCreated, session)
 }
 
+// GetSession returns a session, including its join link, to one of its participants
+func (h *MentorshipHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	session, err := h.service.GetSession(r.Context(), userID, sessionID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, session)
+}
+
+// RescheduleSession moves a session to new times and issues a new join link
+func (h *MentorshipHandler) RescheduleSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		StartTime string `json:"start_time"`
+		EndTime   string `json:"end_time"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		http.Error(w, "Invalid start time format", http.StatusBadRequest)
+		return
+	}
+
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		http.Error(w, "Invalid end time format", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	session, err := h.service.RescheduleSession(r.Context(), userID, sessionID, startTime, endTime)
+	if errors.Is(err, repository.ErrInvalidStatus) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, session)
+}
+
 func (h *MentorshipHandler) GetMentorAnalytics(w http.ResponseWriter, r *http.Request) {
 	mentorID := r.Context().Value("userID").(int)
 
@@ -159,25 +375,70 @@ func (h *MentorshipHandler) GetMentorAnalytics(w http.ResponseWriter, r *http.Re
 	common.RespondJSON(w, http.StatusOK, analytics)
 }
 
+// SearchMentors runs a public full-text mentor search. Skills may be repeated or
+// comma-separated; every listed skill must match.
 func (h *MentorshipHandler) SearchMentors(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
-	filters := map[string]interface{}{
-		"skills":    query.Get("skills"),
-		"rate_min":  query.Get("rate_min"),
-		"rate_max":  query.Get("rate_max"),
-		"timezone":  query.Get("timezone"),
-		"available": query.Get("available"),
+	params := r.URL.Query()
+	query := &models.MentorSearchQuery{
+		Text:     params.Get("q"),
+		Timezone: params.Get("timezone"),
+		Sort:     params.Get("sort"),
 	}
 
-	results, err := h.service.SearchMentors(r.Context(), filters)
+	for _, value := range params["skills"] {
+		query.Skills = append(query.Skills, strings.Split(value, ",")...)
+	}
+
+	var err error
+	if query.RateMin, err = parseOptionalFloat(params.Get("rate_min")); err != nil {
+		http.Error(w, "Invalid rate_min", http.StatusBadRequest)
+		return
+	}
+	if query.RateMax, err = parseOptionalFloat(params.Get("rate_max")); err != nil {
+		http.Error(w, "Invalid rate_max", http.StatusBadRequest)
+		return
+	}
+	if value := params.Get("available"); value != "" {
+		available, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "Invalid available", http.StatusBadRequest)
+			return
+		}
+		query.Available = &available
+	}
+	if value := params.Get("page"); value != "" {
+		if query.Page, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := params.Get("page_size"); value != "" {
+		if query.PageSize, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid page_size", http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := h.service.SearchMentors(r.Context(), query)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	common.RespondJSON(w, http.StatusOK, results)
 }
 
+func parseOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
 func (h *MentorshipHandler) UpdateAvailability(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Availability []models.Availability `json:"availability"`
@@ -198,6 +459,59 @@ func (h *MentorshipHandler) UpdateAvailability(w http.ResponseWriter, r *http.Re
 	common.RespondJSON(w, http.StatusOK, map[string]string{"message": "Availability updated successfully"})
 }
 
+// GetBookableSlots lists a mentor's free slots, taking their synced calendar and booked sessions into account
+func (h *MentorshipHandler) GetBookableSlots(w http.ResponseWriter, r *http.Request) {
+	mentorID, err := strconv.Atoi(chi.URLParam(r, "mentorId"))
+	if err != nil {
+		http.Error(w, "Invalid mentor ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	from := time.Now()
+	if v := query.Get("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			http.Error(w, "Invalid from time format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	to := from.Add(14 * 24 * time.Hour)
+	if v := query.Get("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			http.Error(w, "Invalid to time format", http.StatusBadRequest)
+			return
+		}
+	}
+	if !to.After(from) {
+		http.Error(w, "to must be after from", http.StatusBadRequest)
+		return
+	}
+	if to.Sub(from) > 60*24*time.Hour {
+		http.Error(w, "Range cannot exceed 60 days", http.StatusBadRequest)
+		return
+	}
+
+	length := time.Hour
+	if v := query.Get("duration"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil || minutes < 15 || minutes > 240 {
+			http.Error(w, "Duration must be between 15 and 240 minutes", http.StatusBadRequest)
+			return
+		}
+		length = time.Duration(minutes) * time.Minute
+	}
+
+	slots, err := h.service.GetBookableSlots(r.Context(), mentorID, from, to, length)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, slots)
+}
+
 // ListMentorPrograms lists all programs created by the mentor (logged-in user)
 func (h *MentorshipHandler) ListMentorPrograms(w http.ResponseWriter, r *http.Request) {
 	mentorID := r.Context().Value("userID").(int)
@@ -209,6 +523,29 @@ func (h *MentorshipHandler) ListMentorPrograms(w http.ResponseWriter, r *http.Re
 	common.RespondJSON(w, http.StatusOK, programs)
 }
 
+// CancelMentorship ends a mentorship for either participant and settles its invoice
+func (h *MentorshipHandler) CancelMentorship(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	request, err := h.service.CancelMentorship(r.Context(), userID, requestID)
+	if errors.Is(err, repository.ErrInvalidStatus) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, request)
+}
+
 // ListMentorshipRequests lists all mentorship requests for the mentor
 func (h *MentorshipHandler) ListMentorshipRequests(w http.ResponseWriter, r *http.Request) {
 	mentorID := r.Context().Value("userID").(int)
@@ -237,7 +574,12 @@ func (h *MentorshipHandler) RespondToRequest(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := h.service.RespondToRequest(r.Context(), mentorID, requestID, req.Approve); err != nil {
+	err = h.service.RespondToRequest(r.Context(), mentorID, requestID, req.Approve)
+	if errors.Is(err, repository.ErrInvalidStatus) || errors.Is(err, repository.ErrProgramFull) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -255,14 +597,15 @@ func (h *MentorshipHandler) RequestMentorship(w http.ResponseWriter, r *http.Req
 	}
 
 	var req struct {
-		Message string `json:"message"`
+		Message         string `json:"message"`
+		SponsorshipCode string `json:"sponsorship_code"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request format", http.StatusBadRequest)
 		return
 	}
 
-	if err := h.service.RequestMentorship(r.Context(), menteeID, programID, req.Message); err != nil {
+	if err := h.service.RequestMentorship(r.Context(), menteeID, programID, req.Message, req.SponsorshipCode); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -285,36 +628,25 @@ func (h *MentorshipHandler) ListMenteeSessions(w http.ResponseWriter, r *http.Re
 	common.RespondJSON(w, http.StatusOK, sessions)
 }
 
-func (h *MentorshipHandler) SubmitSessionFeedback(w http.ResponseWriter, r *http.Request) {
+// CompleteSession lets the mentor mark a session as completed so feedback can be left
+func (h *MentorshipHandler) CompleteSession(w http.ResponseWriter, r *http.Request) {
 	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
 	if err != nil {
 		http.Error(w, "Invalid session ID", http.StatusBadRequest)
 		return
 	}
 
-	var req struct {
-		Rating  int    `json:"rating"`
-		Comment string `json:"comment"`
-	}
+	mentorID := r.Context().Value("userID").(int)
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request format", http.StatusBadRequest)
+	session, err := h.service.CompleteSession(r.Context(), mentorID, sessionID)
+	if errors.Is(err, repository.ErrInvoiceUnpaid) {
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
 		return
 	}
-
-	userID := r.Context().Value("userID").(int)
-
-	feedback := &models.SessionFeedback{
-		SessionID: sessionID,
-		UserID:    userID,
-		Rating:    req.Rating,
-		Comment:   req.Comment,
-	}
-
-	if err := h.service.SubmitSessionFeedback(r.Context(), feedback); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	common.RespondJSON(w, http.StatusCreated, map[string]string{"message": "Feedback submitted successfully"})
+	common.RespondJSON(w, http.StatusOK, session)
 }
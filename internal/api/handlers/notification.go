@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type NotificationHandler struct {
+	service services.INotificationService
+}
+
+func NewNotificationHandler(service services.INotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+	}
+}
+
+// ListNotifications returns the logged-in user's notifications; ?unread=true limits to unread ones
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, err := h.service.ListNotifications(r.Context(), userID, unreadOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, notifications)
+}
+
+// MarkRead marks a single notification as read
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	notificationID, err := strconv.Atoi(chi.URLParam(r, "notificationId"))
+	if err != nil {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	err = h.service.MarkRead(r.Context(), userID, notificationID)
+	if errors.Is(err, repository.ErrNotificationNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
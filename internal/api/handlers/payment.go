@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// invoiceDateLayout is how dates appear on rendered invoices
+const invoiceDateLayout = "January 2, 2006"
+
+type PaymentHandler struct {
+	service   services.IPaymentService
+	templates *template.Template
+}
+
+func NewPaymentHandler(service services.IPaymentService, templates *template.Template) *PaymentHandler {
+	return &PaymentHandler{
+		service:   service,
+		templates: templates,
+	}
+}
+
+// invoiceView is the data behind invoice.html, with amounts and dates preformatted
+type invoiceView struct {
+	Number      string
+	Status      string
+	IssuedAt    string
+	PaidAt      string
+	MenteeName  string
+	MentorName  string
+	Description string
+	Amount      string
+	Refunded    string
+	Total       string
+	Payments    []invoicePaymentView
+}
+
+type invoicePaymentView struct {
+	Date      string
+	Kind      string
+	Reference string
+	Status    string
+	Amount    string
+}
+
+func newInvoiceView(invoice *models.Invoice) *invoiceView {
+	view := &invoiceView{
+		Number:      invoice.Number,
+		Status:      invoice.Status,
+		IssuedAt:    invoice.IssuedAt.Format(invoiceDateLayout),
+		MenteeName:  invoice.MenteeName,
+		MentorName:  invoice.MentorName,
+		Description: invoice.Description,
+		Amount:      models.FormatCents(invoice.AmountCents, invoice.Currency),
+		Total:       models.FormatCents(invoice.AmountCents-invoice.RefundedCents, invoice.Currency),
+	}
+	if invoice.PaidAt != nil {
+		view.PaidAt = invoice.PaidAt.Format(invoiceDateLayout)
+	}
+	if invoice.RefundedCents > 0 {
+		view.Refunded = models.FormatCents(invoice.RefundedCents, invoice.Currency)
+	}
+	for _, payment := range invoice.Payments {
+		view.Payments = append(view.Payments, invoicePaymentView{
+			Date:      payment.CreatedAt.Format(invoiceDateLayout),
+			Kind:      payment.Kind,
+			Reference: payment.ProviderRef,
+			Status:    payment.Status,
+			Amount:    models.FormatCents(payment.AmountCents, invoice.Currency),
+		})
+	}
+	return view
+}
+
+// Checkout pays the open invoice of the mentee's approved mentorship
+func (h *PaymentHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		PaymentToken string `json:"payment_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	menteeID := r.Context().Value("userID").(int)
+
+	invoice, err := h.service.Checkout(r.Context(), menteeID, requestID, req.PaymentToken)
+	if errors.Is(err, services.ErrPaymentDeclined) {
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+		return
+	}
+	if errors.Is(err, repository.ErrInvoiceNotOpen) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, invoice)
+}
+
+// GetMentorshipInvoice returns the invoice of a mentorship
+func (h *PaymentHandler) GetMentorshipInvoice(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	invoice, err := h.service.GetMentorshipInvoice(r.Context(), userID, requestID)
+	if errors.Is(err, repository.ErrInvoiceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, invoice)
+}
+
+// ListInvoices returns the caller's invoices as mentee or mentor
+func (h *PaymentHandler) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	invoices, err := h.service.ListInvoices(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, invoices)
+}
+
+// GetInvoice returns one invoice with its payment history
+func (h *PaymentHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := h.loadInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, invoice)
+}
+
+// RenderInvoice returns a printable HTML invoice
+func (h *PaymentHandler) RenderInvoice(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := h.loadInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.templates.ExecuteTemplate(w, "invoice.html", newInvoiceView(invoice)); err != nil {
+		log.Printf("Error rendering invoice %d: %v", invoice.ID, err)
+		http.Error(w, "Failed to render invoice", http.StatusInternalServerError)
+	}
+}
+
+func (h *PaymentHandler) loadInvoice(w http.ResponseWriter, r *http.Request) (*models.Invoice, bool) {
+	invoiceID, err := strconv.Atoi(chi.URLParam(r, "invoiceId"))
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	invoice, err := h.service.GetInvoice(r.Context(), userID, invoiceID)
+	if errors.Is(err, repository.ErrInvoiceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return nil, false
+	}
+
+	return invoice, true
+}
+
+// GetPayoutSummary returns the mentor's earnings, fees and refunds per program
+func (h *PaymentHandler) GetPayoutSummary(w http.ResponseWriter, r *http.Request) {
+	mentorID := r.Context().Value("userID").(int)
+
+	summary, err := h.service.GetPayoutSummary(r.Context(), mentorID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, summary)
+}
+
+// RefundInvoice refunds part or all of a paid invoice. An amount of zero refunds the remaining balance.
+func (h *PaymentHandler) RefundInvoice(w http.ResponseWriter, r *http.Request) {
+	invoiceID, err := strconv.Atoi(chi.URLParam(r, "invoiceId"))
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		AmountCents int64  `json:"amount_cents"`
+		Reason      string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.AmountCents < 0 {
+		http.Error(w, "Refund amount cannot be negative", http.StatusBadRequest)
+		return
+	}
+
+	invoice, err := h.service.RefundInvoice(r.Context(), invoiceID, req.AmountCents, req.Reason)
+	if errors.Is(err, repository.ErrInvoiceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, repository.ErrRefundExceedsBalance) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, invoice)
+}
+
+// GetInvoiceLedger returns the ledger postings made for an invoice
+func (h *PaymentHandler) GetInvoiceLedger(w http.ResponseWriter, r *http.Request) {
+	invoiceID, err := strconv.Atoi(chi.URLParam(r, "invoiceId"))
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		return
+	}
+
+	ledger, err := h.service.GetInvoiceLedger(r.Context(), invoiceID)
+	if errors.Is(err, repository.ErrInvoiceNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, ledger)
+}
@@ -41,6 +41,8 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		LastName       string  `json:"last_name"`
 		Bio            string  `json:"bio"`
 		Skills         string  `json:"skills"`
+		Specialties    string  `json:"specialties"`
+		Experience     string  `json:"experience"`
 		Rate           float64 `json:"rate,omitempty"`
 		TimeZone       string  `json:"timezone"`
 		Availability   string  `json:"availability"`
@@ -61,6 +63,8 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		LastName:       req.LastName,
 		Bio:            req.Bio,
 		Skills:         req.Skills,
+		Specialties:    req.Specialties,
+		Experience:     req.Experience,
 		Rate:           req.Rate,
 		Timezone:       req.TimeZone,
 		LinkedIn:       req.LinkedIn,
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RecommendationHandler struct {
+	service services.IRecommendationService
+}
+
+func NewRecommendationHandler(service services.IRecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{
+		service: service,
+	}
+}
+
+// ListRecommendations returns the mentors best matched to the current mentee
+func (h *RecommendationHandler) ListRecommendations(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	recommendations, err := h.service.GetRecommendations(r.Context(), userID, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, recommendations)
+}
+
+// Dismiss hides a recommended mentor for a while
+func (h *RecommendationHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	h.giveFeedback(w, r, h.service.Dismiss)
+}
+
+// MarkNotInterested hides a recommended mentor until the feedback is cleared
+func (h *RecommendationHandler) MarkNotInterested(w http.ResponseWriter, r *http.Request) {
+	h.giveFeedback(w, r, h.service.MarkNotInterested)
+}
+
+// ClearFeedback lets a hidden mentor be recommended again
+func (h *RecommendationHandler) ClearFeedback(w http.ResponseWriter, r *http.Request) {
+	h.giveFeedback(w, r, h.service.ClearFeedback)
+}
+
+func (h *RecommendationHandler) giveFeedback(w http.ResponseWriter, r *http.Request, record func(ctx context.Context, menteeID, mentorID int) error) {
+	mentorID, err := strconv.Atoi(chi.URLParam(r, "mentorId"))
+	if err != nil {
+		http.Error(w, "Invalid mentor ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := record(r.Context(), userID, mentorID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
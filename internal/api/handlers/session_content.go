@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const dueDateLayout = "2006-01-02"
+
+type SessionContentHandler struct {
+	service services.ISessionContentService
+}
+
+func NewSessionContentHandler(service services.ISessionContentService) *SessionContentHandler {
+	return &SessionContentHandler{
+		service: service,
+	}
+}
+
+// GetContent returns the agenda, notes and action items of a session
+func (h *SessionContentHandler) GetContent(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	content, err := h.service.GetContent(r.Context(), userID, sessionID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, content)
+}
+
+// UpdateAgenda saves the pre-session agenda; a stale version yields 409 Conflict
+func (h *SessionContentHandler) UpdateAgenda(w http.ResponseWriter, r *http.Request) {
+	h.updateVersioned(w, r, h.service.UpdateAgenda)
+}
+
+// UpdateSharedNotes saves the notes both participants see; a stale version yields 409 Conflict
+func (h *SessionContentHandler) UpdateSharedNotes(w http.ResponseWriter, r *http.Request) {
+	h.updateVersioned(w, r, h.service.UpdateSharedNotes)
+}
+
+func (h *SessionContentHandler) updateVersioned(w http.ResponseWriter, r *http.Request, save func(ctx context.Context, userID, sessionID int, text string, version int) (int, error)) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Content string `json:"content"`
+		Version int    `json:"version"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	version, err := save(r.Context(), userID, sessionID, req.Content, req.Version)
+	if errors.Is(err, repository.ErrContentConflict) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, map[string]int{"version": version})
+}
+
+// UpdatePrivateNotes saves the caller's own notes for a session
+func (h *SessionContentHandler) UpdatePrivateNotes(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Content string `json:"content"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.UpdatePrivateNotes(r.Context(), userID, sessionID, req.Content); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddActionItem records a follow-up task raised in a session
+func (h *SessionContentHandler) AddActionItem(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionId"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		OwnerID     int    `json:"owner_id"`
+		DueDate     string `json:"due_date"` // YYYY-MM-DD
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	item := &models.ActionItem{
+		Title:       req.Title,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+	}
+	if req.DueDate != "" {
+		due, err := time.Parse(dueDateLayout, req.DueDate)
+		if err != nil {
+			http.Error(w, "Invalid due date format", http.StatusBadRequest)
+			return
+		}
+		item.DueDate = &due
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	if err := h.service.AddActionItem(r.Context(), userID, sessionID, item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, item)
+}
+
+// UpdateActionItem edits an action item or marks it done; omitted fields are left unchanged
+func (h *SessionContentHandler) UpdateActionItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := strconv.Atoi(chi.URLParam(r, "itemId"))
+	if err != nil {
+		http.Error(w, "Invalid action item ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Title       *string `json:"title"`
+		Description *string `json:"description"`
+		OwnerID     *int    `json:"owner_id"`
+		DueDate     *string `json:"due_date"` // YYYY-MM-DD, or "" to clear
+		Done        *bool   `json:"done"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	update := &models.ActionItemUpdate{
+		Title:       req.Title,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		Done:        req.Done,
+	}
+	if req.DueDate != nil {
+		if *req.DueDate == "" {
+			update.ClearDue = true
+		} else {
+			due, err := time.Parse(dueDateLayout, *req.DueDate)
+			if err != nil {
+				http.Error(w, "Invalid due date format", http.StatusBadRequest)
+				return
+			}
+			update.DueDate = &due
+		}
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	item, err := h.service.UpdateActionItem(r.Context(), userID, itemID, update)
+	if errors.Is(err, repository.ErrActionItemNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, item)
+}
+
+// DeleteActionItem removes an action item
+func (h *SessionContentHandler) DeleteActionItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := strconv.Atoi(chi.URLParam(r, "itemId"))
+	if err != nil {
+		http.Error(w, "Invalid action item ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	err = h.service.DeleteActionItem(r.Context(), userID, itemID)
+	if errors.Is(err, repository.ErrActionItemNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListActionItems returns a mentorship's action items; ?open=true limits to unfinished ones
+func (h *SessionContentHandler) ListActionItems(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+	openOnly := r.URL.Query().Get("open") == "true"
+
+	items, err := h.service.ListActionItems(r.Context(), userID, requestID, openOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, items)
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SkillHandler struct {
+	service services.ISkillService
+}
+
+func NewSkillHandler(service services.ISkillService) *SkillHandler {
+	return &SkillHandler{
+		service: service,
+	}
+}
+
+// skillErrorStatus maps taxonomy errors to HTTP statuses, falling back to fallback
+func skillErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, repository.ErrSkillNotFound),
+		errors.Is(err, repository.ErrSkillCategoryNotFound),
+		errors.Is(err, repository.ErrSynonymNotFound),
+		errors.Is(err, repository.ErrProfileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrSkillExists),
+		errors.Is(err, repository.ErrSkillCategoryExists):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+// ListSkills returns the taxonomy, optionally filtered by category_id or to specialties
+func (h *SkillHandler) ListSkills(w http.ResponseWriter, r *http.Request) {
+	var categoryID *int
+	if raw := r.URL.Query().Get("category_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Invalid category ID", http.StatusBadRequest)
+			return
+		}
+		categoryID = &id
+	}
+	specialtiesOnly := r.URL.Query().Get("specialties") == "true"
+
+	skills, err := h.service.ListSkills(r.Context(), categoryID, specialtiesOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skills)
+}
+
+// GetSkill returns a skill with its synonyms
+func (h *SkillHandler) GetSkill(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(chi.URLParam(r, "skillId"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := h.service.GetSkill(r.Context(), skillID)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skill)
+}
+
+// ListCategories returns every skill category
+func (h *SkillHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.service.ListCategories(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, categories)
+}
+
+// Autocomplete suggests skills matching the q prefix by name or synonym
+func (h *SkillHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	suggestions, err := h.service.Autocomplete(r.Context(), query.Get("q"), query.Get("specialties") == "true", limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, suggestions)
+}
+
+// GetProfileSkills returns the current user's skills with their levels
+func (h *SkillHandler) GetProfileSkills(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	skills, err := h.service.GetProfileSkills(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skills)
+}
+
+// SetProfileSkills replaces the current user's skills
+func (h *SkillHandler) SetProfileSkills(w http.ResponseWriter, r *http.Request) {
+	var req []*models.ProfileSkill
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	skills, err := h.service.SetProfileSkills(r.Context(), userID, req)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skills)
+}
+
+// CreateCategory adds a skill category
+func (h *SkillHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req models.SkillCategory
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.service.CreateCategory(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, category)
+}
+
+// UpdateCategory renames, describes or reorders a skill category
+func (h *SkillHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(chi.URLParam(r, "categoryId"))
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SkillCategory
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.service.UpdateCategory(r.Context(), categoryID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, category)
+}
+
+// DeleteCategory removes a skill category
+func (h *SkillHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(chi.URLParam(r, "categoryId"))
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteCategory(r.Context(), categoryID); err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateSkill adds a skill to the taxonomy
+func (h *SkillHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
+	var req models.Skill
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := h.service.CreateSkill(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, skill)
+}
+
+// UpdateSkill renames, recategorises or describes a skill
+func (h *SkillHandler) UpdateSkill(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(chi.URLParam(r, "skillId"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.Skill
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := h.service.UpdateSkill(r.Context(), skillID, &req)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skill)
+}
+
+// DeleteSkill removes a skill from the taxonomy and every profile
+func (h *SkillHandler) DeleteSkill(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(chi.URLParam(r, "skillId"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteSkill(r.Context(), skillID); err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddSynonym adds an alternative name for a skill
+func (h *SkillHandler) AddSynonym(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(chi.URLParam(r, "skillId"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := h.service.AddSynonym(r.Context(), skillID, req.Name)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skill)
+}
+
+// RemoveSynonym removes an alternative name from a skill
+func (h *SkillHandler) RemoveSynonym(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(chi.URLParam(r, "skillId"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := h.service.RemoveSynonym(r.Context(), skillID, chi.URLParam(r, "synonym"))
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skill)
+}
+
+// MergeSkill folds a duplicate skill into another, moving its mentors and names
+func (h *SkillHandler) MergeSkill(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(chi.URLParam(r, "skillId"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		IntoSkillID int `json:"into_skill_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := h.service.MergeSkill(r.Context(), skillID, req.IntoSkillID)
+	if err != nil {
+		http.Error(w, err.Error(), skillErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, skill)
+}
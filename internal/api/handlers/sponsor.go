@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentorApp/internal/api/handlers/common"
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SponsorHandler struct {
+	service services.ISponsorService
+}
+
+func NewSponsorHandler(service services.ISponsorService) *SponsorHandler {
+	return &SponsorHandler{
+		service: service,
+	}
+}
+
+// sponsorRequest is the body of sponsor create and update requests
+type sponsorRequest struct {
+	Name          string     `json:"name"`
+	Code          string     `json:"code"`
+	ContactEmail  string     `json:"contact_email"`
+	BudgetCredits int        `json:"budget_credits"`
+	Active        *bool      `json:"active"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+}
+
+func (req *sponsorRequest) sponsor() *models.Sponsor {
+	sponsor := &models.Sponsor{
+		Name:          req.Name,
+		Code:          req.Code,
+		ContactEmail:  req.ContactEmail,
+		BudgetCredits: req.BudgetCredits,
+		Active:        true,
+		ExpiresAt:     req.ExpiresAt,
+	}
+	if req.Active != nil {
+		sponsor.Active = *req.Active
+	}
+	return sponsor
+}
+
+// CreateSponsor opens a sponsor account with a credit budget
+func (h *SponsorHandler) CreateSponsor(w http.ResponseWriter, r *http.Request) {
+	var req sponsorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	adminID := r.Context().Value("userID").(int)
+
+	sponsor, err := h.service.CreateSponsor(r.Context(), adminID, req.sponsor())
+	if errors.Is(err, repository.ErrSponsorCodeTaken) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, sponsor)
+}
+
+// ListSponsors returns every sponsor with its budget usage
+func (h *SponsorHandler) ListSponsors(w http.ResponseWriter, r *http.Request) {
+	sponsors, err := h.service.ListSponsors(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, sponsors)
+}
+
+// GetSponsor returns a sponsor with its budget usage
+func (h *SponsorHandler) GetSponsor(w http.ResponseWriter, r *http.Request) {
+	sponsorID, err := strconv.Atoi(chi.URLParam(r, "sponsorId"))
+	if err != nil {
+		http.Error(w, "Invalid sponsor ID", http.StatusBadRequest)
+		return
+	}
+
+	sponsor, err := h.service.GetSponsor(r.Context(), sponsorID)
+	if errors.Is(err, repository.ErrSponsorNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, sponsor)
+}
+
+// UpdateSponsor changes a sponsor's details, budget, status or expiry
+func (h *SponsorHandler) UpdateSponsor(w http.ResponseWriter, r *http.Request) {
+	sponsorID, err := strconv.Atoi(chi.URLParam(r, "sponsorId"))
+	if err != nil {
+		http.Error(w, "Invalid sponsor ID", http.StatusBadRequest)
+		return
+	}
+
+	var req sponsorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sponsor, err := h.service.UpdateSponsor(r.Context(), sponsorID, req.sponsor())
+	if errors.Is(err, repository.ErrSponsorNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, sponsor)
+}
+
+// GetSponsorReport returns a sponsor's budget usage by mentorship and month
+func (h *SponsorHandler) GetSponsorReport(w http.ResponseWriter, r *http.Request) {
+	sponsorID, err := strconv.Atoi(chi.URLParam(r, "sponsorId"))
+	if err != nil {
+		http.Error(w, "Invalid sponsor ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetSponsorReport(r.Context(), sponsorID)
+	if errors.Is(err, repository.ErrSponsorNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, report)
+}
+
+// GetMentorshipSponsorship returns the sponsor paying for a mentorship
+func (h *SponsorHandler) GetMentorshipSponsorship(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	sponsorship, err := h.service.GetMentorshipSponsorship(r.Context(), userID, requestID)
+	if errors.Is(err, repository.ErrSponsorshipNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusOK, sponsorship)
+}
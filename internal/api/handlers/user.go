@@ -149,6 +149,44 @@ func (h *UserHandler) RegisterMentor(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RegisterEmployer handles employer registration
+func (h *UserHandler) RegisterEmployer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username  string `json:"username"`
+		Email     string `json:"email"`
+		Password  string `json:"password"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Bio       string `json:"bio"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	input := services.RegisterEmployerInput{
+		Username:  req.Username,
+		Email:     req.Email,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Bio:       req.Bio,
+	}
+
+	user, err := h.service.RegisterEmployer(r.Context(), input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.RespondJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":       user.Id,
+		"username": user.Username,
+		"email":    user.Email,
+	})
+}
+
 // Login handles user authentication
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -9,12 +9,17 @@ import (
 func InitializeHandlers(
 	userService services.IUserService,
 	mentorshipService services.IMentorshipService,
+	goalService services.IGoalService,
+	recommendationService services.IRecommendationService,
+	featuredService services.IFeaturedService,
+	jobService services.IJobService,
+	baseURL string,
 ) (*handlers.UserHandler, *handlers.MentorshipHandler, *handlers.ProfileHandler, *handlers.HomeHandler) {
 
 	userHandler := handlers.NewUserHandler(userService)
 	mentorshipHandler := handlers.NewMentorshipHandler(mentorshipService)
 	profileHandler := handlers.NewProfileHandler(userService)
-	homeHandler := handlers.NewHomeHandler(userService, mentorshipService)
+	homeHandler := handlers.NewHomeHandler(userService, mentorshipService, goalService, recommendationService, featuredService, jobService, baseURL)
 
 	return userHandler, mentorshipHandler, profileHandler, homeHandler
 }
@@ -17,6 +17,23 @@ func SetupRoutes(
 	profileHandler *handlers.ProfileHandler,
 	homeHandler *handlers.HomeHandler,
 	adminHandler *handlers.AdminHandler,
+	calendarHandler *handlers.CalendarHandler,
+	notificationHandler *handlers.NotificationHandler,
+	sessionContentHandler *handlers.SessionContentHandler,
+	feedbackHandler *handlers.FeedbackHandler,
+	goalHandler *handlers.GoalHandler,
+	cohortHandler *handlers.CohortHandler,
+	curriculumHandler *handlers.CurriculumHandler,
+	assignmentHandler *handlers.AssignmentHandler,
+	agreementHandler *handlers.AgreementHandler,
+	paymentHandler *handlers.PaymentHandler,
+	sponsorHandler *handlers.SponsorHandler,
+	skillHandler *handlers.SkillHandler,
+	recommendationHandler *handlers.RecommendationHandler,
+	matchingHandler *handlers.MatchingHandler,
+	featuredHandler *handlers.FeaturedHandler,
+	jobHandler *handlers.JobHandler,
+	jobAlertHandler *handlers.JobAlertHandler,
 ) {
 	// CORS middleware
 	r.Use(cors.Handler(cors.Options{
@@ -45,6 +62,26 @@ func SetupRoutes(
 		// Registration endpoints
 		r.Post("/register/mentee", userHandler.RegisterMentee)
 		r.Post("/register/mentor", userHandler.RegisterMentor)
+		r.Post("/register/employer", userHandler.RegisterEmployer)
+
+		// Public mentor search, profiles and testimonials
+		r.Get("/mentors/search", mentorshipHandler.SearchMentors)
+		r.Get("/mentors/featured", featuredHandler.GetFeaturedMentors)
+		r.Get("/profiles/{userId}", profileHandler.GetPublicProfile)
+		r.Get("/mentors/{mentorId}/testimonials", feedbackHandler.ListTestimonials)
+
+		// Skills taxonomy
+		r.Get("/skills", skillHandler.ListSkills)
+		r.Get("/skills/autocomplete", skillHandler.Autocomplete)
+		r.Get("/skills/categories", skillHandler.ListCategories)
+		r.Get("/skills/{skillId}", skillHandler.GetSkill)
+
+		// Job board API
+		r.Get("/jobs/search", jobHandler.SearchJobs)
+		r.Get("/jobs/feed.atom", jobHandler.AtomFeed)
+		r.Get("/jobs/feed.rss", jobHandler.RSSFeed)
+		r.Get("/jobs/feed.json", jobHandler.JSONFeed)
+		r.Get("/jobs/{jobId}", homeHandler.GetJobDetail)
 	})
 
 	// Protected routes
@@ -54,6 +91,8 @@ func SetupRoutes(
 		// Profile routes
 		r.Get("/profile", profileHandler.GetProfile)
 		r.Put("/profile", profileHandler.UpdateProfile)
+		r.Get("/profile/skills", skillHandler.GetProfileSkills)
+		r.Put("/profile/skills", skillHandler.SetProfileSkills)
 
 		// Mentor routes
 		r.Route("/mentor", func(r chi.Router) {
@@ -61,16 +100,194 @@ func SetupRoutes(
 			r.Get("/dashboard", homeHandler.GetMentorDashboard) // Make sure this exists
 			r.Get("/programs", mentorshipHandler.ListMentorPrograms)
 			r.Post("/programs", mentorshipHandler.CreateProgram)
+			r.Get("/programs/{programId}", mentorshipHandler.GetProgramDetails)
+			r.Put("/programs/{programId}", mentorshipHandler.UpdateProgram)
+			r.Delete("/programs/{programId}", mentorshipHandler.DeleteProgram)
+			r.Post("/programs/{programId}/pause", mentorshipHandler.PauseProgram)
+			r.Post("/programs/{programId}/archive", mentorshipHandler.ArchiveProgram)
+			r.Post("/programs/{programId}/reactivate", mentorshipHandler.ReactivateProgram)
+			r.Post("/programs/{programId}/duplicate", mentorshipHandler.DuplicateProgram)
 			r.Get("/requests", mentorshipHandler.ListMentorshipRequests)
 			r.Put("/requests/{requestId}", mentorshipHandler.RespondToRequest)
-			
+
+			// External calendar sync
+			r.Get("/calendar", calendarHandler.GetFeed)
+			r.Put("/calendar", calendarHandler.SetFeed)
+			r.Delete("/calendar", calendarHandler.RemoveFeed)
+			r.Post("/calendar/sync", calendarHandler.SyncFeed)
+
+			// Feedback left by mentees
+			r.Get("/feedback", feedbackHandler.ListMentorFeedback)
+			r.Post("/feedback/{feedbackId}/reply", feedbackHandler.ReplyToFeedback)
+
+			// Cohorts
+			r.Post("/programs/{programId}/cohorts", cohortHandler.CreateCohort)
+			r.Post("/cohorts/{cohortId}/cancel", cohortHandler.CancelCohort)
+			r.Post("/cohorts/{cohortId}/sessions", cohortHandler.ScheduleSession)
+			r.Post("/cohorts/{cohortId}/announcements", cohortHandler.PostAnnouncement)
+			r.Post("/cohort-sessions/{sessionId}/cancel", cohortHandler.CancelSession)
+			r.Post("/cohort-sessions/{sessionId}/attendance", cohortHandler.RecordAttendance)
+
+			// Curriculum builder
+			r.Get("/programs/{programId}/curriculum/draft", curriculumHandler.GetDraft)
+			r.Get("/programs/{programId}/curriculum/versions", curriculumHandler.ListVersions)
+			r.Get("/curriculum/{versionId}", curriculumHandler.GetVersion)
+			r.Post("/curriculum/{versionId}/publish", curriculumHandler.Publish)
+			r.Post("/curriculum/{versionId}/modules", curriculumHandler.AddModule)
+			r.Put("/curriculum/{versionId}/modules/order", curriculumHandler.ReorderModules)
+			r.Put("/modules/{moduleId}", curriculumHandler.UpdateModule)
+			r.Delete("/modules/{moduleId}", curriculumHandler.DeleteModule)
+			r.Post("/modules/{moduleId}/links", curriculumHandler.AddLink)
+			r.Post("/modules/{moduleId}/files", curriculumHandler.UploadFile)
+			r.Delete("/resources/{resourceId}", curriculumHandler.DeleteResource)
+
+			// Assignments and review
+			r.Post("/assignments", assignmentHandler.CreateAssignment)
+			r.Put("/assignments/{assignmentId}", assignmentHandler.UpdateAssignment)
+			r.Delete("/assignments/{assignmentId}", assignmentHandler.DeleteAssignment)
+			r.Get("/programs/{programId}/assignments", assignmentHandler.ListProgramAssignments)
+			r.Get("/reviews", assignmentHandler.ListPendingReviews)
+			r.Post("/submissions/{submissionId}/review", assignmentHandler.Review)
+
+			// Payouts
+			r.Get("/payouts", paymentHandler.GetPayoutSummary)
+
+			// Matching rounds
+			r.Put("/matching-rounds/{roundId}", matchingHandler.JoinAsMentor)
 		})
 
+		// Mentor availability
+		r.Get("/mentors/{mentorId}/slots", mentorshipHandler.GetBookableSlots)
+		r.Get("/mentors/{mentorId}/rating", feedbackHandler.GetMentorRating)
+
+		// Session routes, available to both participants
+		r.Route("/sessions", func(r chi.Router) {
+			r.Post("/", mentorshipHandler.ScheduleSession)
+			r.Get("/{sessionId}", mentorshipHandler.GetSession)
+			r.Post("/{sessionId}/reschedule", mentorshipHandler.RescheduleSession)
+			r.Post("/{sessionId}/complete", mentorshipHandler.CompleteSession)
+
+			// Feedback, once the session is completed
+			r.Get("/{sessionId}/feedback", feedbackHandler.GetSessionFeedback)
+			r.Post("/{sessionId}/feedback", feedbackHandler.SubmitFeedback)
+
+			// Agenda, notes and action items
+			r.Get("/{sessionId}/content", sessionContentHandler.GetContent)
+			r.Put("/{sessionId}/agenda", sessionContentHandler.UpdateAgenda)
+			r.Put("/{sessionId}/notes/shared", sessionContentHandler.UpdateSharedNotes)
+			r.Put("/{sessionId}/notes/private", sessionContentHandler.UpdatePrivateNotes)
+			r.Post("/{sessionId}/action-items", sessionContentHandler.AddActionItem)
+		})
+
+		r.Put("/action-items/{itemId}", sessionContentHandler.UpdateActionItem)
+		r.Delete("/action-items/{itemId}", sessionContentHandler.DeleteActionItem)
+		r.Get("/mentorships/{requestId}/action-items", sessionContentHandler.ListActionItems)
+		r.Put("/feedback/{feedbackId}", feedbackHandler.UpdateFeedback)
+		r.Put("/feedback/{feedbackId}/visibility", feedbackHandler.SetVisibility)
+
+		// Goals, milestones and check-ins
+		r.Get("/mentorships/{requestId}/goals", goalHandler.ListGoals)
+		r.Post("/mentorships/{requestId}/goals", goalHandler.CreateGoal)
+		r.Get("/mentorships/{requestId}/timeline", goalHandler.GetTimeline)
+		r.Put("/goals/{goalId}", goalHandler.UpdateGoal)
+		r.Post("/goals/{goalId}/milestones", goalHandler.AddMilestone)
+		r.Post("/goals/{goalId}/checkins", goalHandler.CheckIn)
+		r.Put("/milestones/{milestoneId}", goalHandler.UpdateMilestone)
+		r.Delete("/milestones/{milestoneId}", goalHandler.DeleteMilestone)
+
+		// Cohorts, visible to their mentor and enrolled mentees
+		r.Get("/programs/{programId}/cohorts", cohortHandler.ListProgramCohorts)
+		r.Get("/cohorts/{cohortId}", cohortHandler.GetCohort)
+		r.Get("/cohorts/{cohortId}/roster", cohortHandler.GetRoster)
+		r.Get("/cohorts/{cohortId}/sessions", cohortHandler.ListSessions)
+		r.Get("/cohorts/{cohortId}/announcements", cohortHandler.ListAnnouncements)
+		r.Get("/cohort-sessions/{sessionId}", cohortHandler.GetSession)
+
+		// Curriculum progress
+		r.Get("/mentorships/{requestId}/curriculum", curriculumHandler.GetProgress)
+		r.Put("/mentorships/{requestId}/curriculum/modules/{moduleId}", curriculumHandler.SetModuleCompletion)
+		r.Get("/resources/{resourceId}/file", curriculumHandler.DownloadResource)
+
+		// Assignments
+		r.Get("/mentorships/{requestId}/assignments", assignmentHandler.ListMentorshipAssignments)
+		r.Get("/mentorships/{requestId}/assignments/{assignmentId}/submissions", assignmentHandler.ListSubmissions)
+		r.Post("/mentorships/{requestId}/assignments/{assignmentId}/submissions", assignmentHandler.Submit)
+		r.Get("/submissions/{submissionId}/file", assignmentHandler.DownloadSubmissionFile)
+
+		// Mentorship agreement
+		r.Get("/mentorships/{requestId}/agreement", agreementHandler.GetAgreement)
+		r.Post("/mentorships/{requestId}/agreement/accept", agreementHandler.AcceptAgreement)
+
+		// Billing
+		r.Post("/mentorships/{requestId}/cancel", mentorshipHandler.CancelMentorship)
+		r.Get("/mentorships/{requestId}/invoice", paymentHandler.GetMentorshipInvoice)
+		r.Post("/mentorships/{requestId}/checkout", paymentHandler.Checkout)
+		r.Get("/invoices", paymentHandler.ListInvoices)
+		r.Get("/invoices/{invoiceId}", paymentHandler.GetInvoice)
+		r.Get("/invoices/{invoiceId}/html", paymentHandler.RenderInvoice)
+		r.Get("/mentorships/{requestId}/sponsorship", sponsorHandler.GetMentorshipSponsorship)
+
+		// Matching rounds open for participants
+		r.Get("/matching-rounds", matchingHandler.ListOpenRounds)
+		r.Get("/matching-rounds/{roundId}/mentors", matchingHandler.ListRoundMentors)
+
+		// Job postings by approved mentors and employers
+		r.Post("/jobs", jobHandler.CreatePosting)
+		r.Get("/jobs/mine", jobHandler.ListMyPostings)
+		r.Put("/jobs/{jobId}", jobHandler.UpdatePosting)
+		r.Get("/jobs/{jobId}/history", jobHandler.ListRevisions)
+		r.Post("/jobs/{jobId}/renew", jobHandler.RenewPosting)
+		r.Post("/jobs/{jobId}/close", jobHandler.ClosePosting)
+
+		// Job applications
+		r.Get("/jobs/{jobId}/apply", homeHandler.GetJobApplication)
+		r.Post("/jobs/{jobId}/apply", jobHandler.Apply)
+		r.Get("/jobs/{jobId}/applications", jobHandler.ListJobApplications)
+		r.Get("/job-applications", jobHandler.ListMyApplications)
+		r.Get("/job-applications/{applicationId}", jobHandler.GetApplication)
+		r.Put("/job-applications/{applicationId}/status", jobHandler.UpdateApplicationStatus)
+		r.Post("/job-applications/{applicationId}/notes", jobHandler.AddApplicationNote)
+		r.Post("/job-applications/{applicationId}/withdraw", jobHandler.WithdrawApplication)
+		r.Get("/job-applications/{applicationId}/resume", jobHandler.DownloadResume)
+
+		// Job referrals
+		r.Post("/jobs/{jobId}/referrals", jobHandler.ReferMentee)
+		r.Get("/job-referrals", jobHandler.ListMyReferrals)
+		r.Get("/job-referrals/received", jobHandler.ListReceivedReferrals)
+
+		// Saved job searches, bookmarks and suggestions
+		r.Get("/job-searches", jobAlertHandler.ListSavedSearches)
+		r.Post("/job-searches", jobAlertHandler.CreateSavedSearch)
+		r.Put("/job-searches/{searchId}", jobAlertHandler.UpdateSavedSearch)
+		r.Delete("/job-searches/{searchId}", jobAlertHandler.DeleteSavedSearch)
+		r.Get("/jobs/saved", jobAlertHandler.ListSavedJobs)
+		r.Put("/jobs/{jobId}/save", jobAlertHandler.SaveJob)
+		r.Delete("/jobs/{jobId}/save", jobAlertHandler.UnsaveJob)
+		r.Get("/jobs/similar", jobAlertHandler.SimilarJobs)
+
+		// Notifications
+		r.Get("/notifications", notificationHandler.ListNotifications)
+		r.Post("/notifications/{notificationId}/read", notificationHandler.MarkRead)
+
 		// Mentee routes
 		r.Route("/mentee", func(r chi.Router) {
+			r.Get("/dashboard", homeHandler.GetMenteeDashboard)
 			r.Get("/programs", mentorshipHandler.ListAvailablePrograms)
+			r.Get("/programs/{programId}", mentorshipHandler.GetProgramDetails)
 			r.Post("/request/{programId}", mentorshipHandler.RequestMentorship)
 			r.Get("/sessions", mentorshipHandler.ListMenteeSessions)
+			r.Get("/cohorts", cohortHandler.ListMenteeCohorts)
+			r.Post("/cohorts/{cohortId}/enroll", cohortHandler.Enroll)
+			r.Delete("/cohorts/{cohortId}/enroll", cohortHandler.Withdraw)
+
+			// Mentor recommendations
+			r.Get("/recommendations", recommendationHandler.ListRecommendations)
+			r.Post("/recommendations/{mentorId}/dismiss", recommendationHandler.Dismiss)
+			r.Post("/recommendations/{mentorId}/not-interested", recommendationHandler.MarkNotInterested)
+			r.Delete("/recommendations/{mentorId}/feedback", recommendationHandler.ClearFeedback)
+
+			// Matching rounds
+			r.Put("/matching-rounds/{roundId}/preferences", matchingHandler.SubmitPreferences)
 		})
 	})
 
@@ -93,14 +310,68 @@ func SetupRoutes(
 			r.Get("/users", adminHandler.ListUsers)
 			r.Get("/profiles", adminHandler.ListProfiles)
 			r.Post("/mentors/{userId}/approve", adminHandler.ApproveMentor)
+			r.Post("/employers/{userId}/approve", adminHandler.ApproveEmployer)
 
-			// Job management
+			// Job management and moderation
 			r.Route("/jobs", func(r chi.Router) {
-				r.Get("/", adminHandler.ListJobs)
-				r.Post("/", adminHandler.CreateJob)
-				r.Delete("/{jobId}", adminHandler.DeleteJob)
-				r.Post("/{jobId}/feature", adminHandler.FeatureJob)
+				r.Get("/", jobHandler.ListJobs)
+				r.Post("/", jobHandler.CreatePosting)
+				r.Post("/import", jobHandler.ImportJobs)
+				r.Delete("/{jobId}", jobHandler.ClosePosting)
+				r.Post("/{jobId}/approve", jobHandler.ApproveJob)
+				r.Post("/{jobId}/reject", jobHandler.RejectJob)
+				r.Post("/{jobId}/feature", jobHandler.FeatureJob)
 			})
+
+			// Review moderation
+			r.Get("/reviews", feedbackHandler.ListModerationQueue)
+			r.Post("/reviews/{feedbackId}/moderate", feedbackHandler.ModerateReview)
+
+			// Mentorship agreements
+			r.Get("/agreements", agreementHandler.ListDefaultTemplates)
+			r.Post("/agreements", agreementHandler.PublishDefaultTemplate)
+			r.Get("/programs/{programId}/agreements", agreementHandler.ListProgramTemplates)
+			r.Post("/programs/{programId}/agreements", agreementHandler.PublishProgramTemplate)
+			r.Get("/mentorships/{requestId}/agreement", agreementHandler.GetAcceptanceRecord)
+
+			// Payments
+			r.Post("/invoices/{invoiceId}/refund", paymentHandler.RefundInvoice)
+			r.Get("/invoices/{invoiceId}/ledger", paymentHandler.GetInvoiceLedger)
+
+			// Sponsors
+			r.Get("/sponsors", sponsorHandler.ListSponsors)
+			r.Post("/sponsors", sponsorHandler.CreateSponsor)
+			r.Get("/sponsors/{sponsorId}", sponsorHandler.GetSponsor)
+			r.Put("/sponsors/{sponsorId}", sponsorHandler.UpdateSponsor)
+			r.Get("/sponsors/{sponsorId}/report", sponsorHandler.GetSponsorReport)
+
+			// Skills taxonomy
+			r.Post("/skills/categories", skillHandler.CreateCategory)
+			r.Put("/skills/categories/{categoryId}", skillHandler.UpdateCategory)
+			r.Delete("/skills/categories/{categoryId}", skillHandler.DeleteCategory)
+			r.Post("/skills", skillHandler.CreateSkill)
+			r.Put("/skills/{skillId}", skillHandler.UpdateSkill)
+			r.Delete("/skills/{skillId}", skillHandler.DeleteSkill)
+			r.Post("/skills/{skillId}/synonyms", skillHandler.AddSynonym)
+			r.Delete("/skills/{skillId}/synonyms/{synonym}", skillHandler.RemoveSynonym)
+			r.Post("/skills/{skillId}/merge", skillHandler.MergeSkill)
+
+			// Matching rounds
+			r.Get("/matching-rounds", matchingHandler.ListRounds)
+			r.Post("/matching-rounds", matchingHandler.CreateRound)
+			r.Get("/matching-rounds/{roundId}", matchingHandler.GetRound)
+			r.Post("/matching-rounds/{roundId}/run", matchingHandler.RunMatching)
+			r.Put("/matching-rounds/{roundId}/pairs/{menteeId}", matchingHandler.AssignPair)
+			r.Delete("/matching-rounds/{roundId}/pairs/{menteeId}", matchingHandler.UnassignPair)
+			r.Post("/matching-rounds/{roundId}/publish", matchingHandler.PublishRound)
+
+			// Featured mentors
+			r.Get("/featured-mentors", featuredHandler.ListFeatures)
+			r.Post("/featured-mentors", featuredHandler.CreateFeature)
+			r.Get("/featured-mentors/mode", featuredHandler.GetMode)
+			r.Put("/featured-mentors/mode", featuredHandler.SetMode)
+			r.Put("/featured-mentors/{featureId}", featuredHandler.UpdateFeature)
+			r.Delete("/featured-mentors/{featureId}", featuredHandler.DeleteFeature)
 		})
 	})
 
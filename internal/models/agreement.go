@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// AgreementTemplate is one immutable version of a code of conduct. Templates
+// without a ProgramID are the platform default; others override it for a program.
+type AgreementTemplate struct {
+	ID        int       `json:"id"`
+	ProgramID *int      `json:"program_id,omitempty"`
+	Version   int       `json:"version"`
+	Title     string    `json:"title" validate:"required"`
+	Body      string    `json:"body" validate:"required"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AgreementAcceptance records who accepted which agreement version, when and from where
+type AgreementAcceptance struct {
+	ID         int       `json:"id"`
+	RequestID  int       `json:"request_id"`
+	TemplateID int       `json:"template_id"`
+	UserID     int       `json:"user_id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+// MentorshipAgreement is the agreement a mentorship must accept before it starts
+type MentorshipAgreement struct {
+	RequestID      int                    `json:"request_id"`
+	Template       *AgreementTemplate     `json:"template"`
+	Acceptances    []*AgreementAcceptance `json:"acceptances"`
+	MentorAccepted bool                   `json:"mentor_accepted"`
+	MenteeAccepted bool                   `json:"mentee_accepted"`
+}
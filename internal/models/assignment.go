@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+)
+
+// Assignment is work a mentor sets, either for every mentee of a program or for one mentorship
+type Assignment struct {
+	ID               int               `json:"id"`
+	ProgramID        *int              `json:"program_id,omitempty"`
+	RequestID        *int              `json:"request_id,omitempty"`
+	CreatedBy        int               `json:"created_by"`
+	Title            string            `json:"title" validate:"required"`
+	Instructions     string            `json:"instructions"`
+	DueAt            *time.Time        `json:"due_at,omitempty"`
+	Rubric           []RubricCriterion `json:"rubric"`
+	MaxResubmissions int               `json:"max_resubmissions"`
+	LatestSubmission *Submission       `json:"latest_submission,omitempty"` // Set when listed for a mentorship
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+// RubricCriterion is one scored aspect of an assignment
+type RubricCriterion struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MaxPoints   int    `json:"max_points"`
+}
+
+// MaxScore returns the total points available across the rubric
+func (a *Assignment) MaxScore() int {
+	total := 0
+	for _, criterion := range a.Rubric {
+		total += criterion.MaxPoints
+	}
+	return total
+}
+
+// Submission is one round of a mentee's work on an assignment
+type Submission struct {
+	ID           int               `json:"id"`
+	AssignmentID int               `json:"assignment_id"`
+	RequestID    int               `json:"request_id"`
+	MenteeID     int               `json:"mentee_id"`
+	Round        int               `json:"round"`
+	Body         string            `json:"body"`
+	Links        []string          `json:"links"`
+	FileKey      string            `json:"-"`
+	FileName     string            `json:"file_name,omitempty"`
+	ContentType  string            `json:"content_type,omitempty"`
+	SizeBytes    int64             `json:"size_bytes,omitempty"`
+	Status       string            `json:"status"`
+	Late         bool              `json:"late"`
+	Review       *SubmissionReview `json:"review,omitempty"`
+	SubmittedAt  time.Time         `json:"submitted_at"`
+}
+
+// Submission status constants
+var SubmissionStatus = struct {
+	Submitted        string
+	ChangesRequested string
+	Approved         string
+}{
+	Submitted:        "submitted",
+	ChangesRequested: "changes_requested",
+	Approved:         "approved",
+}
+
+// SubmissionReview is the mentor's rubric-based assessment of a submission
+type SubmissionReview struct {
+	ID           int           `json:"id"`
+	SubmissionID int           `json:"submission_id"`
+	ReviewerID   int           `json:"reviewer_id"`
+	Scores       []RubricScore `json:"scores"`
+	TotalScore   int           `json:"total_score"`
+	MaxScore     int           `json:"max_score"`
+	Comment      string        `json:"comment"`
+	Decision     string        `json:"decision"` // changes_requested or approved
+	ReviewedAt   time.Time     `json:"reviewed_at"`
+}
+
+// RubricScore is the points awarded against one rubric criterion
+type RubricScore struct {
+	Criterion string `json:"criterion"`
+	Points    int    `json:"points"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// PendingReview is a submission waiting in a mentor's review queue
+type PendingReview struct {
+	Submission      *Submission `json:"submission"`
+	AssignmentTitle string      `json:"assignment_title"`
+	MenteeName      string      `json:"mentee_name"`
+}
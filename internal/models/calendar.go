@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeed is an external calendar a mentor syncs free/busy data from
+type CalendarFeed struct {
+	ID           int        `json:"id"`
+	MentorID     int        `json:"mentor_id"`
+	URL          string     `json:"url"`
+	FeedType     string     `json:"feed_type" validate:"required,oneof=ics caldav"`
+	Enabled      bool       `json:"enabled"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Calendar feed type constants
+var CalendarFeedType = struct {
+	ICS    string
+	CalDAV string
+}{
+	ICS:    "ics",
+	CalDAV: "caldav",
+}
+
+// BusyBlock is a period during which a mentor is unavailable according to their calendar
+type BusyBlock struct {
+	ID        int       `json:"id"`
+	FeedID    int       `json:"feed_id"`
+	MentorID  int       `json:"mentor_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BookableSlot is a free period a mentee can book a session in
+type BookableSlot struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Overlaps reports whether the block intersects the given period
+func (b *BusyBlock) Overlaps(start, end time.Time) bool {
+	return b.StartTime.Before(end) && start.Before(b.EndTime)
+}
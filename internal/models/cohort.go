@@ -0,0 +1,140 @@
+package models
+
+import (
+	"time"
+)
+
+// Cohort is one run of a program that a group of mentees starts and finishes together
+type Cohort struct {
+	ID                 int       `json:"id"`
+	ProgramID          int       `json:"program_id"`
+	MentorID           int       `json:"mentor_id"`
+	Name               string    `json:"name" validate:"required"`
+	EnrollmentOpensAt  time.Time `json:"enrollment_opens_at"`
+	EnrollmentClosesAt time.Time `json:"enrollment_closes_at"`
+	StartsOn           time.Time `json:"starts_on"`
+	EndsOn             time.Time `json:"ends_on"`
+	Capacity           int       `json:"capacity" validate:"min=1"`
+	Status             string    `json:"status"`
+	EnrolledCount      int       `json:"enrolled_count"`
+	Phase              string    `json:"phase"` // Derived from the dates, see CurrentPhase
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// Cohort status constants
+var CohortStatus = struct {
+	Active    string
+	Cancelled string
+}{
+	Active:    "active",
+	Cancelled: "cancelled",
+}
+
+// Cohort phase constants, as reported by CurrentPhase
+var CohortPhase = struct {
+	Cancelled    string
+	Upcoming     string
+	Enrolling    string
+	StartingSoon string
+	InProgress   string
+	Finished     string
+}{
+	Cancelled:    "cancelled",
+	Upcoming:     "upcoming",
+	Enrolling:    "enrolling",
+	StartingSoon: "starting_soon",
+	InProgress:   "in_progress",
+	Finished:     "finished",
+}
+
+// EnrollmentOpen reports whether mentees may join the cohort at the given time
+func (c *Cohort) EnrollmentOpen(now time.Time) bool {
+	return c.Status == CohortStatus.Active &&
+		!now.Before(c.EnrollmentOpensAt) &&
+		now.Before(c.EnrollmentClosesAt)
+}
+
+// CurrentPhase describes where the cohort is in its lifecycle at the given time
+func (c *Cohort) CurrentPhase(now time.Time) string {
+	switch {
+	case c.Status == CohortStatus.Cancelled:
+		return CohortPhase.Cancelled
+	case now.Before(c.EnrollmentOpensAt):
+		return CohortPhase.Upcoming
+	case c.EnrollmentOpen(now):
+		return CohortPhase.Enrolling
+	case now.Before(c.StartsOn):
+		return CohortPhase.StartingSoon
+	case now.Before(c.EndsOn.AddDate(0, 0, 1)):
+		return CohortPhase.InProgress
+	default:
+		return CohortPhase.Finished
+	}
+}
+
+// CohortMember is a mentee on a cohort's roster
+type CohortMember struct {
+	CohortID         int       `json:"cohort_id"`
+	MenteeID         int       `json:"mentee_id"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+	Email            string    `json:"email"`
+	Status           string    `json:"status"`
+	EnrolledAt       time.Time `json:"enrolled_at"`
+	SessionsTotal    int       `json:"sessions_total"`
+	SessionsAttended int       `json:"sessions_attended"`
+}
+
+// Cohort member status constants
+var CohortMemberStatus = struct {
+	Enrolled  string
+	Withdrawn string
+}{
+	Enrolled:  "enrolled",
+	Withdrawn: "withdrawn",
+}
+
+// CohortSession is a group session attended by the whole cohort
+type CohortSession struct {
+	ID         int                 `json:"id"`
+	CohortID   int                 `json:"cohort_id"`
+	Title      string              `json:"title" validate:"required"`
+	Topic      string              `json:"topic"`
+	StartTime  time.Time           `json:"start_time"`
+	EndTime    time.Time           `json:"end_time"`
+	Status     string              `json:"status"`
+	MeetingURL string              `json:"meeting_url,omitempty"`
+	Attendance []*AttendanceRecord `json:"attendance,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// AttendanceRecord is one mentee's attendance at a cohort session
+type AttendanceRecord struct {
+	SessionID  int       `json:"session_id"`
+	MenteeID   int       `json:"mentee_id"`
+	Status     string    `json:"status"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// Attendance status constants
+var AttendanceStatus = struct {
+	Present string
+	Absent  string
+	Excused string
+}{
+	Present: "present",
+	Absent:  "absent",
+	Excused: "excused",
+}
+
+// CohortAnnouncement is a message from the mentor to everyone in a cohort
+type CohortAnnouncement struct {
+	ID        int       `json:"id"`
+	CohortID  int       `json:"cohort_id"`
+	AuthorID  int       `json:"author_id"`
+	Title     string    `json:"title" validate:"required"`
+	Body      string    `json:"body" validate:"required"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+)
+
+// CurriculumVersion is one revision of a program's curriculum. Only drafts can
+// be edited; mentees stay on the published version they started with.
+type CurriculumVersion struct {
+	ID          int                 `json:"id"`
+	ProgramID   int                 `json:"program_id"`
+	Version     int                 `json:"version"`
+	Status      string              `json:"status"`
+	PublishedAt *time.Time          `json:"published_at,omitempty"`
+	CreatedBy   int                 `json:"created_by"`
+	Modules     []*CurriculumModule `json:"modules"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// Curriculum version status constants
+var CurriculumStatus = struct {
+	Draft     string
+	Published string
+}{
+	Draft:     "draft",
+	Published: "published",
+}
+
+// CurriculumModule is an ordered unit of a curriculum
+type CurriculumModule struct {
+	ID         int               `json:"id"`
+	VersionID  int               `json:"version_id"`
+	Position   int               `json:"position"`
+	Title      string            `json:"title" validate:"required"`
+	Summary    string            `json:"summary"`
+	Objectives []string          `json:"objectives"`
+	Assignment string            `json:"assignment,omitempty"` // Optional task for the mentee
+	Resources  []*ModuleResource `json:"resources"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// ModuleResource is a link or uploaded file attached to a module
+type ModuleResource struct {
+	ID          int       `json:"id"`
+	ModuleID    int       `json:"module_id"`
+	Kind        string    `json:"kind"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url,omitempty"`
+	FileKey     string    `json:"-"`
+	FileName    string    `json:"file_name,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	SizeBytes   int64     `json:"size_bytes,omitempty"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Resource kind constants
+var ResourceKind = struct {
+	Link string
+	File string
+}{
+	Link: "link",
+	File: "file",
+}
+
+// ModuleCompletion records a mentee finishing a module
+type ModuleCompletion struct {
+	ModuleID    int       `json:"module_id"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// CurriculumProgress is the curriculum a mentorship follows and how far the mentee has got
+type CurriculumProgress struct {
+	RequestID   int                 `json:"request_id"`
+	Curriculum  *CurriculumVersion  `json:"curriculum"`
+	Completions []*ModuleCompletion `json:"completions"`
+	Progress    int                 `json:"progress"` // Percentage of modules completed
+}
+
+// CalculateProgress sets Progress from the completed share of the curriculum's modules
+func (p *CurriculumProgress) CalculateProgress() {
+	if p.Curriculum == nil || len(p.Curriculum.Modules) == 0 {
+		p.Progress = 0
+		return
+	}
+	p.Progress = len(p.Completions) * 100 / len(p.Curriculum.Modules)
+}
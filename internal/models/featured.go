@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// FeaturedMentor is a mentor shown on the home page. Curated entries have an
+// ID and a display window; mentors added by the automatic rotation do not.
+type FeaturedMentor struct {
+	ID             int        `json:"id,omitempty"`
+	MentorID       int        `json:"mentor_id"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Headline       string     `json:"headline"`
+	Skills         string     `json:"skills"`
+	Rate           float64    `json:"rate"`
+	ProfilePicture string     `json:"profile_picture,omitempty"`
+	AverageRating  float64    `json:"average_rating"`
+	RatingCount    int        `json:"rating_count"`
+	Position       int        `json:"position"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	Source         string     `json:"source"`
+	CreatedBy      int        `json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Featured mentor sources
+var FeaturedSource = struct {
+	Manual    string
+	Automatic string
+}{
+	Manual:    "manual",
+	Automatic: "automatic",
+}
+
+// Featured mentors modes. Manual shows only curated mentors; automatic fills
+// the remaining places with a rotation of top-rated, available mentors.
+var FeaturedMentorsMode = struct {
+	Manual    string
+	Automatic string
+}{
+	Manual:    "manual",
+	Automatic: "automatic",
+}
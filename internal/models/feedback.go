@@ -4,22 +4,79 @@ import (
 	"time"
 )
 
+// FeedbackEditWindow is how long after submitting a participant may still change their feedback
+const FeedbackEditWindow = 7 * 24 * time.Hour
+
 // SessionFeedback represents feedback for a mentorship session
 type SessionFeedback struct {
-	ID        int       `json:"id"`
-	SessionID int       `json:"session_id"`
-	UserID    int       `json:"user_id"`
-	Rating    int       `json:"rating"`
-	Comment   string    `json:"comment"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               int        `json:"id"`
+	SessionID        int        `json:"session_id"`
+	UserID           int        `json:"user_id"`
+	MentorID         int        `json:"mentor_id"` // Mentor of the session's mentorship
+	Rating           int        `json:"rating"`
+	Comment          string     `json:"comment"`
+	IsPublic         bool       `json:"is_public"` // Author opted in to showing it as a testimonial
+	ModerationStatus string     `json:"moderation_status"`
+	ModerationFlags  []string   `json:"moderation_flags,omitempty"` // Raised by automatic content checks
+	ModerationNote   string     `json:"moderation_note,omitempty"`
+	ModeratedBy      *int       `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	MentorReply      string     `json:"mentor_reply,omitempty"`
+	MentorRepliedAt  *time.Time `json:"mentor_replied_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Review moderation status constants
+var ReviewStatus = struct {
+	Pending  string
+	Approved string
+	Hidden   string
+	Flagged  string
+}{
+	Pending:  "pending",
+	Approved: "approved",
+	Hidden:   "hidden",
+	Flagged:  "flagged",
+}
+
+// CountsTowardRating reports whether the feedback is a mentee rating the mentor
+func (f *SessionFeedback) CountsTowardRating() bool {
+	return f.UserID != f.MentorID
+}
+
+// Editable reports whether the feedback can still be changed at the given time
+func (f *SessionFeedback) Editable(now time.Time) bool {
+	return now.Before(f.CreatedAt.Add(FeedbackEditWindow))
 }
 
 // FeedbackResponse represents the response shown to users
 type FeedbackResponse struct {
-	ID        int       `json:"id"`
-	Rating    int       `json:"rating"`
-	Comment   string    `json:"comment,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           int       `json:"id"`
+	SessionID    int       `json:"session_id"`
+	SessionTitle string    `json:"session_title,omitempty"`
+	Rating       int       `json:"rating"`
+	Comment      string    `json:"comment,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Testimonial is an approved public review shown on a mentor's profile
+type Testimonial struct {
+	ID              int        `json:"id"`
+	Rating          int        `json:"rating"`
+	Comment         string     `json:"comment"`
+	AuthorName      string     `json:"author_name"` // First name and last initial
+	MentorReply     string     `json:"mentor_reply,omitempty"`
+	MentorRepliedAt *time.Time `json:"mentor_replied_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// RatingSummary aggregates the ratings mentees have given a mentor
+type RatingSummary struct {
+	MentorID      int         `json:"mentor_id"`
+	AverageRating float64     `json:"average_rating"`
+	RatingCount   int         `json:"rating_count"`
+	Distribution  map[int]int `json:"distribution,omitempty"` // Number of ratings per star value
 }
 
 // ValidationRules returns the validation rules for feedback
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+)
+
+// Goal is something the mentee wants to achieve during a mentorship, described with SMART fields
+type Goal struct {
+	ID         int          `json:"id"`
+	RequestID  int          `json:"request_id"`
+	Title      string       `json:"title" validate:"required"`
+	Specific   string       `json:"specific"`
+	Measurable string       `json:"measurable"`
+	Achievable string       `json:"achievable"`
+	Relevant   string       `json:"relevant"`
+	TimeBound  string       `json:"time_bound"`
+	TargetDate *time.Time   `json:"target_date,omitempty"`
+	Status     string       `json:"status"`
+	AchievedAt *time.Time   `json:"achieved_at,omitempty"`
+	CreatedBy  int          `json:"created_by"`
+	Progress   int          `json:"progress"` // Derived from milestones, 0-100
+	Milestones []*Milestone `json:"milestones"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// Goal status constants
+var GoalStatus = struct {
+	Active    string
+	Achieved  string
+	Abandoned string
+}{
+	Active:    "active",
+	Achieved:  "achieved",
+	Abandoned: "abandoned",
+}
+
+// CalculateProgress sets Progress from the goal's status and milestones
+func (g *Goal) CalculateProgress() {
+	if g.Status == GoalStatus.Achieved {
+		g.Progress = 100
+		return
+	}
+	if len(g.Milestones) == 0 {
+		g.Progress = 0
+		return
+	}
+
+	total := 0
+	for _, m := range g.Milestones {
+		total += m.Progress
+	}
+	g.Progress = total / len(g.Milestones)
+}
+
+// Milestone is a step towards a goal
+type Milestone struct {
+	ID          int        `json:"id"`
+	GoalID      int        `json:"goal_id"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description"`
+	Progress    int        `json:"progress" validate:"min=0,max=100"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Position    int        `json:"position"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// MilestoneUpdate holds the milestone fields a participant may change
+type MilestoneUpdate struct {
+	Title       *string
+	Description *string
+	Progress    *int
+	DueDate     *time.Time
+	ClearDue    bool
+	Position    *int
+}
+
+// CheckIn is a progress update on a goal, optionally recorded during a session
+type CheckIn struct {
+	ID        int       `json:"id"`
+	GoalID    int       `json:"goal_id"`
+	SessionID *int      `json:"session_id,omitempty"`
+	UserID    int       `json:"user_id"`
+	Progress  int       `json:"progress" validate:"min=0,max=100"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TimelineEvent is one entry in a mentorship's progress timeline
+type TimelineEvent struct {
+	Type       string    `json:"type"`
+	RequestID  int       `json:"request_id"`
+	GoalID     int       `json:"goal_id"`
+	GoalTitle  string    `json:"goal_title"`
+	Title      string    `json:"title"`
+	Detail     string    `json:"detail,omitempty"`
+	Progress   *int      `json:"progress,omitempty"`
+	SessionID  *int      `json:"session_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Timeline event type constants
+var TimelineEventType = struct {
+	GoalCreated        string
+	GoalAchieved       string
+	MilestoneCompleted string
+	CheckIn            string
+}{
+	GoalCreated:        "goal_created",
+	GoalAchieved:       "goal_achieved",
+	MilestoneCompleted: "milestone_completed",
+	CheckIn:            "check_in",
+}
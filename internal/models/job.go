@@ -6,33 +6,67 @@ import (
 
 // Job represents a job posting
 type Job struct {
-	ID              int       `json:"id"`
-	Title           string    `json:"title" validate:"required"`
-	Company         string    `json:"company" validate:"required"`
-	Location        string    `json:"location"`
-	Description     string    `json:"description" validate:"required"`
-	Requirements    string    `json:"requirements"`
-	SalaryRange     string    `json:"salary_range"`
-	JobType         string    `json:"job_type" validate:"required,oneof=full-time part-time contract"`
-	ExperienceLevel string    `json:"experience_level" validate:"required,oneof=entry mid senior"`
-	RemotePolicy    string    `json:"remote_policy" validate:"required,oneof=remote hybrid on-site"`
-	ContactEmail    string    `json:"contact_email,omitempty"`
-	Status          string    `json:"status" validate:"required,oneof=active expired closed"`
-	IsFeatured      bool      `json:"is_featured"`
-	CreatedBy       int       `json:"created_by"` // Adding CreatedBy field
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              int        `json:"id"`
+	Title           string     `json:"title" validate:"required"`
+	Company         string     `json:"company" validate:"required"`
+	Location        string     `json:"location"`
+	Description     string     `json:"description" validate:"required"`
+	Requirements    string     `json:"requirements"`
+	SalaryRange     string     `json:"salary_range"`
+	JobType         string     `json:"job_type" validate:"required,oneof=full-time part-time contract"`
+	ExperienceLevel string     `json:"experience_level" validate:"required,oneof=entry mid senior"`
+	RemotePolicy    string     `json:"remote_policy" validate:"required,oneof=remote hybrid on-site"`
+	ContactEmail    string     `json:"contact_email,omitempty"`
+	Status          string     `json:"status" validate:"required,oneof=pending active expired closed rejected"`
+	IsFeatured      bool       `json:"is_featured"`
+	CreatedBy       int        `json:"created_by"` // Adding CreatedBy field
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	RenewalCount    int        `json:"renewal_count"`
+	ModeratedBy     int        `json:"moderated_by,omitempty"`
+	ModeratedAt     *time.Time `json:"moderated_at,omitempty"`
+	ModerationNote  string     `json:"moderation_note,omitempty"`
+	ExternalID      string     `json:"external_id,omitempty"` // ID in the spreadsheet the posting was imported from
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// PublishedAt is when the posting went on the board: its approval, or its
+// creation for postings from before moderation
+func (j *Job) PublishedAt() time.Time {
+	if j.ModeratedAt != nil {
+		return *j.ModeratedAt
+	}
+	return j.CreatedAt
 }
 
 // Job Status constants
 var JobStatus = struct {
-	Active  string
-	Expired string
-	Closed  string
+	Pending  string
+	Active   string
+	Expired  string
+	Closed   string
+	Rejected string
 }{
-	Active:  "active",
-	Expired: "expired",
-	Closed:  "closed",
+	Pending:  "pending",
+	Active:   "active",
+	Expired:  "expired",
+	Closed:   "closed",
+	Rejected: "rejected",
+}
+
+// JobFieldChange is the old and new value of an edited posting field
+type JobFieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// JobRevision is one edit of a job posting, keyed by the changed fields' JSON names
+type JobRevision struct {
+	ID        int                        `json:"id"`
+	JobID     int                        `json:"job_id"`
+	EditorID  int                        `json:"editor_id,omitempty"`
+	Changes   map[string]*JobFieldChange `json:"changes"`
+	CreatedAt time.Time                  `json:"created_at"`
 }
 
 // Remote Policy constants
@@ -68,27 +102,199 @@ var JobType = struct {
 	Contract: "contract",
 }
 
+// JobSearchQuery filters and pages the active jobs on the job board
+type JobSearchQuery struct {
+	Text             string // Keywords matched against title, company, description and requirements
+	Location         string // Case-insensitive substring of the job's location
+	JobType          string
+	ExperienceLevel  string
+	RemotePolicy     string
+	PublishedAfter   *time.Time // Only jobs approved (or posted by an admin) after this time
+	ExcludeAppliedBy int        // Leaves out jobs this user posted or has a live application for
+	NewestFirst      bool       // Orders by publication alone, ignoring featuring and keyword rank
+	Page             int
+	PageSize         int
+}
+
+// JobSearchResult is one page of job board results
+type JobSearchResult struct {
+	Jobs       []*Job `json:"jobs"`
+	Total      int    `json:"total"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalPages int    `json:"total_pages"`
+}
+
+// SavedJobSearch is a set of job board filters a user is alerted about,
+// either as soon as a matching job is published or in a daily digest
+type SavedJobSearch struct {
+	ID              int       `json:"id"`
+	UserID          int       `json:"user_id"`
+	Name            string    `json:"name"`
+	Text            string    `json:"q"`
+	Location        string    `json:"location"`
+	JobType         string    `json:"job_type"`
+	ExperienceLevel string    `json:"experience_level"`
+	RemotePolicy    string    `json:"remote_policy"`
+	Frequency       string    `json:"frequency" validate:"required,oneof=instant daily"`
+	LastAlertedAt   time.Time `json:"last_alerted_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// SearchQuery returns the job board query the saved search stands for
+func (s *SavedJobSearch) SearchQuery() *JobSearchQuery {
+	return &JobSearchQuery{
+		Text:            s.Text,
+		Location:        s.Location,
+		JobType:         s.JobType,
+		ExperienceLevel: s.ExperienceLevel,
+		RemotePolicy:    s.RemotePolicy,
+	}
+}
+
+var AlertFrequency = struct {
+	Instant string
+	Daily   string
+}{
+	Instant: "instant",
+	Daily:   "daily",
+}
+
+// SavedJob is a job a user bookmarked, whatever the job's status is now
+type SavedJob struct {
+	Job     *Job      `json:"job"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// JobImportRow is the outcome of one row of a bulk job import: the job it
+// would create or the fields it would change, or why it was rejected
+type JobImportRow struct {
+	Row        int                        `json:"row"`
+	ExternalID string                     `json:"external_id"`
+	Action     string                     `json:"action"`
+	JobID      int                        `json:"job_id,omitempty"`
+	Title      string                     `json:"title,omitempty"`
+	Changes    map[string]*JobFieldChange `json:"changes,omitempty"`
+	Errors     []string                   `json:"errors,omitempty"`
+}
+
+// JobImportResult reports a bulk job import. Rows with errors are skipped and
+// nothing is written on a dry run.
+type JobImportResult struct {
+	DryRun    bool            `json:"dry_run"`
+	Applied   bool            `json:"applied"`
+	Created   int             `json:"created"`
+	Updated   int             `json:"updated"`
+	Unchanged int             `json:"unchanged"`
+	Failed    int             `json:"failed"`
+	Rows      []*JobImportRow `json:"rows"`
+}
+
+var JobImportAction = struct {
+	Create    string
+	Update    string
+	Unchanged string
+	Error     string
+}{
+	Create:    "create",
+	Update:    "update",
+	Unchanged: "unchanged",
+	Error:     "error",
+}
+
 // JobApplication represents a user's application for a job
 type JobApplication struct {
-	ID          int       `json:"id"`
-	JobID       int       `json:"job_id"`
-	UserID      int       `json:"user_id"`
-	Status      string    `json:"status" validate:"required,oneof=pending reviewed accepted rejected"`
-	CoverLetter string    `json:"cover_letter"`
-	ResumeURL   string    `json:"resume_url,omitempty"`
-	AppliedAt   time.Time `json:"applied_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                int                    `json:"id"`
+	JobID             int                    `json:"job_id"`
+	JobTitle          string                 `json:"job_title,omitempty"`
+	Company           string                 `json:"company,omitempty"`
+	UserID            int                    `json:"user_id"`
+	ApplicantName     string                 `json:"applicant_name,omitempty"`
+	Status            string                 `json:"status" validate:"required,oneof=pending reviewed interview accepted rejected withdrawn"`
+	CoverLetter       string                 `json:"cover_letter"`
+	ResumeURL         string                 `json:"resume_url,omitempty"`
+	ResumeKey         string                 `json:"-"`
+	ResumeFileName    string                 `json:"resume_file_name,omitempty"`
+	ResumeContentType string                 `json:"resume_content_type,omitempty"`
+	ResumeSizeBytes   int64                  `json:"resume_size_bytes,omitempty"`
+	ReferrerID        int                    `json:"referrer_id,omitempty"`
+	ReferrerName      string                 `json:"referrer_name,omitempty"`
+	Events            []*JobApplicationEvent `json:"events,omitempty"`
+	AppliedAt         time.Time              `json:"applied_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// JobApplicationEvent is a status change or reviewer note on an application.
+// Notes are only shown to the job's poster and admins.
+type JobApplicationEvent struct {
+	ID            int       `json:"id"`
+	ApplicationID int       `json:"application_id"`
+	AuthorID      int       `json:"author_id,omitempty"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	Note          string    `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // Application Status constants
 var ApplicationStatus = struct {
-	Pending  string
-	Reviewed string
-	Accepted string
-	Rejected string
+	Pending   string
+	Reviewed  string
+	Interview string
+	Accepted  string
+	Rejected  string
+	Withdrawn string
 }{
-	Pending:  "pending",
-	Reviewed: "reviewed",
-	Accepted: "accepted",
-	Rejected: "rejected",
+	Pending:   "pending",
+	Reviewed:  "reviewed",
+	Interview: "interview",
+	Accepted:  "accepted",
+	Rejected:  "rejected",
+	Withdrawn: "withdrawn",
+}
+
+// JobReferral is a mentor's recommendation of one of their mentees for a job.
+// Status is ReferralStatus.Referred until the mentee applies and then follows
+// the application through the review pipeline.
+type JobReferral struct {
+	ID            int        `json:"id"`
+	JobID         int        `json:"job_id"`
+	JobTitle      string     `json:"job_title"`
+	Company       string     `json:"company"`
+	MentorID      int        `json:"mentor_id"`
+	MentorName    string     `json:"mentor_name,omitempty"`
+	MenteeID      int        `json:"mentee_id" validate:"required"`
+	MenteeName    string     `json:"mentee_name,omitempty"`
+	Note          string     `json:"note"`
+	Status        string     `json:"status"`
+	ApplicationID int        `json:"application_id,omitempty"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// ReferralStatus is the status of a referral the mentee has not applied on
+// yet; afterwards a referral has its application's status
+var ReferralStatus = struct {
+	Referred string
+}{
+	Referred: "referred",
+}
+
+// JobReferralSummary counts a mentor's referrals by outcome
+type JobReferralSummary struct {
+	Total     int `json:"total"`
+	Referred  int `json:"referred"`
+	InReview  int `json:"in_review"`
+	Interview int `json:"interview"`
+	Hired     int `json:"hired"`
+	Rejected  int `json:"rejected"`
+	Withdrawn int `json:"withdrawn"`
+}
+
+// JobReferralHistory is a mentor's referrals, newest first, with their outcomes
+type JobReferralHistory struct {
+	Summary   JobReferralSummary `json:"summary"`
+	Referrals []*JobReferral     `json:"referrals"`
 }
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+)
+
+// MatchingRound pairs many mentees with mentors at once, e.g. for a quarterly
+// mentoring drive
+type MatchingRound struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	CreatedBy   int        `json:"created_by"`
+	MentorCount int        `json:"mentor_count"`
+	MenteeCount int        `json:"mentee_count"`
+	MatchedAt   *time.Time `json:"matched_at,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Matching round status constants. Participants can join and change their
+// preferences while a round is open. Running the matcher moves it to matched;
+// publishing turns its pairs into approved mentorships.
+var MatchingRoundStatus = struct {
+	Open      string
+	Matched   string
+	Published string
+}{
+	Open:      "open",
+	Matched:   "matched",
+	Published: "published",
+}
+
+// MatchingMentor is a mentor taking part in a round. Preferences optionally
+// ranks mentee IDs, best first; unranked mentees come after ranked ones.
+type MatchingMentor struct {
+	RoundID     int       `json:"round_id"`
+	MentorID    int       `json:"mentor_id"`
+	Name        string    `json:"name"`
+	ProgramID   int       `json:"program_id"`
+	Capacity    int       `json:"capacity"`
+	Team        string    `json:"team"`
+	Preferences []int64   `json:"preferences"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MatchingMentee is a mentee taking part in a round. Preferences ranks mentor
+// IDs, best first; a mentee is only matched with mentors they ranked.
+type MatchingMentee struct {
+	RoundID     int       `json:"round_id"`
+	MenteeID    int       `json:"mentee_id"`
+	Name        string    `json:"name"`
+	Team        string    `json:"team"`
+	Preferences []int64   `json:"preferences"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MatchingPair is a proposed mentor for a mentee. Rank is the mentor's position
+// in the mentee's preferences, starting at 1, or 0 if the mentee did not rank them.
+type MatchingPair struct {
+	ID         int       `json:"id"`
+	RoundID    int       `json:"round_id"`
+	MenteeID   int       `json:"mentee_id"`
+	MenteeName string    `json:"mentee_name"`
+	MentorID   int       `json:"mentor_id"`
+	MentorName string    `json:"mentor_name"`
+	Rank       int       `json:"rank"`
+	Source     string    `json:"source"`
+	RequestID  *int      `json:"request_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Matching pair sources: proposed by the matcher or set by an admin
+var MatchingPairSource = struct {
+	Algorithm string
+	Manual    string
+}{
+	Algorithm: "algorithm",
+	Manual:    "manual",
+}
+
+// MatchingRoundDetail is a round with its participants, pairs and the mentees
+// left without a mentor
+type MatchingRoundDetail struct {
+	Round     *MatchingRound    `json:"round"`
+	Mentors   []*MatchingMentor `json:"mentors"`
+	Mentees   []*MatchingMentee `json:"mentees"`
+	Pairs     []*MatchingPair   `json:"pairs"`
+	Unmatched []int             `json:"unmatched"`
+}
@@ -0,0 +1,97 @@
+package models
+
+// Mentor search sort orders
+var MentorSearchSort = struct {
+	Relevance string
+	Rating    string
+	PriceAsc  string
+	PriceDesc string
+	Newest    string
+}{
+	Relevance: "relevance",
+	Rating:    "rating",
+	PriceAsc:  "price_asc",
+	PriceDesc: "price_desc",
+	Newest:    "newest",
+}
+
+// RateBand is a range of hourly rates used to facet search results. Max is
+// exclusive; the last band has no upper bound.
+type RateBand struct {
+	Label string   `json:"label"`
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+// MentorRateBands are the rate ranges reported in search facets
+var MentorRateBands = []RateBand{
+	{Label: "under_50", Min: 0, Max: rateBound(50)},
+	{Label: "50_100", Min: 50, Max: rateBound(100)},
+	{Label: "100_200", Min: 100, Max: rateBound(200)},
+	{Label: "200_plus", Min: 200},
+}
+
+func rateBound(rate float64) *float64 {
+	return &rate
+}
+
+// MentorSearchQuery filters, sorts and pages a mentor search
+type MentorSearchQuery struct {
+	Text      string   // Free text matched against name, bio, skills, specialties and experience
+	Skills    []string // Every listed skill must be on the mentor's profile
+	RateMin   *float64
+	RateMax   *float64
+	Timezone  string
+	Available *bool
+	Sort      string
+	Page      int
+	PageSize  int
+}
+
+// MentorSearchHit is one mentor in a search result page
+type MentorSearchHit struct {
+	UserID         int     `json:"user_id"`
+	FirstName      string  `json:"first_name"`
+	LastName       string  `json:"last_name"`
+	Bio            string  `json:"bio"`
+	Skills         string  `json:"skills"`
+	Specialties    string  `json:"specialties"`
+	Rate           float64 `json:"rate"`
+	Timezone       string  `json:"timezone"`
+	Available      bool    `json:"available"`
+	ProfilePicture string  `json:"profile_picture,omitempty"`
+	AverageRating  float64 `json:"average_rating"`
+	RatingCount    int     `json:"rating_count"`
+	Rank           float64 `json:"rank,omitempty"`
+}
+
+// FacetCount is the number of matching mentors sharing a facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// RateBandCount is the number of matching mentors within a rate band
+type RateBandCount struct {
+	RateBand
+	Count int `json:"count"`
+}
+
+// MentorSearchFacets counts the mentors matching a search by facet value
+type MentorSearchFacets struct {
+	Skills       []*FacetCount    `json:"skills"`
+	RateBands    []*RateBandCount `json:"rate_bands"`
+	Timezones    []*FacetCount    `json:"timezones"`
+	Availability []*FacetCount    `json:"availability"`
+}
+
+// MentorSearchResult is one page of mentor search results with facet counts
+// over every match
+type MentorSearchResult struct {
+	Mentors  []*MentorSearchHit  `json:"mentors"`
+	Total    int                 `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+	Sort     string              `json:"sort"`
+	Facets   *MentorSearchFacets `json:"facets"`
+}
@@ -5,16 +5,62 @@ import (
 )
 
 type MentorshipProgram struct {
-	ID          int       `json:"id"`
-	MentorID    int       `json:"mentor_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Duration    string    `json:"duration"`
-	Price       float64   `json:"price"`
-	MaxMentees  int       `json:"max_mentees"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int                `json:"id"`
+	MentorID    int                `json:"mentor_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Duration    string             `json:"duration"`
+	Price       float64            `json:"price"`
+	MaxMentees  int                `json:"max_mentees"`
+	Status      string             `json:"status"`
+	Enrollment  *ProgramEnrollment `json:"enrollment,omitempty"` // Only shown to the program's mentor
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// Program status constants
+var ProgramStatus = struct {
+	Active   string
+	Paused   string
+	Archived string
+}{
+	Active:   "active",
+	Paused:   "paused",
+	Archived: "archived",
+}
+
+// ProgramEnrollment counts the mentorship requests made against a program.
+// AwaitingAgreement is the part of Pending that has already been approved.
+type ProgramEnrollment struct {
+	Pending           int `json:"pending"`
+	AwaitingAgreement int `json:"awaiting_agreement"`
+	Active            int `json:"active"`
+	Total             int `json:"total"`
+}
+
+// MentorActivity counts the sessions a mentor has held and the mentees they
+// have taken on
+type MentorActivity struct {
+	CompletedSessions int `json:"completed_sessions"`
+	Mentees           int `json:"mentees"`
+}
+
+// Request status constants. Approved requests wait in AwaitingAgreement until
+// both participants accept the applicable agreement.
+var RequestStatus = struct {
+	Pending           string
+	AwaitingAgreement string
+	Approved          string
+	Rejected          string
+	Completed         string
+	Cancelled         string
+}{
+	Pending:           "pending",
+	AwaitingAgreement: "awaiting_agreement",
+	Approved:          "approved",
+	Rejected:          "rejected",
+	Completed:         "completed",
+	Cancelled:         "cancelled",
 }
 
 type MentorshipRequest struct {
@@ -29,16 +75,20 @@ type MentorshipRequest struct {
 }
 
 type MentorshipSession struct {
-	ID        int       `json:"id"`
-	RequestID int       `json:"request_id"`
-	Title     string    `json:"title"` // Added Title field
-	Topic     string    `json:"topic"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	Status    string    `json:"status"`
-	Notes     string    `json:"notes"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              int        `json:"id"`
+	RequestID       int        `json:"request_id"`
+	Title           string     `json:"title"` // Added Title field
+	Topic           string     `json:"topic"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         time.Time  `json:"end_time"`
+	Status          string     `json:"status"`
+	Notes           string     `json:"notes"`
+	MeetingProvider string     `json:"meeting_provider,omitempty"`
+	MeetingURL      string     `json:"meeting_url,omitempty"`
+	Sequence        int        `json:"sequence"`
+	ReminderSentAt  *time.Time `json:"reminder_sent_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // Add these session status constants
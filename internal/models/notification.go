@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification is an in-app message delivered to a user
+type Notification struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Notification type constants
+var NotificationType = struct {
+	SessionScheduled    string
+	SessionRescheduled  string
+	SessionReminder     string
+	FeedbackReceived    string
+	GoalCheckIn         string
+	CohortSession       string
+	CohortAnnouncement  string
+	CohortCancelled     string
+	AssignmentSubmitted string
+	AssignmentReviewed  string
+	AgreementRequired   string
+	MentorshipActivated string
+	MentorshipCancelled string
+	InvoiceIssued       string
+	PaymentReceived     string
+	PaymentRefunded     string
+	SponsorshipUsedUp   string
+	MatchPublished      string
+	JobApplication      string
+	ApplicationUpdated  string
+	JobModerated        string
+	JobExpired          string
+	JobAlert            string
+	JobReferral         string
+	ReferralUpdated     string
+}{
+	SessionScheduled:    "session_scheduled",
+	SessionRescheduled:  "session_rescheduled",
+	SessionReminder:     "session_reminder",
+	FeedbackReceived:    "feedback_received",
+	GoalCheckIn:         "goal_check_in",
+	CohortSession:       "cohort_session",
+	CohortAnnouncement:  "cohort_announcement",
+	CohortCancelled:     "cohort_cancelled",
+	AssignmentSubmitted: "assignment_submitted",
+	AssignmentReviewed:  "assignment_reviewed",
+	AgreementRequired:   "agreement_required",
+	MentorshipActivated: "mentorship_activated",
+	MentorshipCancelled: "mentorship_cancelled",
+	InvoiceIssued:       "invoice_issued",
+	PaymentReceived:     "payment_received",
+	PaymentRefunded:     "payment_refunded",
+	SponsorshipUsedUp:   "sponsorship_used_up",
+	MatchPublished:      "match_published",
+	JobApplication:      "job_application",
+	ApplicationUpdated:  "application_updated",
+	JobModerated:        "job_moderated",
+	JobExpired:          "job_expired",
+	JobAlert:            "job_alert",
+	JobReferral:         "job_referral",
+	ReferralUpdated:     "referral_updated",
+}
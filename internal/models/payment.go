@@ -0,0 +1,152 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Invoice bills a mentee for a paid mentorship. Amounts are in the currency's minor unit.
+type Invoice struct {
+	ID               int        `json:"id"`
+	Number           string     `json:"number"`
+	RequestID        int        `json:"request_id"`
+	MenteeID         int        `json:"mentee_id"`
+	MentorID         int        `json:"mentor_id"`
+	ProgramID        int        `json:"program_id"`
+	Description      string     `json:"description"`
+	Currency         string     `json:"currency"`
+	AmountCents      int64      `json:"amount_cents"`
+	PlatformFeeCents int64      `json:"platform_fee_cents"`
+	RefundedCents    int64      `json:"refunded_cents"`
+	Status           string     `json:"status"`
+	IssuedAt         time.Time  `json:"issued_at"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Joined for display
+	MenteeName   string     `json:"mentee_name,omitempty"`
+	MentorName   string     `json:"mentor_name,omitempty"`
+	ProgramTitle string     `json:"program_title,omitempty"`
+	Payments     []*Payment `json:"payments,omitempty"`
+}
+
+// RefundableCents returns how much of a paid invoice can still be refunded
+func (i *Invoice) RefundableCents() int64 {
+	if i.PaidAt == nil {
+		return 0
+	}
+	return i.AmountCents - i.RefundedCents
+}
+
+// Invoice status constants
+var InvoiceStatus = struct {
+	Open              string
+	Paid              string
+	PartiallyRefunded string
+	Refunded          string
+	Void              string
+}{
+	Open:              "open",
+	Paid:              "paid",
+	PartiallyRefunded: "partially_refunded",
+	Refunded:          "refunded",
+	Void:              "void",
+}
+
+// Payment is one charge or refund attempt through the payment provider
+type Payment struct {
+	ID            int       `json:"id"`
+	InvoiceID     int       `json:"invoice_id"`
+	Kind          string    `json:"kind"`
+	Provider      string    `json:"provider"`
+	ProviderRef   string    `json:"provider_ref"`
+	AmountCents   int64     `json:"amount_cents"`
+	Status        string    `json:"status"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Payment kind constants
+var PaymentKind = struct {
+	Charge string
+	Refund string
+}{
+	Charge: "charge",
+	Refund: "refund",
+}
+
+// Payment status constants
+var PaymentStatus = struct {
+	Succeeded string
+	Failed    string
+}{
+	Succeeded: "succeeded",
+	Failed:    "failed",
+}
+
+// LedgerTransaction groups balanced ledger entries for one movement of money
+type LedgerTransaction struct {
+	ID          int            `json:"id"`
+	InvoiceID   *int           `json:"invoice_id,omitempty"`
+	Kind        string         `json:"kind"`
+	Description string         `json:"description"`
+	Entries     []*LedgerEntry `json:"entries"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// LedgerEntry debits (positive) or credits (negative) an account
+type LedgerEntry struct {
+	ID            int    `json:"id"`
+	TransactionID int    `json:"transaction_id"`
+	Account       string `json:"account"`
+	AmountCents   int64  `json:"amount_cents"`
+}
+
+// Ledger account names. Mentor balances are kept per mentor, see MentorPayableAccount.
+var LedgerAccount = struct {
+	Cash         string
+	PlatformFees string
+}{
+	Cash:         "cash",
+	PlatformFees: "platform_fees",
+}
+
+// MentorPayableAccount names the ledger account holding what the platform owes a mentor
+func MentorPayableAccount(mentorID int) string {
+	return fmt.Sprintf("mentor_payable:%d", mentorID)
+}
+
+// PayoutSummary totals what a mentor has earned through paid programs
+type PayoutSummary struct {
+	MentorID      int              `json:"mentor_id"`
+	Currency      string           `json:"currency"`
+	GrossCents    int64            `json:"gross_cents"`
+	FeeCents      int64            `json:"fee_cents"`
+	RefundedCents int64            `json:"refunded_cents"`
+	BalanceCents  int64            `json:"balance_cents"`
+	PaidInvoices  int              `json:"paid_invoices"`
+	Programs      []*ProgramPayout `json:"programs"`
+}
+
+// ProgramPayout is one program's share of a mentor's payout summary. Gross and
+// refunds are what mentees paid; Net is what the mentor is owed after fees.
+type ProgramPayout struct {
+	ProgramID     int    `json:"program_id"`
+	ProgramTitle  string `json:"program_title"`
+	PaidInvoices  int    `json:"paid_invoices"`
+	GrossCents    int64  `json:"gross_cents"`
+	FeeCents      int64  `json:"fee_cents"`
+	RefundedCents int64  `json:"refunded_cents"`
+	NetCents      int64  `json:"net_cents"`
+}
+
+// FormatCents renders a minor-unit amount such as 12345 as "123.45 USD"
+func FormatCents(cents int64, currency string) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, cents/100, cents%100, strings.ToUpper(currency))
+}
@@ -11,6 +11,7 @@ type Profile struct {
 	LastName                string
 	Bio                     string
 	Skills                  string
+	Specialties             string
 	Experience              string
 	LinkedIn                string
 	Github                  string
@@ -36,15 +37,17 @@ type NotificationSettings struct {
 
 // PublicProfile represents a user’s public-facing profile information
 type PublicProfile struct {
-	FirstName      string  `json:"first_name"`
-	LastName       string  `json:"last_name"`
-	Email          string  `json:"email,omitempty"`
-	Bio            string  `json:"bio"`
-	Skills         string  `json:"skills"`
-	Rate           float64 `json:"rate"`
-	ProfilePicture string  `json:"profile_picture,omitempty"`
-	AverageRating  float64 `json:"average_rating,omitempty"`
-	RatingCount    int     `json:"rating_count,omitempty"`
+	FirstName      string          `json:"first_name"`
+	LastName       string          `json:"last_name"`
+	Email          string          `json:"email,omitempty"`
+	Bio            string          `json:"bio"`
+	Skills         string          `json:"skills"`
+	SkillLevels    []*ProfileSkill `json:"skill_levels,omitempty"`
+	Rate           float64         `json:"rate"`
+	ProfilePicture string          `json:"profile_picture,omitempty"`
+	AverageRating  float64         `json:"average_rating,omitempty"`
+	RatingCount    int             `json:"rating_count,omitempty"`
+	Testimonials   []*Testimonial  `json:"testimonials,omitempty"`
 }
 
 // ProfileSettings for user preferences and privacy
@@ -98,21 +101,12 @@ type Education struct {
 	UpdatedAt   time.Time
 }
 
-// Rating represents mentor ratings
-type Rating struct {
-	Id         int
-	FromUserId int
-	ToUserId   int
-	Rating     float64
-	Comment    string
-	CreatedAt  time.Time
-}
-
 type ProfileUpdate struct {
 	FirstName      *string
 	LastName       *string
 	Bio            *string
 	Skills         *string
+	Specialties    *string
 	TimeZone       *string
 	Rate           *float64
 	Availability   *string
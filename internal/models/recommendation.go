@@ -0,0 +1,38 @@
+package models
+
+// MentorCandidate is an approved mentor considered for a mentee's
+// recommendations, with the signals the recommender scores
+type MentorCandidate struct {
+	UserID         int      `json:"user_id"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	Bio            string   `json:"bio"`
+	Rate           float64  `json:"rate"`
+	Timezone       string   `json:"timezone"`
+	Available      bool     `json:"available"`
+	ProfilePicture string   `json:"profile_picture,omitempty"`
+	AverageRating  float64  `json:"average_rating"`
+	RatingCount    int      `json:"rating_count"`
+	OpenSpots      int      `json:"open_spots"` // Remaining places across the mentor's active programs
+	Rejections     int      `json:"-"`          // Earlier requests from this mentee the mentor rejected
+	SkillIDs       []int64  `json:"-"`
+	SkillNames     []string `json:"-"` // Parallel to SkillIDs
+}
+
+// MentorRecommendation is a scored mentor with the reasons it was suggested
+type MentorRecommendation struct {
+	Mentor        *MentorCandidate `json:"mentor"`
+	Score         float64          `json:"score"`
+	MatchedSkills []string         `json:"matched_skills"`
+	Reasons       []string         `json:"reasons"`
+}
+
+// Recommendation feedback kinds. Dismissed mentors return after a while;
+// mentors marked not interested stay hidden until the feedback is removed.
+var RecommendationFeedback = struct {
+	Dismissed     string
+	NotInterested string
+}{
+	Dismissed:     "dismissed",
+	NotInterested: "not_interested",
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// SessionContent is everything written for a session as seen by one participant
+type SessionContent struct {
+	SessionID            int           `json:"session_id"`
+	Agenda               string        `json:"agenda"`
+	AgendaVersion        int           `json:"agenda_version"`
+	AgendaUpdatedBy      *int          `json:"agenda_updated_by,omitempty"`
+	SharedNotes          string        `json:"shared_notes"`
+	SharedNotesVersion   int           `json:"shared_notes_version"`
+	SharedNotesUpdatedBy *int          `json:"shared_notes_updated_by,omitempty"`
+	PrivateNotes         string        `json:"private_notes"`
+	ActionItems          []*ActionItem `json:"action_items"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+// ActionItem is a follow-up task agreed during a session
+type ActionItem struct {
+	ID          int        `json:"id"`
+	RequestID   int        `json:"request_id"`
+	SessionID   int        `json:"session_id"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description"`
+	OwnerID     int        `json:"owner_id"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Done        bool       `json:"done"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedBy   int        `json:"created_by"`
+	CarriedOver bool       `json:"carried_over"` // Open item raised in an earlier session
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ActionItemUpdate holds the action item fields a participant may change
+type ActionItemUpdate struct {
+	Title       *string
+	Description *string
+	OwnerID     *int
+	DueDate     *time.Time
+	ClearDue    bool
+	Done        *bool
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SkillCategory groups related skills, e.g. "Languages" or "Cloud"
+type SkillCategory struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	Position    int       `json:"position"`
+	SkillCount  int       `json:"skill_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Skill is an entry in the managed taxonomy. Specialties are broad areas
+// offered at mentor registration; other skills are specific technologies or practices.
+type Skill struct {
+	ID           int       `json:"id"`
+	CategoryID   *int      `json:"category_id,omitempty"`
+	CategoryName string    `json:"category_name,omitempty"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	IsSpecialty  bool      `json:"is_specialty"`
+	Description  string    `json:"description"`
+	Synonyms     []string  `json:"synonyms"`
+	MentorCount  int       `json:"mentor_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ProfileSkill links a profile to a skill at a proficiency level
+type ProfileSkill struct {
+	SkillID      int    `json:"skill_id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	CategoryName string `json:"category_name,omitempty"`
+	IsSpecialty  bool   `json:"is_specialty"`
+	Level        string `json:"level"`
+}
+
+// SkillSuggestion is an autocomplete match. MatchedSynonym is set when the
+// prefix matched one of the skill's synonyms rather than its name.
+type SkillSuggestion struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Slug           string `json:"slug"`
+	CategoryName   string `json:"category_name,omitempty"`
+	IsSpecialty    bool   `json:"is_specialty"`
+	MatchedSynonym string `json:"matched_synonym,omitempty"`
+}
+
+// Skill proficiency level constants
+var SkillLevel = struct {
+	Beginner     string
+	Intermediate string
+	Advanced     string
+	Expert       string
+}{
+	Beginner:     "beginner",
+	Intermediate: "intermediate",
+	Advanced:     "advanced",
+	Expert:       "expert",
+}
+
+var slugSeparators = regexp.MustCompile(`[^a-z0-9+#.]+`)
+
+// maxSlugLength matches the slug columns of the taxonomy tables
+const maxSlugLength = 64
+
+// SkillSlug normalises a skill, synonym or category name for lookups. It keeps
+// "+", "#" and "." so that C, C++, C# and .NET stay distinct.
+func SkillSlug(name string) string {
+	slug := strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+)
+
+// Sponsor is a team that pays for mentorships out of a budget of session credits.
+// Mentees join a sponsor by applying its code when they request a paid program.
+// Credits are reserved for a mentorship's upcoming sessions and used once the
+// sessions are completed.
+type Sponsor struct {
+	ID              int        `json:"id"`
+	Name            string     `json:"name"`
+	Code            string     `json:"code"`
+	ContactEmail    string     `json:"contact_email,omitempty"`
+	BudgetCredits   int        `json:"budget_credits"`
+	UsedCredits     int        `json:"used_credits"`
+	ReservedCredits int        `json:"reserved_credits"`
+	Active          bool       `json:"active"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	CreatedBy       int        `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// RemainingCredits returns the credits neither used nor reserved, never below zero
+func (s *Sponsor) RemainingCredits() int {
+	committed := s.UsedCredits + s.ReservedCredits
+	if committed >= s.BudgetCredits {
+		return 0
+	}
+	return s.BudgetCredits - committed
+}
+
+// AcceptsCodeAt reports whether the sponsor's code can be applied to new mentorships
+func (s *Sponsor) AcceptsCodeAt(now time.Time) bool {
+	if !s.Active {
+		return false
+	}
+	return s.ExpiresAt == nil || now.Before(*s.ExpiresAt)
+}
+
+// Sponsorship links a mentorship to the sponsor paying for it
+type Sponsorship struct {
+	RequestID       int       `json:"request_id"`
+	SponsorID       int       `json:"sponsor_id"`
+	SponsorName     string    `json:"sponsor_name"`
+	CreditsUsed     int       `json:"credits_used"`
+	CreditsReserved int       `json:"credits_reserved"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CreditUsage is the credit drawn from a sponsor's budget for one session
+type CreditUsage struct {
+	ID        int       `json:"id"`
+	SponsorID int       `json:"sponsor_id"`
+	RequestID int       `json:"request_id"`
+	SessionID int       `json:"session_id"`
+	Credits   int       `json:"credits"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SponsorReport breaks a sponsor's budget usage down by mentorship and month
+type SponsorReport struct {
+	Sponsor           *Sponsor               `json:"sponsor"`
+	RemainingCredits  int                    `json:"remaining_credits"`
+	OverdrawnCredits  int                    `json:"overdrawn_credits"`
+	ActiveMentorships int                    `json:"active_mentorships"`
+	Mentorships       []*SponsoredMentorship `json:"mentorships"`
+	Monthly           []*MonthlyCreditUsage  `json:"monthly"`
+}
+
+// SponsoredMentorship is one mentorship's line in a sponsor report
+type SponsoredMentorship struct {
+	RequestID    int        `json:"request_id"`
+	MenteeID     int        `json:"mentee_id"`
+	MenteeName   string     `json:"mentee_name"`
+	MentorName   string     `json:"mentor_name"`
+	ProgramTitle string     `json:"program_title"`
+	Status       string     `json:"status"`
+	CreditsUsed  int        `json:"credits_used"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	SponsoredAt  time.Time  `json:"sponsored_at"`
+}
+
+// MonthlyCreditUsage totals the credits a sponsor consumed in one calendar month
+type MonthlyCreditUsage struct {
+	Month    string `json:"month"` // YYYY-MM
+	Credits  int    `json:"credits"`
+	Sessions int    `json:"sessions"`
+}
@@ -29,6 +29,19 @@ type User struct {
 	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
+// User role constants
+var UserRole = struct {
+	Mentee   string
+	Mentor   string
+	Employer string
+	Admin    string
+}{
+	Mentee:   "mentee",
+	Mentor:   "mentor",
+	Employer: "employer",
+	Admin:    "admin",
+}
+
 // SetPassword hashes and sets the user's password
 func (u *User) SetPassword(password string) error {
 	if len(password) < 8 {
@@ -68,9 +81,9 @@ func (u *User) ValidateEmail() error {
 func (u *User) BeforeInsert() {
 	if u.Role == "" {
 		if u.IsMentor {
-			u.Role = "mentor"
+			u.Role = UserRole.Mentor
 		} else {
-			u.Role = "mentee"
+			u.Role = UserRole.Mentee
 		}
 	}
 }
@@ -110,3 +123,8 @@ func (u *User) MakeAdmin() {
 func (u *User) RemoveAdmin() {
 	u.IsAdmin = false
 }
+
+// IsEmployer checks if the user registered to post jobs
+func (u *User) IsEmployer() bool {
+	return u.Role == UserRole.Employer
+}
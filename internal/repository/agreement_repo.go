@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+)
+
+var (
+	ErrAgreementNotFound      = errors.New("agreement not found")
+	ErrAgreementNotPending    = errors.New("mentorship is not awaiting agreement")
+	ErrAgreementAlreadySigned = errors.New("agreement already accepted")
+)
+
+const agreementTemplateColumns = `id, program_id, version, title, body, created_by, created_at`
+
+type AgreementRepository struct {
+	db *sql.DB
+}
+
+func NewAgreementRepository(db *sql.DB) *AgreementRepository {
+	return &AgreementRepository{
+		db: db,
+	}
+}
+
+// CreateTemplate stores the next version of the default agreement, or of a
+// program's override when ProgramID is set
+func (r *AgreementRepository) CreateTemplate(ctx context.Context, template *models.AgreementTemplate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Serialise version numbering per agreement scope
+	scope := 0
+	if template.ProgramID != nil {
+		scope = *template.ProgramID
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('agreement_templates'), $1)", scope); err != nil {
+		return fmt.Errorf("failed to lock agreement versions: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(MAX(version), 0) + 1
+        FROM agreement_templates
+        WHERE program_id IS NOT DISTINCT FROM $1`, template.ProgramID).Scan(&template.Version)
+	if err != nil {
+		return fmt.Errorf("failed to get next agreement version: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO agreement_templates (program_id, version, title, body, created_by)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`,
+		template.ProgramID,
+		template.Version,
+		template.Title,
+		template.Body,
+		template.CreatedBy,
+	).Scan(&template.ID, &template.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create agreement template: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetTemplate retrieves an agreement template by ID
+func (r *AgreementRepository) GetTemplate(ctx context.Context, templateID int) (*models.AgreementTemplate, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT `+agreementTemplateColumns+`
+        FROM agreement_templates
+        WHERE id = $1`, templateID)
+
+	template, err := scanAgreementTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrAgreementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agreement template: %w", err)
+	}
+
+	return template, nil
+}
+
+// ListTemplates retrieves every version of the default agreement, or of a
+// program's override when programID is set, newest first
+func (r *AgreementRepository) ListTemplates(ctx context.Context, programID *int) ([]*models.AgreementTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+agreementTemplateColumns+`
+        FROM agreement_templates
+        WHERE program_id IS NOT DISTINCT FROM $1
+        ORDER BY version DESC`, programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agreement templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*models.AgreementTemplate{}
+	for rows.Next() {
+		template, err := scanAgreementTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan agreement template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agreement templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetEffectiveTemplate retrieves the agreement that applies to a program: the
+// latest version of its override, otherwise the latest default version
+func (r *AgreementRepository) GetEffectiveTemplate(ctx context.Context, programID int) (*models.AgreementTemplate, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT `+agreementTemplateColumns+`
+        FROM agreement_templates
+        WHERE program_id = $1 OR program_id IS NULL
+        ORDER BY program_id IS NULL, version DESC
+        LIMIT 1`, programID)
+
+	template, err := scanAgreementTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrAgreementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective agreement: %w", err)
+	}
+
+	return template, nil
+}
+
+// RequireAgreement moves a pending request to awaiting_agreement, which holds
+// a seat in its program, and fixes the agreement version its participants must
+// accept. A program without room gets ErrProgramFull.
+func (r *AgreementRepository) RequireAgreement(ctx context.Context, requestID, templateID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkProgramSeat(ctx, tx, requestID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE mentorship_requests
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND status = $3`,
+		models.RequestStatus.AwaitingAgreement, requestID, models.RequestStatus.Pending)
+	if err != nil {
+		return fmt.Errorf("failed to update request status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrInvalidStatus
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO mentorship_agreements (request_id, template_id)
+        VALUES ($1, $2)`, requestID, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to assign agreement: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetMentorshipAgreement retrieves the agreement assigned to a mentorship and its acceptances
+func (r *AgreementRepository) GetMentorshipAgreement(ctx context.Context, requestID int) (*models.MentorshipAgreement, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT t.id, t.program_id, t.version, t.title, t.body, t.created_by, t.created_at
+        FROM mentorship_agreements ma
+        JOIN agreement_templates t ON ma.template_id = t.id
+        WHERE ma.request_id = $1`, requestID)
+
+	template, err := scanAgreementTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrAgreementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentorship agreement: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, request_id, template_id, user_id, ip_address, user_agent, accepted_at
+        FROM agreement_acceptances
+        WHERE request_id = $1
+        ORDER BY accepted_at ASC`, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agreement acceptances: %w", err)
+	}
+	defer rows.Close()
+
+	agreement := &models.MentorshipAgreement{
+		RequestID:   requestID,
+		Template:    template,
+		Acceptances: []*models.AgreementAcceptance{},
+	}
+	for rows.Next() {
+		acceptance := &models.AgreementAcceptance{}
+		if err := rows.Scan(
+			&acceptance.ID,
+			&acceptance.RequestID,
+			&acceptance.TemplateID,
+			&acceptance.UserID,
+			&acceptance.IPAddress,
+			&acceptance.UserAgent,
+			&acceptance.AcceptedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agreement acceptance: %w", err)
+		}
+		agreement.Acceptances = append(agreement.Acceptances, acceptance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agreement acceptances: %w", err)
+	}
+
+	return agreement, nil
+}
+
+// Accept records a participant's acceptance of the mentorship's agreement. Once
+// both participants have accepted, the request becomes approved and activated is true.
+func (r *AgreementRepository) Accept(ctx context.Context, acceptance *models.AgreementAcceptance) (activated bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM mentorship_requests WHERE id = $1 FOR UPDATE", acceptance.RequestID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return false, ErrAgreementNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock request: %w", err)
+	}
+	if status != models.RequestStatus.AwaitingAgreement {
+		return false, ErrAgreementNotPending
+	}
+
+	err = tx.QueryRowContext(ctx, "SELECT template_id FROM mentorship_agreements WHERE request_id = $1", acceptance.RequestID).Scan(&acceptance.TemplateID)
+	if err == sql.ErrNoRows {
+		return false, ErrAgreementNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get mentorship agreement: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO agreement_acceptances (request_id, template_id, user_id, ip_address, user_agent)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (request_id, user_id) DO NOTHING
+        RETURNING id, accepted_at`,
+		acceptance.RequestID,
+		acceptance.TemplateID,
+		acceptance.UserID,
+		acceptance.IPAddress,
+		acceptance.UserAgent,
+	).Scan(&acceptance.ID, &acceptance.AcceptedAt)
+	if err == sql.ErrNoRows {
+		return false, ErrAgreementAlreadySigned
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record acceptance: %w", err)
+	}
+
+	// The request is approved once both its mentor and mentee have accepted
+	var accepted int
+	err = tx.QueryRowContext(ctx, `
+        SELECT COUNT(*)
+        FROM agreement_acceptances a
+        JOIN mentorship_requests mr ON a.request_id = mr.id
+        WHERE a.request_id = $1 AND a.user_id IN (mr.mentor_id, mr.mentee_id)`, acceptance.RequestID).Scan(&accepted)
+	if err != nil {
+		return false, fmt.Errorf("failed to count acceptances: %w", err)
+	}
+
+	if accepted == 2 {
+		_, err = tx.ExecContext(ctx, `
+            UPDATE mentorship_requests
+            SET status = $1, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $2`, models.RequestStatus.Approved, acceptance.RequestID)
+		if err != nil {
+			return false, fmt.Errorf("failed to activate mentorship: %w", err)
+		}
+		activated = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return activated, nil
+}
+
+// scanAgreementTemplate scans agreementTemplateColumns from a *sql.Row or *sql.Rows
+func scanAgreementTemplate(row interface{ Scan(...interface{}) error }) (*models.AgreementTemplate, error) {
+	template := &models.AgreementTemplate{}
+	if err := row.Scan(
+		&template.ID,
+		&template.ProgramID,
+		&template.Version,
+		&template.Title,
+		&template.Body,
+		&template.CreatedBy,
+		&template.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
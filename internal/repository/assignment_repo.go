@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrAssignmentNotFound = errors.New("assignment not found")
+	ErrSubmissionNotFound = errors.New("submission not found")
+	ErrSubmissionConflict = errors.New("a submission for this round already exists")
+	ErrAlreadyReviewed    = errors.New("submission has already been reviewed")
+)
+
+const assignmentColumns = `
+        id, program_id, request_id, created_by, title, instructions, due_at, rubric,
+        max_resubmissions, created_at, updated_at`
+
+const submissionColumns = `
+        s.id, s.assignment_id, s.request_id, s.mentee_id, s.round, s.body, s.links, s.file_key,
+        s.file_name, s.content_type, s.size_bytes, s.status, s.late, s.submitted_at,
+        sr.id, sr.reviewer_id, sr.scores, sr.total_score, sr.max_score, sr.comment, sr.decision, sr.reviewed_at`
+
+type AssignmentRepository struct {
+	db *sql.DB
+}
+
+func NewAssignmentRepository(db *sql.DB) *AssignmentRepository {
+	return &AssignmentRepository{
+		db: db,
+	}
+}
+
+// CreateAssignment saves a new program or mentorship assignment
+func (r *AssignmentRepository) CreateAssignment(ctx context.Context, assignment *models.Assignment) error {
+	rubric, err := json.Marshal(assignment.Rubric)
+	if err != nil {
+		return fmt.Errorf("failed to encode rubric: %w", err)
+	}
+
+	query := `
+        INSERT INTO assignments (program_id, request_id, created_by, title, instructions, due_at,
+                                 rubric, max_resubmissions)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		assignment.ProgramID,
+		assignment.RequestID,
+		assignment.CreatedBy,
+		assignment.Title,
+		assignment.Instructions,
+		assignment.DueAt,
+		rubric,
+		assignment.MaxResubmissions,
+	).Scan(&assignment.ID, &assignment.CreatedAt, &assignment.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAssignment retrieves an assignment by ID
+func (r *AssignmentRepository) GetAssignment(ctx context.Context, assignmentID int) (*models.Assignment, error) {
+	assignments, err := r.queryAssignments(ctx, `SELECT `+assignmentColumns+` FROM assignments WHERE id = $1`, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, ErrAssignmentNotFound
+	}
+
+	return assignments[0], nil
+}
+
+// UpdateAssignment saves an assignment's editable fields
+func (r *AssignmentRepository) UpdateAssignment(ctx context.Context, assignment *models.Assignment) error {
+	rubric, err := json.Marshal(assignment.Rubric)
+	if err != nil {
+		return fmt.Errorf("failed to encode rubric: %w", err)
+	}
+
+	query := `
+        UPDATE assignments
+        SET title = $1, instructions = $2, due_at = $3, rubric = $4, max_resubmissions = $5
+        WHERE id = $6
+        RETURNING updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		assignment.Title,
+		assignment.Instructions,
+		assignment.DueAt,
+		rubric,
+		assignment.MaxResubmissions,
+		assignment.ID,
+	).Scan(&assignment.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrAssignmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update assignment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAssignment removes an assignment along with its submissions
+func (r *AssignmentRepository) DeleteAssignment(ctx context.Context, assignmentID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM assignments WHERE id = $1`, assignmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete assignment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+// ListForMentorship retrieves the assignments of a mentorship and of the program it belongs to
+func (r *AssignmentRepository) ListForMentorship(ctx context.Context, requestID, programID int) ([]*models.Assignment, error) {
+	query := `SELECT ` + assignmentColumns + `
+        FROM assignments
+        WHERE request_id = $1 OR program_id = $2
+        ORDER BY due_at ASC NULLS LAST, created_at ASC`
+
+	return r.queryAssignments(ctx, query, requestID, programID)
+}
+
+// ListForProgram retrieves the program-wide assignments of a program
+func (r *AssignmentRepository) ListForProgram(ctx context.Context, programID int) ([]*models.Assignment, error) {
+	query := `SELECT ` + assignmentColumns + `
+        FROM assignments
+        WHERE program_id = $1
+        ORDER BY due_at ASC NULLS LAST, created_at ASC`
+
+	return r.queryAssignments(ctx, query, programID)
+}
+
+// CreateSubmission records a new submission round. Two submissions racing for
+// the same round yield ErrSubmissionConflict.
+func (r *AssignmentRepository) CreateSubmission(ctx context.Context, submission *models.Submission) error {
+	query := `
+        INSERT INTO assignment_submissions (assignment_id, request_id, mentee_id, round, body, links,
+                                            file_key, file_name, content_type, size_bytes, status, late)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        ON CONFLICT (assignment_id, request_id, round) DO NOTHING
+        RETURNING id, submitted_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		submission.AssignmentID,
+		submission.RequestID,
+		submission.MenteeID,
+		submission.Round,
+		submission.Body,
+		pq.Array(submission.Links),
+		submission.FileKey,
+		submission.FileName,
+		submission.ContentType,
+		submission.SizeBytes,
+		submission.Status,
+		submission.Late,
+	).Scan(&submission.ID, &submission.SubmittedAt)
+	if err == sql.ErrNoRows {
+		return ErrSubmissionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create submission: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubmission retrieves a submission with its review, if any
+func (r *AssignmentRepository) GetSubmission(ctx context.Context, submissionID int) (*models.Submission, error) {
+	submissions, err := r.querySubmissions(ctx, `WHERE s.id = $1`, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(submissions) == 0 {
+		return nil, ErrSubmissionNotFound
+	}
+
+	return submissions[0], nil
+}
+
+// ListSubmissions retrieves every round a mentorship submitted for an assignment, oldest first
+func (r *AssignmentRepository) ListSubmissions(ctx context.Context, assignmentID, requestID int) ([]*models.Submission, error) {
+	return r.querySubmissions(ctx, `
+        WHERE s.assignment_id = $1 AND s.request_id = $2
+        ORDER BY s.round ASC`, assignmentID, requestID)
+}
+
+// ListLatestSubmissions retrieves the most recent round of each assignment a mentorship has submitted
+func (r *AssignmentRepository) ListLatestSubmissions(ctx context.Context, requestID int) ([]*models.Submission, error) {
+	return r.querySubmissions(ctx, `
+        WHERE s.request_id = $1
+          AND s.round = (SELECT MAX(round) FROM assignment_submissions
+                         WHERE assignment_id = s.assignment_id AND request_id = s.request_id)`, requestID)
+}
+
+// CreateReview stores the mentor's review and moves the submission to the
+// review's decision in one transaction
+func (r *AssignmentRepository) CreateReview(ctx context.Context, review *models.SubmissionReview) error {
+	scores, err := json.Marshal(review.Scores)
+	if err != nil {
+		return fmt.Errorf("failed to encode scores: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE assignment_submissions SET status = $1
+        WHERE id = $2 AND status = 'submitted'`, review.Decision, review.SubmissionID)
+	if err != nil {
+		return fmt.Errorf("failed to update submission status: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrAlreadyReviewed
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO submission_reviews (submission_id, reviewer_id, scores, total_score, max_score,
+                                        comment, decision)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, reviewed_at`,
+		review.SubmissionID,
+		review.ReviewerID,
+		scores,
+		review.TotalScore,
+		review.MaxScore,
+		review.Comment,
+		review.Decision,
+	).Scan(&review.ID, &review.ReviewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListPendingReviews retrieves submissions awaiting review across a mentor's mentorships, oldest first
+func (r *AssignmentRepository) ListPendingReviews(ctx context.Context, mentorID int) ([]*models.PendingReview, error) {
+	query := `
+        SELECT ` + submissionColumns + `, a.title, COALESCE(p.first_name || ' ' || p.last_name, '')
+        FROM assignment_submissions s
+        LEFT JOIN submission_reviews sr ON sr.submission_id = s.id
+        JOIN assignments a ON s.assignment_id = a.id
+        JOIN mentorship_requests mr ON s.request_id = mr.id
+        LEFT JOIN profiles p ON p.user_id = s.mentee_id
+        WHERE mr.mentor_id = $1 AND s.status = 'submitted'
+        ORDER BY s.submitted_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, mentorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	pending := []*models.PendingReview{}
+	for rows.Next() {
+		item := &models.PendingReview{}
+		scan := newSubmissionScan()
+		if err := rows.Scan(append(scan.fields(), &item.AssignmentTitle, &item.MenteeName)...); err != nil {
+			return nil, fmt.Errorf("failed to scan pending review: %w", err)
+		}
+		if item.Submission, err = scan.submission(); err != nil {
+			return nil, err
+		}
+		pending = append(pending, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending reviews: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (r *AssignmentRepository) queryAssignments(ctx context.Context, query string, args ...interface{}) ([]*models.Assignment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []*models.Assignment{}
+	for rows.Next() {
+		assignment := &models.Assignment{}
+		var rubric []byte
+		if err := rows.Scan(
+			&assignment.ID,
+			&assignment.ProgramID,
+			&assignment.RequestID,
+			&assignment.CreatedBy,
+			&assignment.Title,
+			&assignment.Instructions,
+			&assignment.DueAt,
+			&rubric,
+			&assignment.MaxResubmissions,
+			&assignment.CreatedAt,
+			&assignment.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		if err := json.Unmarshal(rubric, &assignment.Rubric); err != nil {
+			return nil, fmt.Errorf("failed to decode rubric: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (r *AssignmentRepository) querySubmissions(ctx context.Context, where string, args ...interface{}) ([]*models.Submission, error) {
+	query := `
+        SELECT ` + submissionColumns + `
+        FROM assignment_submissions s
+        LEFT JOIN submission_reviews sr ON sr.submission_id = s.id
+        ` + where
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submissions: %w", err)
+	}
+	defer rows.Close()
+
+	submissions := []*models.Submission{}
+	for rows.Next() {
+		scan := newSubmissionScan()
+		if err := rows.Scan(scan.fields()...); err != nil {
+			return nil, fmt.Errorf("failed to scan submission: %w", err)
+		}
+		submission, err := scan.submission()
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, submission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// submissionScan holds a submission row whose review columns may be NULL
+type submissionScan struct {
+	s          *models.Submission
+	reviewID   sql.NullInt64
+	reviewerID sql.NullInt64
+	scores     []byte
+	total      sql.NullInt64
+	max        sql.NullInt64
+	comment    sql.NullString
+	decision   sql.NullString
+	reviewedAt sql.NullTime
+}
+
+func newSubmissionScan() *submissionScan {
+	return &submissionScan{s: &models.Submission{}}
+}
+
+func (sc *submissionScan) fields() []interface{} {
+	return []interface{}{
+		&sc.s.ID,
+		&sc.s.AssignmentID,
+		&sc.s.RequestID,
+		&sc.s.MenteeID,
+		&sc.s.Round,
+		&sc.s.Body,
+		pq.Array(&sc.s.Links),
+		&sc.s.FileKey,
+		&sc.s.FileName,
+		&sc.s.ContentType,
+		&sc.s.SizeBytes,
+		&sc.s.Status,
+		&sc.s.Late,
+		&sc.s.SubmittedAt,
+		&sc.reviewID,
+		&sc.reviewerID,
+		&sc.scores,
+		&sc.total,
+		&sc.max,
+		&sc.comment,
+		&sc.decision,
+		&sc.reviewedAt,
+	}
+}
+
+func (sc *submissionScan) submission() (*models.Submission, error) {
+	if !sc.reviewID.Valid {
+		return sc.s, nil
+	}
+
+	review := &models.SubmissionReview{
+		ID:           int(sc.reviewID.Int64),
+		SubmissionID: sc.s.ID,
+		ReviewerID:   int(sc.reviewerID.Int64),
+		TotalScore:   int(sc.total.Int64),
+		MaxScore:     int(sc.max.Int64),
+		Comment:      sc.comment.String,
+		Decision:     sc.decision.String,
+		ReviewedAt:   sc.reviewedAt.Time,
+	}
+	if err := json.Unmarshal(sc.scores, &review.Scores); err != nil {
+		return nil, fmt.Errorf("failed to decode review scores: %w", err)
+	}
+
+	sc.s.Review = review
+	return sc.s, nil
+}
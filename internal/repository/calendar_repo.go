@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{
+		db: db,
+	}
+}
+
+// UpsertFeed creates or replaces the calendar feed configured for a mentor
+func (r *CalendarRepository) UpsertFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	query := `
+        INSERT INTO calendar_feeds (mentor_id, url, feed_type, enabled)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (mentor_id) DO UPDATE
+        SET url = EXCLUDED.url, feed_type = EXCLUDED.feed_type, enabled = EXCLUDED.enabled,
+            last_synced_at = NULL, last_error = NULL
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		feed.MentorID,
+		feed.URL,
+		feed.FeedType,
+		feed.Enabled,
+	).Scan(&feed.ID, &feed.CreatedAt, &feed.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+// GetFeedByMentor retrieves the calendar feed configured for a mentor
+func (r *CalendarRepository) GetFeedByMentor(ctx context.Context, mentorID int) (*models.CalendarFeed, error) {
+	query := `
+        SELECT id, mentor_id, url, feed_type, enabled, last_synced_at, COALESCE(last_error, ''), created_at, updated_at
+        FROM calendar_feeds
+        WHERE mentor_id = $1`
+
+	feed := &models.CalendarFeed{}
+	err := r.db.QueryRowContext(ctx, query, mentorID).Scan(
+		&feed.ID,
+		&feed.MentorID,
+		&feed.URL,
+		&feed.FeedType,
+		&feed.Enabled,
+		&feed.LastSyncedAt,
+		&feed.LastError,
+		&feed.CreatedAt,
+		&feed.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// ListEnabledFeeds retrieves every feed that should be synced
+func (r *CalendarRepository) ListEnabledFeeds(ctx context.Context) ([]*models.CalendarFeed, error) {
+	query := `
+        SELECT id, mentor_id, url, feed_type, enabled, last_synced_at, COALESCE(last_error, ''), created_at, updated_at
+        FROM calendar_feeds
+        WHERE enabled = true
+        ORDER BY last_synced_at ASC NULLS FIRST`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar feeds: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []*models.CalendarFeed
+	for rows.Next() {
+		feed := &models.CalendarFeed{}
+		if err := rows.Scan(
+			&feed.ID,
+			&feed.MentorID,
+			&feed.URL,
+			&feed.FeedType,
+			&feed.Enabled,
+			&feed.LastSyncedAt,
+			&feed.LastError,
+			&feed.CreatedAt,
+			&feed.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan calendar feed: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating calendar feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// DeleteFeed removes a mentor's feed along with its imported busy blocks
+func (r *CalendarRepository) DeleteFeed(ctx context.Context, mentorID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM calendar_feeds WHERE mentor_id = $1`, mentorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrCalendarFeedNotFound
+	}
+
+	return nil
+}
+
+// ReplaceBusyBlocks swaps a feed's busy blocks for a freshly synced set and
+// records the successful sync
+func (r *CalendarRepository) ReplaceBusyBlocks(ctx context.Context, feed *models.CalendarFeed, blocks []*models.BusyBlock) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM calendar_busy_blocks WHERE feed_id = $1`, feed.ID); err != nil {
+		return fmt.Errorf("failed to clear busy blocks: %w", err)
+	}
+
+	for _, block := range blocks {
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO calendar_busy_blocks (feed_id, mentor_id, start_time, end_time)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id, created_at`,
+			feed.ID,
+			feed.MentorID,
+			block.StartTime.UTC(),
+			block.EndTime.UTC(),
+		).Scan(&block.ID, &block.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert busy block: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE calendar_feeds
+        SET last_synced_at = CURRENT_TIMESTAMP, last_error = NULL
+        WHERE id = $1`, feed.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark calendar feed synced: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// RecordSyncError stores the reason the last sync of a feed failed
+func (r *CalendarRepository) RecordSyncError(ctx context.Context, feedID int, syncErr string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE calendar_feeds SET last_error = $1 WHERE id = $2`, syncErr, feedID)
+	if err != nil {
+		return fmt.Errorf("failed to record calendar sync error: %w", err)
+	}
+	return nil
+}
+
+// ListBusyBlocks retrieves a mentor's busy blocks overlapping [from, to)
+func (r *CalendarRepository) ListBusyBlocks(ctx context.Context, mentorID int, from, to time.Time) ([]*models.BusyBlock, error) {
+	query := `
+        SELECT id, feed_id, mentor_id, start_time, end_time, created_at
+        FROM calendar_busy_blocks
+        WHERE mentor_id = $1 AND end_time > $2 AND start_time < $3
+        ORDER BY start_time ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, mentorID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []*models.BusyBlock
+	for rows.Next() {
+		block := &models.BusyBlock{}
+		if err := rows.Scan(
+			&block.ID,
+			&block.FeedID,
+			&block.MentorID,
+			&block.StartTime,
+			&block.EndTime,
+			&block.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan busy block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating busy blocks: %w", err)
+	}
+
+	return blocks, nil
+}
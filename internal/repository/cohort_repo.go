@@ -0,0 +1,532 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+)
+
+var (
+	ErrCohortNotFound        = errors.New("cohort not found")
+	ErrCohortFull            = errors.New("cohort is full")
+	ErrAlreadyEnrolled       = errors.New("already enrolled in this cohort")
+	ErrNotEnrolled           = errors.New("not enrolled in this cohort")
+	ErrCohortSessionNotFound = errors.New("cohort session not found")
+)
+
+const cohortColumns = `
+        c.id, c.program_id, c.mentor_id, c.name, c.enrollment_opens_at, c.enrollment_closes_at,
+        c.starts_on, c.ends_on, c.capacity, c.status,
+        (SELECT COUNT(*) FROM cohort_members m WHERE m.cohort_id = c.id AND m.status = 'enrolled'),
+        c.created_at, c.updated_at`
+
+type CohortRepository struct {
+	db *sql.DB
+}
+
+func NewCohortRepository(db *sql.DB) *CohortRepository {
+	return &CohortRepository{
+		db: db,
+	}
+}
+
+// CreateCohort schedules a new run of a program
+func (r *CohortRepository) CreateCohort(ctx context.Context, cohort *models.Cohort) error {
+	query := `
+        INSERT INTO cohorts (program_id, mentor_id, name, enrollment_opens_at, enrollment_closes_at,
+                             starts_on, ends_on, capacity, status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		cohort.ProgramID,
+		cohort.MentorID,
+		cohort.Name,
+		cohort.EnrollmentOpensAt,
+		cohort.EnrollmentClosesAt,
+		cohort.StartsOn,
+		cohort.EndsOn,
+		cohort.Capacity,
+		cohort.Status,
+	).Scan(&cohort.ID, &cohort.CreatedAt, &cohort.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create cohort: %w", err)
+	}
+
+	return nil
+}
+
+// GetCohort retrieves a cohort with its current enrollment count
+func (r *CohortRepository) GetCohort(ctx context.Context, cohortID int) (*models.Cohort, error) {
+	query := `SELECT ` + cohortColumns + ` FROM cohorts c WHERE c.id = $1`
+
+	cohort := &models.Cohort{}
+	err := r.db.QueryRowContext(ctx, query, cohortID).Scan(cohortScanFields(cohort)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrCohortNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort: %w", err)
+	}
+
+	return cohort, nil
+}
+
+// UpdateCohortStatus activates or cancels a cohort
+func (r *CohortRepository) UpdateCohortStatus(ctx context.Context, cohortID int, status string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE cohorts SET status = $1 WHERE id = $2`, status, cohortID)
+	if err != nil {
+		return fmt.Errorf("failed to update cohort status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrCohortNotFound
+	}
+
+	return nil
+}
+
+// ListProgramCohorts retrieves every cohort of a program, soonest first
+func (r *CohortRepository) ListProgramCohorts(ctx context.Context, programID int) ([]*models.Cohort, error) {
+	query := `SELECT ` + cohortColumns + `
+        FROM cohorts c
+        WHERE c.program_id = $1
+        ORDER BY c.starts_on ASC`
+
+	return r.queryCohorts(ctx, query, programID)
+}
+
+// ListMenteeCohorts retrieves the cohorts a mentee is enrolled in
+func (r *CohortRepository) ListMenteeCohorts(ctx context.Context, menteeID int) ([]*models.Cohort, error) {
+	query := `SELECT ` + cohortColumns + `
+        FROM cohorts c
+        JOIN cohort_members cm ON cm.cohort_id = c.id
+        WHERE cm.mentee_id = $1 AND cm.status = 'enrolled'
+        ORDER BY c.starts_on ASC`
+
+	return r.queryCohorts(ctx, query, menteeID)
+}
+
+// Enroll adds a mentee to a cohort's roster. The cohort row is locked while the
+// seat count is checked so concurrent enrollments cannot overfill it.
+func (r *CohortRepository) Enroll(ctx context.Context, cohortID, menteeID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var capacity int
+	err = tx.QueryRowContext(ctx, `SELECT capacity FROM cohorts WHERE id = $1 FOR UPDATE`, cohortID).Scan(&capacity)
+	if err == sql.ErrNoRows {
+		return ErrCohortNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock cohort: %w", err)
+	}
+
+	var enrolled int
+	err = tx.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM cohort_members
+        WHERE cohort_id = $1 AND status = 'enrolled'`, cohortID).Scan(&enrolled)
+	if err != nil {
+		return fmt.Errorf("failed to count cohort members: %w", err)
+	}
+	if enrolled >= capacity {
+		return ErrCohortFull
+	}
+
+	// A mentee who withdrew earlier may rejoin; an active enrollment is left alone
+	result, err := tx.ExecContext(ctx, `
+        INSERT INTO cohort_members (cohort_id, mentee_id, status)
+        VALUES ($1, $2, 'enrolled')
+        ON CONFLICT (cohort_id, mentee_id) DO UPDATE
+        SET status = 'enrolled', enrolled_at = CURRENT_TIMESTAMP
+        WHERE cohort_members.status = 'withdrawn'`, cohortID, menteeID)
+	if err != nil {
+		return fmt.Errorf("failed to enroll in cohort: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrAlreadyEnrolled
+	}
+
+	return tx.Commit()
+}
+
+// Withdraw removes a mentee from a cohort's active roster
+func (r *CohortRepository) Withdraw(ctx context.Context, cohortID, menteeID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE cohort_members SET status = 'withdrawn'
+        WHERE cohort_id = $1 AND mentee_id = $2 AND status = 'enrolled'`, cohortID, menteeID)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw from cohort: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotEnrolled
+	}
+
+	return nil
+}
+
+// IsEnrolled reports whether a mentee is currently on a cohort's roster
+func (r *CohortRepository) IsEnrolled(ctx context.Context, cohortID, menteeID int) (bool, error) {
+	var enrolled bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM cohort_members
+            WHERE cohort_id = $1 AND mentee_id = $2 AND status = 'enrolled'
+        )`, cohortID, menteeID).Scan(&enrolled)
+	if err != nil {
+		return false, fmt.Errorf("failed to check cohort enrollment: %w", err)
+	}
+
+	return enrolled, nil
+}
+
+// ListRoster retrieves a cohort's members with their attendance at completed sessions
+func (r *CohortRepository) ListRoster(ctx context.Context, cohortID int) ([]*models.CohortMember, error) {
+	query := `
+        SELECT cm.cohort_id, cm.mentee_id, COALESCE(p.first_name, ''), COALESCE(p.last_name, ''),
+               u.email, cm.status, cm.enrolled_at,
+               (SELECT COUNT(*) FROM cohort_sessions s
+                WHERE s.cohort_id = cm.cohort_id AND s.status = 'completed'),
+               (SELECT COUNT(*) FROM cohort_attendance a
+                JOIN cohort_sessions s ON a.session_id = s.id
+                WHERE s.cohort_id = cm.cohort_id AND s.status = 'completed'
+                  AND a.mentee_id = cm.mentee_id AND a.status = 'present')
+        FROM cohort_members cm
+        JOIN users u ON cm.mentee_id = u.id
+        LEFT JOIN profiles p ON p.user_id = u.id
+        WHERE cm.cohort_id = $1
+        ORDER BY cm.status ASC, p.last_name ASC, p.first_name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, cohortID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cohort roster: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*models.CohortMember{}
+	for rows.Next() {
+		member := &models.CohortMember{}
+		if err := rows.Scan(
+			&member.CohortID,
+			&member.MenteeID,
+			&member.FirstName,
+			&member.LastName,
+			&member.Email,
+			&member.Status,
+			&member.EnrolledAt,
+			&member.SessionsTotal,
+			&member.SessionsAttended,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan cohort member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohort roster: %w", err)
+	}
+
+	return members, nil
+}
+
+// ListEnrolledMenteeIDs retrieves the IDs of everyone currently in a cohort
+func (r *CohortRepository) ListEnrolledMenteeIDs(ctx context.Context, cohortID int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT mentee_id FROM cohort_members
+        WHERE cohort_id = $1 AND status = 'enrolled'`, cohortID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cohort members: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan cohort member: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohort members: %w", err)
+	}
+
+	return ids, nil
+}
+
+// CreateSession schedules a group session for a cohort
+func (r *CohortRepository) CreateSession(ctx context.Context, session *models.CohortSession) error {
+	query := `
+        INSERT INTO cohort_sessions (cohort_id, title, topic, start_time, end_time, status, meeting_url)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		session.CohortID,
+		session.Title,
+		session.Topic,
+		session.StartTime,
+		session.EndTime,
+		session.Status,
+		session.MeetingURL,
+	).Scan(&session.ID, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create cohort session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSession retrieves a cohort session with its attendance records
+func (r *CohortRepository) GetSession(ctx context.Context, sessionID int) (*models.CohortSession, error) {
+	query := `
+        SELECT id, cohort_id, title, topic, start_time, end_time, status, meeting_url, created_at, updated_at
+        FROM cohort_sessions
+        WHERE id = $1`
+
+	session := &models.CohortSession{}
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(cohortSessionScanFields(session)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrCohortSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort session: %w", err)
+	}
+
+	session.Attendance, err = r.ListAttendance(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// UpdateSessionStatus marks a cohort session completed or cancelled
+func (r *CohortRepository) UpdateSessionStatus(ctx context.Context, sessionID int, status string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE cohort_sessions SET status = $1 WHERE id = $2`, status, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to update cohort session status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrCohortSessionNotFound
+	}
+
+	return nil
+}
+
+// ListSessions retrieves a cohort's group sessions in chronological order
+func (r *CohortRepository) ListSessions(ctx context.Context, cohortID int) ([]*models.CohortSession, error) {
+	query := `
+        SELECT id, cohort_id, title, topic, start_time, end_time, status, meeting_url, created_at, updated_at
+        FROM cohort_sessions
+        WHERE cohort_id = $1
+        ORDER BY start_time ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, cohortID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cohort sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*models.CohortSession{}
+	for rows.Next() {
+		session := &models.CohortSession{}
+		if err := rows.Scan(cohortSessionScanFields(session)...); err != nil {
+			return nil, fmt.Errorf("failed to scan cohort session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohort sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RecordAttendance saves attendance for a session, replacing earlier entries for the same mentees
+func (r *CohortRepository) RecordAttendance(ctx context.Context, records []*models.AttendanceRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO cohort_attendance (session_id, mentee_id, status)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (session_id, mentee_id) DO UPDATE
+        SET status = EXCLUDED.status, recorded_at = CURRENT_TIMESTAMP
+        RETURNING recorded_at`
+
+	for _, record := range records {
+		err := tx.QueryRowContext(ctx, query,
+			record.SessionID,
+			record.MenteeID,
+			record.Status,
+		).Scan(&record.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record attendance: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListAttendance retrieves the attendance recorded for a session
+func (r *CohortRepository) ListAttendance(ctx context.Context, sessionID int) ([]*models.AttendanceRecord, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT session_id, mentee_id, status, recorded_at
+        FROM cohort_attendance
+        WHERE session_id = $1
+        ORDER BY mentee_id ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attendance: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*models.AttendanceRecord{}
+	for rows.Next() {
+		record := &models.AttendanceRecord{}
+		if err := rows.Scan(&record.SessionID, &record.MenteeID, &record.Status, &record.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attendance: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attendance: %w", err)
+	}
+
+	return records, nil
+}
+
+// CreateAnnouncement posts a message to a cohort
+func (r *CohortRepository) CreateAnnouncement(ctx context.Context, announcement *models.CohortAnnouncement) error {
+	query := `
+        INSERT INTO cohort_announcements (cohort_id, author_id, title, body)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		announcement.CohortID,
+		announcement.AuthorID,
+		announcement.Title,
+		announcement.Body,
+	).Scan(&announcement.ID, &announcement.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	return nil
+}
+
+// ListAnnouncements retrieves a cohort's announcements, newest first
+func (r *CohortRepository) ListAnnouncements(ctx context.Context, cohortID int) ([]*models.CohortAnnouncement, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, cohort_id, author_id, title, body, created_at
+        FROM cohort_announcements
+        WHERE cohort_id = $1
+        ORDER BY created_at DESC`, cohortID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := []*models.CohortAnnouncement{}
+	for rows.Next() {
+		a := &models.CohortAnnouncement{}
+		if err := rows.Scan(&a.ID, &a.CohortID, &a.AuthorID, &a.Title, &a.Body, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+func (r *CohortRepository) queryCohorts(ctx context.Context, query string, args ...interface{}) ([]*models.Cohort, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cohorts: %w", err)
+	}
+	defer rows.Close()
+
+	cohorts := []*models.Cohort{}
+	for rows.Next() {
+		cohort := &models.Cohort{}
+		if err := rows.Scan(cohortScanFields(cohort)...); err != nil {
+			return nil, fmt.Errorf("failed to scan cohort: %w", err)
+		}
+		cohorts = append(cohorts, cohort)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohorts: %w", err)
+	}
+
+	return cohorts, nil
+}
+
+func cohortScanFields(cohort *models.Cohort) []interface{} {
+	return []interface{}{
+		&cohort.ID,
+		&cohort.ProgramID,
+		&cohort.MentorID,
+		&cohort.Name,
+		&cohort.EnrollmentOpensAt,
+		&cohort.EnrollmentClosesAt,
+		&cohort.StartsOn,
+		&cohort.EndsOn,
+		&cohort.Capacity,
+		&cohort.Status,
+		&cohort.EnrolledCount,
+		&cohort.CreatedAt,
+		&cohort.UpdatedAt,
+	}
+}
+
+func cohortSessionScanFields(session *models.CohortSession) []interface{} {
+	return []interface{}{
+		&session.ID,
+		&session.CohortID,
+		&session.Title,
+		&session.Topic,
+		&session.StartTime,
+		&session.EndTime,
+		&session.Status,
+		&session.MeetingURL,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	}
+}
@@ -0,0 +1,613 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrCurriculumNotFound    = errors.New("curriculum version not found")
+	ErrNoPublishedCurriculum = errors.New("program has no published curriculum")
+	ErrModuleNotFound        = errors.New("curriculum module not found")
+	ErrResourceNotFound      = errors.New("module resource not found")
+)
+
+type CurriculumRepository struct {
+	db *sql.DB
+}
+
+func NewCurriculumRepository(db *sql.DB) *CurriculumRepository {
+	return &CurriculumRepository{
+		db: db,
+	}
+}
+
+// CreateDraft starts a new draft version of a program's curriculum, copying the
+// modules and resources of the latest published version when there is one
+func (r *CurriculumRepository) CreateDraft(ctx context.Context, programID, createdBy int) (*models.CurriculumVersion, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var latestVersion int
+	var publishedID sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(MAX(version), 0),
+               (SELECT id FROM curriculum_versions
+                WHERE program_id = $1 AND status = 'published'
+                ORDER BY version DESC LIMIT 1)
+        FROM curriculum_versions
+        WHERE program_id = $1`, programID).Scan(&latestVersion, &publishedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest curriculum version: %w", err)
+	}
+
+	draft := &models.CurriculumVersion{
+		ProgramID: programID,
+		Version:   latestVersion + 1,
+		Status:    models.CurriculumStatus.Draft,
+		CreatedBy: createdBy,
+	}
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO curriculum_versions (program_id, version, status, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, updated_at`,
+		draft.ProgramID,
+		draft.Version,
+		draft.Status,
+		draft.CreatedBy,
+	).Scan(&draft.ID, &draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create curriculum draft: %w", err)
+	}
+
+	if publishedID.Valid {
+		if err := copyModules(ctx, tx, int(publishedID.Int64), draft.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetVersion(ctx, draft.ID)
+}
+
+// copyModules duplicates every module and resource of one version into another
+func copyModules(ctx context.Context, tx *sql.Tx, fromVersionID, toVersionID int) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id FROM curriculum_modules WHERE version_id = $1 ORDER BY position ASC`, fromVersionID)
+	if err != nil {
+		return fmt.Errorf("failed to list modules to copy: %w", err)
+	}
+	var moduleIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan module to copy: %w", err)
+		}
+		moduleIDs = append(moduleIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating modules to copy: %w", err)
+	}
+
+	for _, oldID := range moduleIDs {
+		var newID int
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO curriculum_modules (version_id, position, title, summary, objectives, assignment)
+            SELECT $1, position, title, summary, objectives, assignment
+            FROM curriculum_modules WHERE id = $2
+            RETURNING id`, toVersionID, oldID).Scan(&newID)
+		if err != nil {
+			return fmt.Errorf("failed to copy module: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO module_resources (module_id, kind, title, url, file_key, file_name,
+                                          content_type, size_bytes, position)
+            SELECT $1, kind, title, url, file_key, file_name, content_type, size_bytes, position
+            FROM module_resources WHERE module_id = $2`, newID, oldID)
+		if err != nil {
+			return fmt.Errorf("failed to copy module resources: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetDraft retrieves the program's current draft with its modules
+func (r *CurriculumRepository) GetDraft(ctx context.Context, programID int) (*models.CurriculumVersion, error) {
+	var versionID int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT id FROM curriculum_versions
+        WHERE program_id = $1 AND status = 'draft'`, programID).Scan(&versionID)
+	if err == sql.ErrNoRows {
+		return nil, ErrCurriculumNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get curriculum draft: %w", err)
+	}
+
+	return r.GetVersion(ctx, versionID)
+}
+
+// GetLatestPublished retrieves the newest published version of a program's curriculum
+func (r *CurriculumRepository) GetLatestPublished(ctx context.Context, programID int) (*models.CurriculumVersion, error) {
+	var versionID int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT id FROM curriculum_versions
+        WHERE program_id = $1 AND status = 'published'
+        ORDER BY version DESC
+        LIMIT 1`, programID).Scan(&versionID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoPublishedCurriculum
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get published curriculum: %w", err)
+	}
+
+	return r.GetVersion(ctx, versionID)
+}
+
+// GetVersion retrieves a curriculum version with its modules and resources
+func (r *CurriculumRepository) GetVersion(ctx context.Context, versionID int) (*models.CurriculumVersion, error) {
+	query := `
+        SELECT id, program_id, version, status, published_at, created_by, created_at, updated_at
+        FROM curriculum_versions
+        WHERE id = $1`
+
+	version := &models.CurriculumVersion{}
+	err := r.db.QueryRowContext(ctx, query, versionID).Scan(curriculumVersionScanFields(version)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrCurriculumNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get curriculum version: %w", err)
+	}
+
+	version.Modules, err = r.listModules(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// ListVersions retrieves every version of a program's curriculum without modules, newest first
+func (r *CurriculumRepository) ListVersions(ctx context.Context, programID int) ([]*models.CurriculumVersion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, program_id, version, status, published_at, created_by, created_at, updated_at
+        FROM curriculum_versions
+        WHERE program_id = $1
+        ORDER BY version DESC`, programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list curriculum versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*models.CurriculumVersion{}
+	for rows.Next() {
+		version := &models.CurriculumVersion{}
+		if err := rows.Scan(curriculumVersionScanFields(version)...); err != nil {
+			return nil, fmt.Errorf("failed to scan curriculum version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating curriculum versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// PublishVersion freezes a draft so mentees can start following it
+func (r *CurriculumRepository) PublishVersion(ctx context.Context, versionID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE curriculum_versions
+        SET status = 'published', published_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'draft'`, versionID)
+	if err != nil {
+		return fmt.Errorf("failed to publish curriculum: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrCurriculumNotFound
+	}
+
+	return nil
+}
+
+// CreateModule adds a module to a curriculum version
+func (r *CurriculumRepository) CreateModule(ctx context.Context, module *models.CurriculumModule) error {
+	query := `
+        INSERT INTO curriculum_modules (version_id, position, title, summary, objectives, assignment)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		module.VersionID,
+		module.Position,
+		module.Title,
+		module.Summary,
+		pq.Array(module.Objectives),
+		module.Assignment,
+	).Scan(&module.ID, &module.CreatedAt, &module.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create module: %w", err)
+	}
+
+	return nil
+}
+
+// GetModule retrieves a module with its resources
+func (r *CurriculumRepository) GetModule(ctx context.Context, moduleID int) (*models.CurriculumModule, error) {
+	query := `
+        SELECT id, version_id, position, title, summary, objectives, assignment, created_at, updated_at
+        FROM curriculum_modules
+        WHERE id = $1`
+
+	module := &models.CurriculumModule{}
+	err := r.db.QueryRowContext(ctx, query, moduleID).Scan(curriculumModuleScanFields(module)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrModuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get module: %w", err)
+	}
+
+	module.Resources, err = r.listResources(ctx, `WHERE module_id = $1`, moduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return module, nil
+}
+
+// UpdateModule saves a module's editable fields
+func (r *CurriculumRepository) UpdateModule(ctx context.Context, module *models.CurriculumModule) error {
+	query := `
+        UPDATE curriculum_modules
+        SET title = $1, summary = $2, objectives = $3, assignment = $4, position = $5
+        WHERE id = $6
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		module.Title,
+		module.Summary,
+		pq.Array(module.Objectives),
+		module.Assignment,
+		module.Position,
+		module.ID,
+	).Scan(&module.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrModuleNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update module: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteModule removes a module and its resources
+func (r *CurriculumRepository) DeleteModule(ctx context.Context, moduleID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM curriculum_modules WHERE id = $1`, moduleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete module: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrModuleNotFound
+	}
+
+	return nil
+}
+
+// ReorderModules sets each module's position to its index in moduleIDs
+func (r *CurriculumRepository) ReorderModules(ctx context.Context, versionID int, moduleIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, moduleID := range moduleIDs {
+		result, err := tx.ExecContext(ctx, `
+            UPDATE curriculum_modules SET position = $1
+            WHERE id = $2 AND version_id = $3`, i+1, moduleID, versionID)
+		if err != nil {
+			return fmt.Errorf("failed to reorder modules: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return ErrModuleNotFound
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CreateResource attaches a link or uploaded file to a module
+func (r *CurriculumRepository) CreateResource(ctx context.Context, resource *models.ModuleResource) error {
+	query := `
+        INSERT INTO module_resources (module_id, kind, title, url, file_key, file_name,
+                                      content_type, size_bytes, position)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		resource.ModuleID,
+		resource.Kind,
+		resource.Title,
+		resource.URL,
+		resource.FileKey,
+		resource.FileName,
+		resource.ContentType,
+		resource.SizeBytes,
+		resource.Position,
+	).Scan(&resource.ID, &resource.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	return nil
+}
+
+// GetResource retrieves a single module resource
+func (r *CurriculumRepository) GetResource(ctx context.Context, resourceID int) (*models.ModuleResource, error) {
+	resources, err := r.listResources(ctx, `WHERE id = $1`, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, ErrResourceNotFound
+	}
+
+	return resources[0], nil
+}
+
+// DeleteResource removes a resource from a module
+func (r *CurriculumRepository) DeleteResource(ctx context.Context, resourceID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM module_resources WHERE id = $1`, resourceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete resource: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// GetPinnedVersionID returns the curriculum version a mentorship follows
+func (r *CurriculumRepository) GetPinnedVersionID(ctx context.Context, requestID int) (int, error) {
+	var versionID int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT version_id FROM mentee_curricula WHERE request_id = $1`, requestID).Scan(&versionID)
+	if err == sql.ErrNoRows {
+		return 0, ErrCurriculumNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pinned curriculum: %w", err)
+	}
+
+	return versionID, nil
+}
+
+// PinVersion fixes the curriculum version a mentorship follows. An existing pin
+// is kept, and the version actually pinned is returned.
+func (r *CurriculumRepository) PinVersion(ctx context.Context, requestID, versionID int) (int, error) {
+	var pinned int
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO mentee_curricula (request_id, version_id)
+        VALUES ($1, $2)
+        ON CONFLICT (request_id) DO UPDATE SET request_id = mentee_curricula.request_id
+        RETURNING version_id`, requestID, versionID).Scan(&pinned)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pin curriculum: %w", err)
+	}
+
+	return pinned, nil
+}
+
+// IsVersionPinnedForMentee reports whether any of the mentee's mentorships follows the version
+func (r *CurriculumRepository) IsVersionPinnedForMentee(ctx context.Context, menteeID, versionID int) (bool, error) {
+	var pinned bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM mentee_curricula mc
+            JOIN mentorship_requests mr ON mc.request_id = mr.id
+            WHERE mr.mentee_id = $1 AND mc.version_id = $2
+        )`, menteeID, versionID).Scan(&pinned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check curriculum access: %w", err)
+	}
+
+	return pinned, nil
+}
+
+// ListCompletions retrieves the modules a mentorship has completed
+func (r *CurriculumRepository) ListCompletions(ctx context.Context, requestID int) ([]*models.ModuleCompletion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT module_id, completed_at
+        FROM module_completions
+        WHERE request_id = $1
+        ORDER BY completed_at ASC`, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list module completions: %w", err)
+	}
+	defer rows.Close()
+
+	completions := []*models.ModuleCompletion{}
+	for rows.Next() {
+		completion := &models.ModuleCompletion{}
+		if err := rows.Scan(&completion.ModuleID, &completion.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan module completion: %w", err)
+		}
+		completions = append(completions, completion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating module completions: %w", err)
+	}
+
+	return completions, nil
+}
+
+// SetCompletion marks a module done or not done for a mentorship
+func (r *CurriculumRepository) SetCompletion(ctx context.Context, requestID, moduleID int, done bool) error {
+	var err error
+	if done {
+		_, err = r.db.ExecContext(ctx, `
+            INSERT INTO module_completions (request_id, module_id)
+            VALUES ($1, $2)
+            ON CONFLICT (request_id, module_id) DO NOTHING`, requestID, moduleID)
+	} else {
+		_, err = r.db.ExecContext(ctx, `
+            DELETE FROM module_completions
+            WHERE request_id = $1 AND module_id = $2`, requestID, moduleID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update module completion: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CurriculumRepository) listModules(ctx context.Context, versionID int) ([]*models.CurriculumModule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, version_id, position, title, summary, objectives, assignment, created_at, updated_at
+        FROM curriculum_modules
+        WHERE version_id = $1
+        ORDER BY position ASC, id ASC`, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+	defer rows.Close()
+
+	modules := []*models.CurriculumModule{}
+	byID := make(map[int]*models.CurriculumModule)
+	for rows.Next() {
+		module := &models.CurriculumModule{Resources: []*models.ModuleResource{}}
+		if err := rows.Scan(curriculumModuleScanFields(module)...); err != nil {
+			return nil, fmt.Errorf("failed to scan module: %w", err)
+		}
+		modules = append(modules, module)
+		byID[module.ID] = module
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating modules: %w", err)
+	}
+
+	resources, err := r.listResources(ctx, `
+        WHERE module_id IN (SELECT id FROM curriculum_modules WHERE version_id = $1)`, versionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		if module, ok := byID[resource.ModuleID]; ok {
+			module.Resources = append(module.Resources, resource)
+		}
+	}
+
+	return modules, nil
+}
+
+func (r *CurriculumRepository) listResources(ctx context.Context, where string, args ...interface{}) ([]*models.ModuleResource, error) {
+	query := `
+        SELECT id, module_id, kind, title, url, file_key, file_name, content_type, size_bytes, position, created_at
+        FROM module_resources
+        ` + where + `
+        ORDER BY position ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	defer rows.Close()
+
+	resources := []*models.ModuleResource{}
+	for rows.Next() {
+		resource := &models.ModuleResource{}
+		if err := rows.Scan(
+			&resource.ID,
+			&resource.ModuleID,
+			&resource.Kind,
+			&resource.Title,
+			&resource.URL,
+			&resource.FileKey,
+			&resource.FileName,
+			&resource.ContentType,
+			&resource.SizeBytes,
+			&resource.Position,
+			&resource.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan resource: %w", err)
+		}
+		resources = append(resources, resource)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating resources: %w", err)
+	}
+
+	return resources, nil
+}
+
+func curriculumVersionScanFields(version *models.CurriculumVersion) []interface{} {
+	return []interface{}{
+		&version.ID,
+		&version.ProgramID,
+		&version.Version,
+		&version.Status,
+		&version.PublishedAt,
+		&version.CreatedBy,
+		&version.CreatedAt,
+		&version.UpdatedAt,
+	}
+}
+
+func curriculumModuleScanFields(module *models.CurriculumModule) []interface{} {
+	return []interface{}{
+		&module.ID,
+		&module.VersionID,
+		&module.Position,
+		&module.Title,
+		&module.Summary,
+		pq.Array(&module.Objectives),
+		&module.Assignment,
+		&module.CreatedAt,
+		&module.UpdatedAt,
+	}
+}
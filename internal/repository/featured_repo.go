@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+var ErrFeaturedMentorNotFound = errors.New("featured mentor not found")
+
+// featuredModeKey is the admin_settings key holding the featured mentors mode
+const featuredModeKey = "featured_mentors_mode"
+
+const featuredColumns = `
+        f.id, f.mentor_id, COALESCE(p.first_name, ''), COALESCE(p.last_name, ''), f.headline,
+        COALESCE(p.skills, ''), COALESCE(p.rate, 0), COALESCE(p.profile_picture, ''),
+        ` + mentorRatingExpr + `, COALESCE(p.rating_count, 0),
+        f.position, f.starts_at, f.ends_at, f.created_by, f.created_at, f.updated_at`
+
+const featuredJoins = `
+        FROM featured_mentors f
+        LEFT JOIN profiles p ON p.user_id = f.mentor_id`
+
+type FeaturedRepository struct {
+	db *sql.DB
+}
+
+func NewFeaturedRepository(db *sql.DB) *FeaturedRepository {
+	return &FeaturedRepository{
+		db: db,
+	}
+}
+
+// CreateFeature curates a mentor onto the home page
+func (r *FeaturedRepository) CreateFeature(ctx context.Context, feature *models.FeaturedMentor) error {
+	query := `
+        INSERT INTO featured_mentors (mentor_id, headline, position, starts_at, ends_at, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		feature.MentorID,
+		feature.Headline,
+		feature.Position,
+		feature.StartsAt,
+		feature.EndsAt,
+		feature.CreatedBy,
+	).Scan(&feature.ID, &feature.CreatedAt, &feature.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create featured mentor: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFeature changes a curated entry's headline, position or display window
+func (r *FeaturedRepository) UpdateFeature(ctx context.Context, feature *models.FeaturedMentor) error {
+	query := `
+        UPDATE featured_mentors
+        SET headline = $1, position = $2, starts_at = $3, ends_at = $4
+        WHERE id = $5`
+
+	result, err := r.db.ExecContext(ctx, query,
+		feature.Headline,
+		feature.Position,
+		feature.StartsAt,
+		feature.EndsAt,
+		feature.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update featured mentor: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrFeaturedMentorNotFound
+	}
+
+	return nil
+}
+
+// DeleteFeature removes a curated entry
+func (r *FeaturedRepository) DeleteFeature(ctx context.Context, featureID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM featured_mentors WHERE id = $1`, featureID)
+	if err != nil {
+		return fmt.Errorf("failed to delete featured mentor: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrFeaturedMentorNotFound
+	}
+
+	return nil
+}
+
+// GetFeature returns a curated entry
+func (r *FeaturedRepository) GetFeature(ctx context.Context, featureID int) (*models.FeaturedMentor, error) {
+	query := `SELECT ` + featuredColumns + featuredJoins + ` WHERE f.id = $1`
+
+	feature, err := scanFeaturedMentor(r.db.QueryRowContext(ctx, query, featureID))
+	if err == sql.ErrNoRows {
+		return nil, ErrFeaturedMentorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get featured mentor: %w", err)
+	}
+
+	return feature, nil
+}
+
+// ListFeatures returns every curated entry, current and scheduled first
+func (r *FeaturedRepository) ListFeatures(ctx context.Context) ([]*models.FeaturedMentor, error) {
+	query := `SELECT ` + featuredColumns + featuredJoins + `
+        ORDER BY (f.ends_at IS NOT NULL AND f.ends_at <= CURRENT_TIMESTAMP), f.position, f.starts_at DESC, f.id`
+
+	return r.listFeatured(ctx, query)
+}
+
+// ListActiveFeatures returns the curated entries shown at now for approved
+// mentors, in display order
+func (r *FeaturedRepository) ListActiveFeatures(ctx context.Context, now time.Time, limit int) ([]*models.FeaturedMentor, error) {
+	query := `SELECT ` + featuredColumns + featuredJoins + `
+        JOIN users u ON u.id = f.mentor_id
+        WHERE u.is_mentor = true AND u.is_approved = true
+          AND f.starts_at <= $1 AND (f.ends_at IS NULL OR f.ends_at > $1)
+        ORDER BY f.position, f.starts_at, f.id
+        LIMIT $2`
+
+	return r.listFeatured(ctx, query, now, limit)
+}
+
+// ListTopRatedAvailable returns approved, available mentors with at least
+// minReviews ratings, best rated first
+func (r *FeaturedRepository) ListTopRatedAvailable(ctx context.Context, minReviews, limit int) ([]*models.FeaturedMentor, error) {
+	query := `
+        SELECT p.user_id, p.first_name, p.last_name, COALESCE(p.skills, ''), COALESCE(p.rate, 0),
+               COALESCE(p.profile_picture, ''), ` + mentorRatingExpr + ` AS rating, p.rating_count
+        FROM profiles p
+        JOIN users u ON p.user_id = u.id
+        WHERE u.is_mentor = true AND u.is_approved = true
+          AND COALESCE(p.available, true) = true AND p.rating_count >= $1
+        ORDER BY rating DESC, p.rating_count DESC, p.user_id
+        LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, minReviews, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top rated mentors: %w", err)
+	}
+	defer rows.Close()
+
+	var mentors []*models.FeaturedMentor
+	for rows.Next() {
+		mentor := &models.FeaturedMentor{Source: models.FeaturedSource.Automatic}
+		if err := rows.Scan(
+			&mentor.MentorID,
+			&mentor.FirstName,
+			&mentor.LastName,
+			&mentor.Skills,
+			&mentor.Rate,
+			&mentor.ProfilePicture,
+			&mentor.AverageRating,
+			&mentor.RatingCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan top rated mentor: %w", err)
+		}
+		mentors = append(mentors, mentor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating top rated mentors: %w", err)
+	}
+
+	return mentors, nil
+}
+
+// GetMode returns the featured mentors mode, manual if it was never set
+func (r *FeaturedRepository) GetMode(ctx context.Context) (string, error) {
+	var mode sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT settings_value FROM admin_settings WHERE settings_key = $1`, featuredModeKey).Scan(&mode)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to get featured mentors mode: %w", err)
+	}
+	if !mode.Valid || mode.String == "" {
+		return models.FeaturedMentorsMode.Manual, nil
+	}
+	return mode.String, nil
+}
+
+// SetMode stores the featured mentors mode
+func (r *FeaturedRepository) SetMode(ctx context.Context, mode string) error {
+	query := `
+        INSERT INTO admin_settings (settings_key, settings_value)
+        VALUES ($1, $2)
+        ON CONFLICT (settings_key)
+        DO UPDATE SET settings_value = EXCLUDED.settings_value, updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.ExecContext(ctx, query, featuredModeKey, mode); err != nil {
+		return fmt.Errorf("failed to set featured mentors mode: %w", err)
+	}
+	return nil
+}
+
+func (r *FeaturedRepository) listFeatured(ctx context.Context, query string, args ...interface{}) ([]*models.FeaturedMentor, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list featured mentors: %w", err)
+	}
+	defer rows.Close()
+
+	features := []*models.FeaturedMentor{}
+	for rows.Next() {
+		feature, err := scanFeaturedMentor(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan featured mentor: %w", err)
+		}
+		features = append(features, feature)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating featured mentors: %w", err)
+	}
+
+	return features, nil
+}
+
+func scanFeaturedMentor(row interface{ Scan(...interface{}) error }) (*models.FeaturedMentor, error) {
+	feature := &models.FeaturedMentor{Source: models.FeaturedSource.Manual}
+	err := row.Scan(
+		&feature.ID,
+		&feature.MentorID,
+		&feature.FirstName,
+		&feature.LastName,
+		&feature.Headline,
+		&feature.Skills,
+		&feature.Rate,
+		&feature.ProfilePicture,
+		&feature.AverageRating,
+		&feature.RatingCount,
+		&feature.Position,
+		&feature.StartsAt,
+		&feature.EndsAt,
+		&feature.CreatedBy,
+		&feature.CreatedAt,
+		&feature.UpdatedAt,
+	)
+	return feature, err
+}
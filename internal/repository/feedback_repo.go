@@ -0,0 +1,388 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrFeedbackNotFound = errors.New("feedback not found")
+	ErrFeedbackExists   = errors.New("feedback already submitted for this session")
+)
+
+// feedbackColumns lists the session_feedback columns read by feedbackScanFields
+const feedbackColumns = `id, session_id, user_id, mentor_id, rating, COALESCE(comment, ''),
+               is_public, moderation_status, moderation_flags, moderation_note, moderated_by, moderated_at,
+               mentor_reply, mentor_replied_at, created_at, updated_at`
+
+type FeedbackRepository struct {
+	db *sql.DB
+}
+
+func NewFeedbackRepository(db *sql.DB) *FeedbackRepository {
+	return &FeedbackRepository{
+		db: db,
+	}
+}
+
+// CreateFeedback stores a participant's feedback and updates the mentor's rating counters
+func (r *FeedbackRepository) CreateFeedback(ctx context.Context, feedback *models.SessionFeedback) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO session_feedback (session_id, user_id, mentor_id, rating, comment,
+                                      is_public, moderation_status, moderation_flags)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (session_id, user_id) DO NOTHING
+        RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		feedback.SessionID,
+		feedback.UserID,
+		feedback.MentorID,
+		feedback.Rating,
+		feedback.Comment,
+		feedback.IsPublic,
+		feedback.ModerationStatus,
+		pq.Array(feedback.ModerationFlags),
+	).Scan(&feedback.ID, &feedback.CreatedAt, &feedback.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrFeedbackExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create feedback: %w", err)
+	}
+
+	if feedback.CountsTowardRating() {
+		if err := recalculateRating(ctx, tx, feedback.MentorID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateFeedback saves an edited rating and comment, along with the moderation
+// state the new comment needs, and recalculates the mentor's rating counters
+func (r *FeedbackRepository) UpdateFeedback(ctx context.Context, feedback *models.SessionFeedback) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+        UPDATE session_feedback
+        SET rating = $1, comment = $2, is_public = $3, moderation_status = $4, moderation_flags = $5
+        WHERE id = $6
+        RETURNING updated_at`,
+		feedback.Rating,
+		feedback.Comment,
+		feedback.IsPublic,
+		feedback.ModerationStatus,
+		pq.Array(feedback.ModerationFlags),
+		feedback.ID,
+	).Scan(&feedback.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrFeedbackNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update feedback: %w", err)
+	}
+
+	if feedback.CountsTowardRating() {
+		if err := recalculateRating(ctx, tx, feedback.MentorID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// recalculateRating rebuilds a mentor's rating counters from their mentees'
+// feedback. The profile row is locked first so that the recount, a separate
+// statement, sees every rating committed by a concurrent review.
+func recalculateRating(ctx context.Context, tx *sql.Tx, mentorID int) error {
+	var locked int
+	err := tx.QueryRowContext(ctx, `
+        SELECT user_id FROM profiles WHERE user_id = $1 FOR UPDATE`, mentorID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock rating counters: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE profiles
+        SET rating_sum = agg.rating_sum, rating_count = agg.rating_count
+        FROM (
+            SELECT COALESCE(SUM(rating), 0) AS rating_sum, COUNT(*) AS rating_count
+            FROM session_feedback
+            WHERE mentor_id = $1 AND user_id <> mentor_id
+        ) agg
+        WHERE user_id = $1`, mentorID); err != nil {
+		return fmt.Errorf("failed to update rating counters: %w", err)
+	}
+	return nil
+}
+
+// GetFeedback retrieves a feedback entry by ID
+func (r *FeedbackRepository) GetFeedback(ctx context.Context, feedbackID int) (*models.SessionFeedback, error) {
+	query := `
+        SELECT ` + feedbackColumns + `
+        FROM session_feedback
+        WHERE id = $1`
+
+	feedback := &models.SessionFeedback{}
+	err := r.db.QueryRowContext(ctx, query, feedbackID).Scan(feedbackScanFields(feedback)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrFeedbackNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
+	}
+
+	return feedback, nil
+}
+
+// ListSessionFeedback retrieves the feedback left for a specific session
+func (r *FeedbackRepository) ListSessionFeedback(ctx context.Context, sessionID int) ([]*models.SessionFeedback, error) {
+	query := `
+        SELECT ` + feedbackColumns + `
+        FROM session_feedback
+        WHERE session_id = $1
+        ORDER BY created_at DESC`
+
+	return r.queryFeedback(ctx, query, sessionID)
+}
+
+// SetVisibility records whether the author wants the feedback shown publicly
+func (r *FeedbackRepository) SetVisibility(ctx context.Context, feedbackID int, public bool) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE session_feedback SET is_public = $1 WHERE id = $2`,
+		public, feedbackID)
+	if err != nil {
+		return fmt.Errorf("failed to update feedback visibility: %w", err)
+	}
+	return feedbackRowsAffected(result)
+}
+
+// SetModeration records an admin's moderation decision
+func (r *FeedbackRepository) SetModeration(ctx context.Context, feedbackID, adminID int, status, note string) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE session_feedback
+        SET moderation_status = $1, moderation_note = $2, moderated_by = $3, moderated_at = $4
+        WHERE id = $5`,
+		status, note, adminID, time.Now().UTC(), feedbackID)
+	if err != nil {
+		return fmt.Errorf("failed to moderate feedback: %w", err)
+	}
+	return feedbackRowsAffected(result)
+}
+
+// SaveMentorReply stores the mentor's public reply to a review
+func (r *FeedbackRepository) SaveMentorReply(ctx context.Context, feedbackID int, reply string) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE session_feedback SET mentor_reply = $1, mentor_replied_at = $2 WHERE id = $3`,
+		reply, time.Now().UTC(), feedbackID)
+	if err != nil {
+		return fmt.Errorf("failed to save mentor reply: %w", err)
+	}
+	return feedbackRowsAffected(result)
+}
+
+// ListModerationQueue retrieves public reviews with the given moderation status, oldest first
+func (r *FeedbackRepository) ListModerationQueue(ctx context.Context, status string) ([]*models.SessionFeedback, error) {
+	query := `
+        SELECT ` + feedbackColumns + `
+        FROM session_feedback
+        WHERE is_public = true AND comment <> '' AND moderation_status = $1
+        ORDER BY created_at ASC
+        LIMIT 100`
+
+	return r.queryFeedback(ctx, query, status)
+}
+
+// ListTestimonials retrieves a mentor's approved public reviews with the author's name
+func (r *FeedbackRepository) ListTestimonials(ctx context.Context, mentorID, limit int) ([]*models.Testimonial, error) {
+	query := `
+        SELECT sf.id, sf.rating, sf.comment, COALESCE(p.first_name, ''), COALESCE(p.last_name, ''),
+               sf.mentor_reply, sf.mentor_replied_at, sf.created_at
+        FROM session_feedback sf
+        LEFT JOIN profiles p ON p.user_id = sf.user_id
+        WHERE sf.mentor_id = $1 AND sf.user_id <> sf.mentor_id
+          AND sf.is_public = true AND sf.moderation_status = $2 AND sf.comment <> ''
+        ORDER BY sf.created_at DESC
+        LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, mentorID, models.ReviewStatus.Approved, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list testimonials: %w", err)
+	}
+	defer rows.Close()
+
+	testimonials := []*models.Testimonial{}
+	for rows.Next() {
+		t := &models.Testimonial{}
+		var firstName, lastName string
+		if err := rows.Scan(
+			&t.ID,
+			&t.Rating,
+			&t.Comment,
+			&firstName,
+			&lastName,
+			&t.MentorReply,
+			&t.MentorRepliedAt,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan testimonial: %w", err)
+		}
+		t.AuthorName = firstName
+		if lastName != "" {
+			t.AuthorName += " " + string([]rune(lastName)[0]) + "."
+		}
+		testimonials = append(testimonials, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating testimonials: %w", err)
+	}
+
+	return testimonials, nil
+}
+
+// ListMentorFeedback retrieves the feedback mentees have left for a mentor, newest first
+func (r *FeedbackRepository) ListMentorFeedback(ctx context.Context, mentorID, limit, offset int) ([]*models.FeedbackResponse, error) {
+	query := `
+        SELECT sf.id, sf.session_id, COALESCE(ms.title, ''), sf.rating, COALESCE(sf.comment, ''), sf.created_at
+        FROM session_feedback sf
+        JOIN mentorship_sessions ms ON sf.session_id = ms.id
+        WHERE sf.mentor_id = $1 AND sf.user_id <> sf.mentor_id
+        ORDER BY sf.created_at DESC
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, mentorID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mentor feedback: %w", err)
+	}
+	defer rows.Close()
+
+	responses := []*models.FeedbackResponse{}
+	for rows.Next() {
+		response := &models.FeedbackResponse{}
+		if err := rows.Scan(
+			&response.ID,
+			&response.SessionID,
+			&response.SessionTitle,
+			&response.Rating,
+			&response.Comment,
+			&response.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback: %w", err)
+		}
+		responses = append(responses, response)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feedback: %w", err)
+	}
+
+	return responses, nil
+}
+
+// GetRatingDistribution counts a mentor's ratings per star value
+func (r *FeedbackRepository) GetRatingDistribution(ctx context.Context, mentorID int) (map[int]int, error) {
+	query := `
+        SELECT rating, COUNT(*)
+        FROM session_feedback
+        WHERE mentor_id = $1 AND user_id <> mentor_id
+        GROUP BY rating`
+
+	rows, err := r.db.QueryContext(ctx, query, mentorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating distribution: %w", err)
+	}
+	defer rows.Close()
+
+	distribution := make(map[int]int)
+	for rows.Next() {
+		var rating, count int
+		if err := rows.Scan(&rating, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan rating distribution: %w", err)
+		}
+		distribution[rating] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rating distribution: %w", err)
+	}
+
+	return distribution, nil
+}
+
+func (r *FeedbackRepository) queryFeedback(ctx context.Context, query string, args ...interface{}) ([]*models.SessionFeedback, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+	defer rows.Close()
+
+	feedbacks := []*models.SessionFeedback{}
+	for rows.Next() {
+		feedback := &models.SessionFeedback{}
+		if err := rows.Scan(feedbackScanFields(feedback)...); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback: %w", err)
+		}
+		feedbacks = append(feedbacks, feedback)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feedback: %w", err)
+	}
+
+	return feedbacks, nil
+}
+
+func feedbackRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrFeedbackNotFound
+	}
+	return nil
+}
+
+func feedbackScanFields(feedback *models.SessionFeedback) []interface{} {
+	return []interface{}{
+		&feedback.ID,
+		&feedback.SessionID,
+		&feedback.UserID,
+		&feedback.MentorID,
+		&feedback.Rating,
+		&feedback.Comment,
+		&feedback.IsPublic,
+		&feedback.ModerationStatus,
+		pq.Array(&feedback.ModerationFlags),
+		&feedback.ModerationNote,
+		&feedback.ModeratedBy,
+		&feedback.ModeratedAt,
+		&feedback.MentorReply,
+		&feedback.MentorRepliedAt,
+		&feedback.CreatedAt,
+		&feedback.UpdatedAt,
+	}
+}
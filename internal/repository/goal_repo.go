@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+)
+
+var (
+	ErrGoalNotFound      = errors.New("goal not found")
+	ErrMilestoneNotFound = errors.New("milestone not found")
+)
+
+type GoalRepository struct {
+	db *sql.DB
+}
+
+func NewGoalRepository(db *sql.DB) *GoalRepository {
+	return &GoalRepository{
+		db: db,
+	}
+}
+
+// CreateGoal adds a goal to a mentorship
+func (r *GoalRepository) CreateGoal(ctx context.Context, goal *models.Goal) error {
+	query := `
+        INSERT INTO mentorship_goals (request_id, title, specific, measurable, achievable, relevant,
+                                      time_bound, target_date, status, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		goal.RequestID,
+		goal.Title,
+		goal.Specific,
+		goal.Measurable,
+		goal.Achievable,
+		goal.Relevant,
+		goal.TimeBound,
+		goal.TargetDate,
+		goal.Status,
+		goal.CreatedBy,
+	).Scan(&goal.ID, &goal.CreatedAt, &goal.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	return nil
+}
+
+// GetGoal retrieves a goal with its milestones
+func (r *GoalRepository) GetGoal(ctx context.Context, goalID int) (*models.Goal, error) {
+	query := `
+        SELECT id, request_id, title, specific, measurable, achievable, relevant, time_bound,
+               target_date, status, achieved_at, created_by, created_at, updated_at
+        FROM mentorship_goals
+        WHERE id = $1`
+
+	goal := &models.Goal{}
+	err := r.db.QueryRowContext(ctx, query, goalID).Scan(goalScanFields(goal)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrGoalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	goal.Milestones, err = r.listMilestones(ctx, `WHERE goal_id = $1`, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// UpdateGoal saves a goal's editable fields
+func (r *GoalRepository) UpdateGoal(ctx context.Context, goal *models.Goal) error {
+	query := `
+        UPDATE mentorship_goals
+        SET title = $1, specific = $2, measurable = $3, achievable = $4, relevant = $5,
+            time_bound = $6, target_date = $7, status = $8, achieved_at = $9
+        WHERE id = $10
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		goal.Title,
+		goal.Specific,
+		goal.Measurable,
+		goal.Achievable,
+		goal.Relevant,
+		goal.TimeBound,
+		goal.TargetDate,
+		goal.Status,
+		goal.AchievedAt,
+		goal.ID,
+	).Scan(&goal.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrGoalNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	return nil
+}
+
+// ListGoalsByRequest retrieves a mentorship's goals with their milestones
+func (r *GoalRepository) ListGoalsByRequest(ctx context.Context, requestID int) ([]*models.Goal, error) {
+	query := `
+        SELECT id, request_id, title, specific, measurable, achievable, relevant, time_bound,
+               target_date, status, achieved_at, created_by, created_at, updated_at
+        FROM mentorship_goals
+        WHERE request_id = $1
+        ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := []*models.Goal{}
+	byID := make(map[int]*models.Goal)
+	for rows.Next() {
+		goal := &models.Goal{Milestones: []*models.Milestone{}}
+		if err := rows.Scan(goalScanFields(goal)...); err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		goals = append(goals, goal)
+		byID[goal.ID] = goal
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goals: %w", err)
+	}
+
+	milestones, err := r.listMilestones(ctx, `
+        WHERE goal_id IN (SELECT id FROM mentorship_goals WHERE request_id = $1)`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range milestones {
+		if goal, ok := byID[m.GoalID]; ok {
+			goal.Milestones = append(goal.Milestones, m)
+		}
+	}
+
+	return goals, nil
+}
+
+// CreateMilestone adds a milestone to a goal
+func (r *GoalRepository) CreateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	query := `
+        INSERT INTO goal_milestones (goal_id, title, description, progress, due_date, completed_at, position)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		milestone.GoalID,
+		milestone.Title,
+		milestone.Description,
+		milestone.Progress,
+		milestone.DueDate,
+		milestone.CompletedAt,
+		milestone.Position,
+	).Scan(&milestone.ID, &milestone.CreatedAt, &milestone.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return nil
+}
+
+// GetMilestone retrieves a milestone by ID
+func (r *GoalRepository) GetMilestone(ctx context.Context, milestoneID int) (*models.Milestone, error) {
+	milestones, err := r.listMilestones(ctx, `WHERE id = $1`, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if len(milestones) == 0 {
+		return nil, ErrMilestoneNotFound
+	}
+	return milestones[0], nil
+}
+
+// UpdateMilestone saves a milestone's editable fields
+func (r *GoalRepository) UpdateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	query := `
+        UPDATE goal_milestones
+        SET title = $1, description = $2, progress = $3, due_date = $4, completed_at = $5, position = $6
+        WHERE id = $7
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		milestone.Title,
+		milestone.Description,
+		milestone.Progress,
+		milestone.DueDate,
+		milestone.CompletedAt,
+		milestone.Position,
+		milestone.ID,
+	).Scan(&milestone.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrMilestoneNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMilestone removes a milestone
+func (r *GoalRepository) DeleteMilestone(ctx context.Context, milestoneID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM goal_milestones WHERE id = $1`, milestoneID)
+	if err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrMilestoneNotFound
+	}
+
+	return nil
+}
+
+// CreateCheckIn records a progress update on a goal
+func (r *GoalRepository) CreateCheckIn(ctx context.Context, checkIn *models.CheckIn) error {
+	query := `
+        INSERT INTO goal_checkins (goal_id, session_id, user_id, progress, note)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		checkIn.GoalID,
+		checkIn.SessionID,
+		checkIn.UserID,
+		checkIn.Progress,
+		checkIn.Note,
+	).Scan(&checkIn.ID, &checkIn.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create check-in: %w", err)
+	}
+
+	return nil
+}
+
+// ListRequestTimeline retrieves the progress events of one mentorship, newest first
+func (r *GoalRepository) ListRequestTimeline(ctx context.Context, requestID, limit int) ([]*models.TimelineEvent, error) {
+	return r.listTimeline(ctx, `g.request_id = $1`, requestID, limit)
+}
+
+// ListUserTimeline retrieves the progress events across all of a user's mentorships, newest first
+func (r *GoalRepository) ListUserTimeline(ctx context.Context, userID, limit int) ([]*models.TimelineEvent, error) {
+	return r.listTimeline(ctx, `g.request_id IN (
+            SELECT id FROM mentorship_requests WHERE mentor_id = $1 OR mentee_id = $1)`, userID, limit)
+}
+
+// listTimeline merges goal, milestone and check-in events for the goals matching filter
+func (r *GoalRepository) listTimeline(ctx context.Context, filter string, arg interface{}, limit int) ([]*models.TimelineEvent, error) {
+	query := `
+        SELECT $2::text, g.request_id, g.id, g.title, g.title, '', NULL::int, NULL::int, g.created_at
+        FROM mentorship_goals g
+        WHERE ` + filter + `
+        UNION ALL
+        SELECT $3::text, g.request_id, g.id, g.title, g.title, '', 100, NULL::int, g.achieved_at
+        FROM mentorship_goals g
+        WHERE g.achieved_at IS NOT NULL AND ` + filter + `
+        UNION ALL
+        SELECT $4::text, g.request_id, g.id, g.title, m.title, m.description, m.progress, NULL::int, m.completed_at
+        FROM goal_milestones m
+        JOIN mentorship_goals g ON m.goal_id = g.id
+        WHERE m.completed_at IS NOT NULL AND ` + filter + `
+        UNION ALL
+        SELECT $5::text, g.request_id, g.id, g.title, 'Check-in', c.note, c.progress, c.session_id, c.created_at
+        FROM goal_checkins c
+        JOIN mentorship_goals g ON c.goal_id = g.id
+        WHERE ` + filter + `
+        ORDER BY 9 DESC
+        LIMIT $6`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		arg,
+		models.TimelineEventType.GoalCreated,
+		models.TimelineEventType.GoalAchieved,
+		models.TimelineEventType.MilestoneCompleted,
+		models.TimelineEventType.CheckIn,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list timeline: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.TimelineEvent{}
+	for rows.Next() {
+		event := &models.TimelineEvent{}
+		if err := rows.Scan(
+			&event.Type,
+			&event.RequestID,
+			&event.GoalID,
+			&event.GoalTitle,
+			&event.Title,
+			&event.Detail,
+			&event.Progress,
+			&event.SessionID,
+			&event.OccurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan timeline event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating timeline: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *GoalRepository) listMilestones(ctx context.Context, where string, args ...interface{}) ([]*models.Milestone, error) {
+	query := `
+        SELECT id, goal_id, title, description, progress, due_date, completed_at, position, created_at, updated_at
+        FROM goal_milestones
+        ` + where + `
+        ORDER BY position ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	defer rows.Close()
+
+	milestones := []*models.Milestone{}
+	for rows.Next() {
+		m := &models.Milestone{}
+		if err := rows.Scan(
+			&m.ID,
+			&m.GoalID,
+			&m.Title,
+			&m.Description,
+			&m.Progress,
+			&m.DueDate,
+			&m.CompletedAt,
+			&m.Position,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+		milestones = append(milestones, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+func goalScanFields(goal *models.Goal) []interface{} {
+	return []interface{}{
+		&goal.ID,
+		&goal.RequestID,
+		&goal.Title,
+		&goal.Specific,
+		&goal.Measurable,
+		&goal.Achievable,
+		&goal.Relevant,
+		&goal.TimeBound,
+		&goal.TargetDate,
+		&goal.Status,
+		&goal.AchievedAt,
+		&goal.CreatedBy,
+		&goal.CreatedAt,
+		&goal.UpdatedAt,
+	}
+}
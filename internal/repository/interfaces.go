@@ -5,6 +5,7 @@ package repository
 import (
 	"context"
 	"mentorApp/internal/models"
+	"time"
 )
 
 type IUserRepository interface {
@@ -22,12 +23,13 @@ type IProfileRepository interface {
 	CreateProfile(ctx context.Context, profile *models.Profile) error
 	GetProfileByUserID(ctx context.Context, userID int) (*models.Profile, error)
 	UpdateProfile(ctx context.Context, profile *models.Profile) error
-	GetUserRatings(ctx context.Context, userID int) ([]*models.Rating, error)
+	GetRatingSummary(ctx context.Context, userID int) (*models.RatingSummary, error)
 	AddExperience(ctx context.Context, experience *models.Experience) error
 	GetExperience(ctx context.Context, experienceID int) (*models.Experience, error)
 	UpdateExperience(ctx context.Context, experience *models.Experience) error
 	DeleteExperience(ctx context.Context, experienceID int) error
 	SearchProfiles(ctx context.Context, filters map[string]interface{}) ([]*models.Profile, error)
+	SearchMentors(ctx context.Context, query *models.MentorSearchQuery) (*models.MentorSearchResult, error)
 	SetAvailability(ctx context.Context, availability *models.Availability) error
 	GetProfileAvailability(ctx context.Context, profileID int) ([]models.Availability, error)
 	UpdateAvailability(ctx context.Context, availability *models.Availability) error
@@ -39,12 +41,17 @@ type IMentorshipRepository interface {
 	GetProgram(ctx context.Context, id int) (*models.MentorshipProgram, error)
 	ListMentorPrograms(ctx context.Context, mentorID int) ([]*models.MentorshipProgram, error)
 	ListActivePrograms(ctx context.Context) ([]*models.MentorshipProgram, error)
+	UpdateProgram(ctx context.Context, program *models.MentorshipProgram) error
 	UpdateProgramStatus(ctx context.Context, programID int, status string) error
+	DeleteProgram(ctx context.Context, programID int) error
+	GetProgramEnrollment(ctx context.Context, programID int) (*models.ProgramEnrollment, error)
+	GetMentorActivity(ctx context.Context, mentorID int) (*models.MentorActivity, error)
 
 	// Request Management
 	CreateRequest(ctx context.Context, request *models.MentorshipRequest) error
 	GetRequest(ctx context.Context, requestID int) (*models.MentorshipRequest, error)
-	UpdateRequestStatus(ctx context.Context, requestID int, status string) error
+	UpdateRequestStatus(ctx context.Context, requestID int, from, to string) error
+	ApproveRequest(ctx context.Context, requestID int) error
 	ListMenteeRequests(ctx context.Context, menteeID int) ([]*models.MentorshipRequest, error)
 
 	// Session Management
@@ -52,20 +59,260 @@ type IMentorshipRepository interface {
 	GetSession(ctx context.Context, sessionID int) (*models.MentorshipSession, error)
 	UpdateSessionStatus(ctx context.Context, sessionID int, status string) error
 	ListSessionsByRequest(ctx context.Context, requestID int) ([]*models.MentorshipSession, error)
+	ListScheduledSessionsBetween(ctx context.Context, userID int, from, to time.Time) ([]*models.MentorshipSession, error)
+	UpdateSessionSchedule(ctx context.Context, session *models.MentorshipSession) error
+	ListSessionsDueForReminder(ctx context.Context, before time.Time) ([]*models.MentorshipSession, error)
+	MarkReminderSent(ctx context.Context, sessionID int) error
+}
+
+type ICalendarRepository interface {
+	UpsertFeed(ctx context.Context, feed *models.CalendarFeed) error
+	GetFeedByMentor(ctx context.Context, mentorID int) (*models.CalendarFeed, error)
+	ListEnabledFeeds(ctx context.Context) ([]*models.CalendarFeed, error)
+	DeleteFeed(ctx context.Context, mentorID int) error
+	ReplaceBusyBlocks(ctx context.Context, feed *models.CalendarFeed, blocks []*models.BusyBlock) error
+	RecordSyncError(ctx context.Context, feedID int, syncErr string) error
+	ListBusyBlocks(ctx context.Context, mentorID int, from, to time.Time) ([]*models.BusyBlock, error)
+}
+
+type INotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *models.Notification) error
+	ListNotifications(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID int) error
+}
+
+type ISessionContentRepository interface {
+	GetContent(ctx context.Context, sessionID int) (*models.SessionContent, error)
+	SaveAgenda(ctx context.Context, sessionID, userID int, agenda string, expectedVersion int) (int, error)
+	SaveSharedNotes(ctx context.Context, sessionID, userID int, notes string, expectedVersion int) (int, error)
+	GetPrivateNotes(ctx context.Context, sessionID, userID int) (string, error)
+	SavePrivateNotes(ctx context.Context, sessionID, userID int, content string) error
+	CreateActionItem(ctx context.Context, item *models.ActionItem) error
+	GetActionItem(ctx context.Context, itemID int) (*models.ActionItem, error)
+	UpdateActionItem(ctx context.Context, item *models.ActionItem) error
+	DeleteActionItem(ctx context.Context, itemID int) error
+	ListSessionActionItems(ctx context.Context, requestID, sessionID int, sessionStart time.Time) ([]*models.ActionItem, error)
+	ListRequestActionItems(ctx context.Context, requestID int, openOnly bool) ([]*models.ActionItem, error)
+}
+
+type IFeedbackRepository interface {
+	CreateFeedback(ctx context.Context, feedback *models.SessionFeedback) error
+	UpdateFeedback(ctx context.Context, feedback *models.SessionFeedback) error
+	GetFeedback(ctx context.Context, feedbackID int) (*models.SessionFeedback, error)
+	ListSessionFeedback(ctx context.Context, sessionID int) ([]*models.SessionFeedback, error)
+	SetVisibility(ctx context.Context, feedbackID int, public bool) error
+	SetModeration(ctx context.Context, feedbackID, adminID int, status, note string) error
+	SaveMentorReply(ctx context.Context, feedbackID int, reply string) error
+	ListModerationQueue(ctx context.Context, status string) ([]*models.SessionFeedback, error)
+	ListTestimonials(ctx context.Context, mentorID, limit int) ([]*models.Testimonial, error)
+	ListMentorFeedback(ctx context.Context, mentorID, limit, offset int) ([]*models.FeedbackResponse, error)
+	GetRatingDistribution(ctx context.Context, mentorID int) (map[int]int, error)
+}
+
+type IGoalRepository interface {
+	CreateGoal(ctx context.Context, goal *models.Goal) error
+	GetGoal(ctx context.Context, goalID int) (*models.Goal, error)
+	UpdateGoal(ctx context.Context, goal *models.Goal) error
+	ListGoalsByRequest(ctx context.Context, requestID int) ([]*models.Goal, error)
+	CreateMilestone(ctx context.Context, milestone *models.Milestone) error
+	GetMilestone(ctx context.Context, milestoneID int) (*models.Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone *models.Milestone) error
+	DeleteMilestone(ctx context.Context, milestoneID int) error
+	CreateCheckIn(ctx context.Context, checkIn *models.CheckIn) error
+	ListRequestTimeline(ctx context.Context, requestID, limit int) ([]*models.TimelineEvent, error)
+	ListUserTimeline(ctx context.Context, userID, limit int) ([]*models.TimelineEvent, error)
+}
+
+type ICohortRepository interface {
+	CreateCohort(ctx context.Context, cohort *models.Cohort) error
+	GetCohort(ctx context.Context, cohortID int) (*models.Cohort, error)
+	UpdateCohortStatus(ctx context.Context, cohortID int, status string) error
+	ListProgramCohorts(ctx context.Context, programID int) ([]*models.Cohort, error)
+	ListMenteeCohorts(ctx context.Context, menteeID int) ([]*models.Cohort, error)
+	Enroll(ctx context.Context, cohortID, menteeID int) error
+	Withdraw(ctx context.Context, cohortID, menteeID int) error
+	IsEnrolled(ctx context.Context, cohortID, menteeID int) (bool, error)
+	ListRoster(ctx context.Context, cohortID int) ([]*models.CohortMember, error)
+	ListEnrolledMenteeIDs(ctx context.Context, cohortID int) ([]int, error)
+	CreateSession(ctx context.Context, session *models.CohortSession) error
+	GetSession(ctx context.Context, sessionID int) (*models.CohortSession, error)
+	UpdateSessionStatus(ctx context.Context, sessionID int, status string) error
+	ListSessions(ctx context.Context, cohortID int) ([]*models.CohortSession, error)
+	RecordAttendance(ctx context.Context, records []*models.AttendanceRecord) error
+	ListAttendance(ctx context.Context, sessionID int) ([]*models.AttendanceRecord, error)
+	CreateAnnouncement(ctx context.Context, announcement *models.CohortAnnouncement) error
+	ListAnnouncements(ctx context.Context, cohortID int) ([]*models.CohortAnnouncement, error)
+}
+
+type ICurriculumRepository interface {
+	CreateDraft(ctx context.Context, programID, createdBy int) (*models.CurriculumVersion, error)
+	GetDraft(ctx context.Context, programID int) (*models.CurriculumVersion, error)
+	GetLatestPublished(ctx context.Context, programID int) (*models.CurriculumVersion, error)
+	GetVersion(ctx context.Context, versionID int) (*models.CurriculumVersion, error)
+	ListVersions(ctx context.Context, programID int) ([]*models.CurriculumVersion, error)
+	PublishVersion(ctx context.Context, versionID int) error
+	CreateModule(ctx context.Context, module *models.CurriculumModule) error
+	GetModule(ctx context.Context, moduleID int) (*models.CurriculumModule, error)
+	UpdateModule(ctx context.Context, module *models.CurriculumModule) error
+	DeleteModule(ctx context.Context, moduleID int) error
+	ReorderModules(ctx context.Context, versionID int, moduleIDs []int) error
+	CreateResource(ctx context.Context, resource *models.ModuleResource) error
+	GetResource(ctx context.Context, resourceID int) (*models.ModuleResource, error)
+	DeleteResource(ctx context.Context, resourceID int) error
+	GetPinnedVersionID(ctx context.Context, requestID int) (int, error)
+	PinVersion(ctx context.Context, requestID, versionID int) (int, error)
+	IsVersionPinnedForMentee(ctx context.Context, menteeID, versionID int) (bool, error)
+	ListCompletions(ctx context.Context, requestID int) ([]*models.ModuleCompletion, error)
+	SetCompletion(ctx context.Context, requestID, moduleID int, done bool) error
+}
 
-	// Feedback Management
-	CreateSessionFeedback(ctx context.Context, feedback *models.SessionFeedback) error
-	GetSessionFeedback(ctx context.Context, sessionID int) ([]*models.SessionFeedback, error)
-	GetAverageRating(ctx context.Context, mentorID int) (float64, error)
+type IAssignmentRepository interface {
+	CreateAssignment(ctx context.Context, assignment *models.Assignment) error
+	GetAssignment(ctx context.Context, assignmentID int) (*models.Assignment, error)
+	UpdateAssignment(ctx context.Context, assignment *models.Assignment) error
+	DeleteAssignment(ctx context.Context, assignmentID int) error
+	ListForMentorship(ctx context.Context, requestID, programID int) ([]*models.Assignment, error)
+	ListForProgram(ctx context.Context, programID int) ([]*models.Assignment, error)
+	CreateSubmission(ctx context.Context, submission *models.Submission) error
+	GetSubmission(ctx context.Context, submissionID int) (*models.Submission, error)
+	ListSubmissions(ctx context.Context, assignmentID, requestID int) ([]*models.Submission, error)
+	ListLatestSubmissions(ctx context.Context, requestID int) ([]*models.Submission, error)
+	CreateReview(ctx context.Context, review *models.SubmissionReview) error
+	ListPendingReviews(ctx context.Context, mentorID int) ([]*models.PendingReview, error)
+}
+
+type IAgreementRepository interface {
+	CreateTemplate(ctx context.Context, template *models.AgreementTemplate) error
+	GetTemplate(ctx context.Context, templateID int) (*models.AgreementTemplate, error)
+	ListTemplates(ctx context.Context, programID *int) ([]*models.AgreementTemplate, error)
+	GetEffectiveTemplate(ctx context.Context, programID int) (*models.AgreementTemplate, error)
+	RequireAgreement(ctx context.Context, requestID, templateID int) error
+	GetMentorshipAgreement(ctx context.Context, requestID int) (*models.MentorshipAgreement, error)
+	Accept(ctx context.Context, acceptance *models.AgreementAcceptance) (bool, error)
+}
+
+type IPaymentRepository interface {
+	CreateInvoice(ctx context.Context, invoice *models.Invoice) error
+	GetInvoice(ctx context.Context, invoiceID int) (*models.Invoice, error)
+	GetInvoiceByRequest(ctx context.Context, requestID int) (*models.Invoice, error)
+	ListInvoices(ctx context.Context, userID int) ([]*models.Invoice, error)
+	VoidInvoice(ctx context.Context, invoiceID int) error
+	RecordCharge(ctx context.Context, payment *models.Payment, entry *models.LedgerTransaction) error
+	RecordFailedPayment(ctx context.Context, payment *models.Payment) error
+	ReserveRefund(ctx context.Context, invoiceID int, amountCents int64) (int64, error)
+	ReleaseRefund(ctx context.Context, invoiceID int, amountCents int64) error
+	RecordRefund(ctx context.Context, payment *models.Payment, entry *models.LedgerTransaction) error
+	ListLedgerTransactions(ctx context.Context, invoiceID int) ([]*models.LedgerTransaction, error)
+	ListProgramPayouts(ctx context.Context, mentorID int) ([]*models.ProgramPayout, error)
+}
+
+// ISponsorRepository defines methods for sponsor accounts and their credit budgets
+type ISponsorRepository interface {
+	CreateSponsor(ctx context.Context, sponsor *models.Sponsor) error
+	UpdateSponsor(ctx context.Context, sponsor *models.Sponsor) error
+	GetSponsor(ctx context.Context, sponsorID int) (*models.Sponsor, error)
+	GetSponsorByCode(ctx context.Context, code string) (*models.Sponsor, error)
+	ListSponsors(ctx context.Context) ([]*models.Sponsor, error)
+	CreateSponsoredRequest(ctx context.Context, code string, request *models.MentorshipRequest, credits int, now time.Time) (*models.Sponsor, error)
+	CreateSponsoredSession(ctx context.Context, session *models.MentorshipSession, creditsPerSession int) error
+	ReleaseCredits(ctx context.Context, requestID int) error
+	GetSponsorship(ctx context.Context, requestID int) (*models.Sponsorship, error)
+	ConsumeCredits(ctx context.Context, usage *models.CreditUsage) (*models.Sponsor, error)
+	ListSponsoredMentorships(ctx context.Context, sponsorID int) ([]*models.SponsoredMentorship, error)
+	ListMonthlyUsage(ctx context.Context, sponsorID int) ([]*models.MonthlyCreditUsage, error)
+}
+
+// ISkillRepository defines methods for the skills taxonomy and profile skills
+type ISkillRepository interface {
+	CreateCategory(ctx context.Context, category *models.SkillCategory) error
+	UpdateCategory(ctx context.Context, category *models.SkillCategory) error
+	DeleteCategory(ctx context.Context, categoryID int) error
+	GetCategory(ctx context.Context, categoryID int) (*models.SkillCategory, error)
+	ListCategories(ctx context.Context) ([]*models.SkillCategory, error)
+	CreateSkill(ctx context.Context, skill *models.Skill) error
+	UpdateSkill(ctx context.Context, skill *models.Skill) error
+	DeleteSkill(ctx context.Context, skillID int) error
+	GetSkill(ctx context.Context, skillID int) (*models.Skill, error)
+	ListSkills(ctx context.Context, categoryID *int, specialtiesOnly bool) ([]*models.Skill, error)
+	AddSynonym(ctx context.Context, skillID int, name, slug string) error
+	RemoveSynonym(ctx context.Context, skillID int, slug string) error
+	MergeSkill(ctx context.Context, sourceID, targetID int) error
+	SuggestSkills(ctx context.Context, prefix string, specialtiesOnly bool, limit int) ([]*models.SkillSuggestion, error)
+	ResolveSkill(ctx context.Context, name, slug string, isSpecialty bool) (*models.Skill, error)
+	GetProfileSkills(ctx context.Context, userID int) ([]*models.ProfileSkill, error)
+	SetProfileSkills(ctx context.Context, userID int, skills []*models.ProfileSkill) error
+	AddProfileSkills(ctx context.Context, userID int, skillIDs []int, level string) error
+}
+
+type IRecommendationRepository interface {
+	ListMenteeInterests(ctx context.Context, menteeID int) ([]int, error)
+	ListCandidates(ctx context.Context, menteeID int, dismissedSince time.Time) ([]*models.MentorCandidate, error)
+	SaveFeedback(ctx context.Context, menteeID, mentorID int, kind string) error
+	DeleteFeedback(ctx context.Context, menteeID, mentorID int) error
+}
+
+type IMatchingRepository interface {
+	CreateRound(ctx context.Context, round *models.MatchingRound) error
+	GetRound(ctx context.Context, roundID int) (*models.MatchingRound, error)
+	ListRounds(ctx context.Context, status string) ([]*models.MatchingRound, error)
+	SaveMentor(ctx context.Context, mentor *models.MatchingMentor) error
+	SaveMentee(ctx context.Context, mentee *models.MatchingMentee) error
+	ListMentors(ctx context.Context, roundID int) ([]*models.MatchingMentor, error)
+	ListMentees(ctx context.Context, roundID int) ([]*models.MatchingMentee, error)
+	ListPairs(ctx context.Context, roundID int) ([]*models.MatchingPair, error)
+	ReplacePairs(ctx context.Context, roundID int, pairs []*models.MatchingPair) error
+	SavePair(ctx context.Context, pair *models.MatchingPair) error
+	DeletePair(ctx context.Context, roundID, menteeID int) error
+	PublishRound(ctx context.Context, roundID int, message string) ([]*models.MentorshipRequest, error)
+}
+
+type IFeaturedRepository interface {
+	CreateFeature(ctx context.Context, feature *models.FeaturedMentor) error
+	UpdateFeature(ctx context.Context, feature *models.FeaturedMentor) error
+	DeleteFeature(ctx context.Context, featureID int) error
+	GetFeature(ctx context.Context, featureID int) (*models.FeaturedMentor, error)
+	ListFeatures(ctx context.Context) ([]*models.FeaturedMentor, error)
+	ListActiveFeatures(ctx context.Context, now time.Time, limit int) ([]*models.FeaturedMentor, error)
+	ListTopRatedAvailable(ctx context.Context, minReviews, limit int) ([]*models.FeaturedMentor, error)
+	GetMode(ctx context.Context) (string, error)
+	SetMode(ctx context.Context, mode string) error
 }
 
 type IJobRepository interface {
 	CreateJob(ctx context.Context, job *models.Job) error
 	GetJob(ctx context.Context, jobID int) (*models.Job, error)
-	ListJobs(ctx context.Context, filters map[string]interface{}) ([]*models.Job, error)
-	UpdateJob(ctx context.Context, job *models.Job) error
+	SearchJobs(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error)
+	UpdateJob(ctx context.Context, job *models.Job, revision *models.JobRevision) error
 	DeleteJob(ctx context.Context, jobID int) error
-	CreateJobApplication(ctx context.Context, application *models.JobApplication) error
-	GetJobApplication(ctx context.Context, applicationID int) (*models.JobApplication, error)
-	ListJobApplications(ctx context.Context, jobID int) ([]*models.JobApplication, error)
+	ListJobs(ctx context.Context, status string, createdBy int) ([]*models.Job, error)
+	ExpireJobs(ctx context.Context, now time.Time) ([]*models.Job, error)
+	ListJobRevisions(ctx context.Context, jobID int) ([]*models.JobRevision, error)
+	GetJobsByExternalIDs(ctx context.Context, externalIDs []string) ([]*models.Job, error)
+	ImportJobs(ctx context.Context, creates, updates []*models.Job, revisions []*models.JobRevision) (map[*models.Job]error, error)
+	CreateApplication(ctx context.Context, app *models.JobApplication) error
+	GetApplication(ctx context.Context, applicationID int) (*models.JobApplication, error)
+	ListJobApplications(ctx context.Context, jobID int, status string) ([]*models.JobApplication, error)
+	ListUserApplications(ctx context.Context, userID int) ([]*models.JobApplication, error)
+	UpdateApplicationStatus(ctx context.Context, event *models.JobApplicationEvent) error
+	AddApplicationEvent(ctx context.Context, event *models.JobApplicationEvent) error
+	ListApplicationEvents(ctx context.Context, applicationID int) ([]*models.JobApplicationEvent, error)
+	CreateReferral(ctx context.Context, referral *models.JobReferral) error
+	GetReferral(ctx context.Context, referralID int) (*models.JobReferral, error)
+	ListMentorReferrals(ctx context.Context, mentorID int) ([]*models.JobReferral, error)
+	ListMenteeReferrals(ctx context.Context, menteeID int) ([]*models.JobReferral, error)
+}
+
+type IJobAlertRepository interface {
+	CreateSavedSearch(ctx context.Context, search *models.SavedJobSearch) error
+	UpdateSavedSearch(ctx context.Context, search *models.SavedJobSearch) error
+	DeleteSavedSearch(ctx context.Context, userID, searchID int) error
+	GetSavedSearch(ctx context.Context, userID, searchID int) (*models.SavedJobSearch, error)
+	CountSavedSearches(ctx context.Context, userID int) (int, error)
+	ListSavedSearches(ctx context.Context, userID int) ([]*models.SavedJobSearch, error)
+	ListDueSavedSearches(ctx context.Context, frequency string, before time.Time) ([]*models.SavedJobSearch, error)
+	ListSavedSearchesMatchingJob(ctx context.Context, jobID int, frequency string) ([]*models.SavedJobSearch, error)
+	MarkSavedSearchAlerted(ctx context.Context, searchID int, alertedAt time.Time) error
+	SaveJob(ctx context.Context, userID, jobID int) error
+	UnsaveJob(ctx context.Context, userID, jobID int) error
+	ListSavedJobs(ctx context.Context, userID int) ([]*models.SavedJob, error)
 }
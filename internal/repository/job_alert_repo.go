@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+var ErrSavedJobSearchNotFound = errors.New("saved job search not found")
+
+const savedJobSearchColumns = `
+        s.id, s.user_id, s.name, s.query, s.location, s.job_type, s.experience_level,
+        s.remote_policy, s.frequency, s.last_alerted_at, s.created_at, s.updated_at`
+
+type JobAlertRepository struct {
+	db *sql.DB
+}
+
+func NewJobAlertRepository(db *sql.DB) *JobAlertRepository {
+	return &JobAlertRepository{db: db}
+}
+
+// CreateSavedSearch stores a user's job board filters
+func (r *JobAlertRepository) CreateSavedSearch(ctx context.Context, search *models.SavedJobSearch) error {
+	query := `
+        INSERT INTO saved_job_searches (
+            user_id, name, query, location, job_type, experience_level, remote_policy, frequency
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, last_alerted_at, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		search.UserID, search.Name, search.Text, search.Location,
+		search.JobType, search.ExperienceLevel, search.RemotePolicy, search.Frequency,
+	).Scan(&search.ID, &search.LastAlertedAt, &search.CreatedAt, &search.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create saved job search: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSavedSearch changes one of the user's saved searches
+func (r *JobAlertRepository) UpdateSavedSearch(ctx context.Context, search *models.SavedJobSearch) error {
+	query := `
+        UPDATE saved_job_searches
+        SET name = $1, query = $2, location = $3, job_type = $4,
+            experience_level = $5, remote_policy = $6, frequency = $7
+        WHERE id = $8 AND user_id = $9
+        RETURNING last_alerted_at, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		search.Name, search.Text, search.Location, search.JobType,
+		search.ExperienceLevel, search.RemotePolicy, search.Frequency,
+		search.ID, search.UserID,
+	).Scan(&search.LastAlertedAt, &search.CreatedAt, &search.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSavedJobSearchNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update saved job search: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSavedSearch removes one of the user's saved searches
+func (r *JobAlertRepository) DeleteSavedSearch(ctx context.Context, userID, searchID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_job_searches WHERE id = $1 AND user_id = $2`, searchID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved job search: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrSavedJobSearchNotFound
+	}
+
+	return nil
+}
+
+// GetSavedSearch returns one of the user's saved searches
+func (r *JobAlertRepository) GetSavedSearch(ctx context.Context, userID, searchID int) (*models.SavedJobSearch, error) {
+	query := `SELECT ` + savedJobSearchColumns + ` FROM saved_job_searches s WHERE s.id = $1 AND s.user_id = $2`
+
+	search, err := scanSavedJobSearch(r.db.QueryRowContext(ctx, query, searchID, userID))
+	if err == sql.ErrNoRows {
+		return nil, ErrSavedJobSearchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved job search: %w", err)
+	}
+
+	return search, nil
+}
+
+// CountSavedSearches returns how many searches the user has saved
+func (r *JobAlertRepository) CountSavedSearches(ctx context.Context, userID int) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM saved_job_searches WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count saved job searches: %w", err)
+	}
+	return count, nil
+}
+
+// ListSavedSearches returns the user's saved searches, oldest first
+func (r *JobAlertRepository) ListSavedSearches(ctx context.Context, userID int) ([]*models.SavedJobSearch, error) {
+	query := `SELECT ` + savedJobSearchColumns + `
+        FROM saved_job_searches s
+        WHERE s.user_id = $1
+        ORDER BY s.created_at, s.id`
+
+	return r.listSavedSearches(ctx, query, userID)
+}
+
+// ListDueSavedSearches returns the searches with the given frequency that were
+// last alerted at or before before
+func (r *JobAlertRepository) ListDueSavedSearches(ctx context.Context, frequency string, before time.Time) ([]*models.SavedJobSearch, error) {
+	query := `SELECT ` + savedJobSearchColumns + `
+        FROM saved_job_searches s
+        WHERE s.frequency = $1 AND s.last_alerted_at <= $2
+        ORDER BY s.last_alerted_at, s.id`
+
+	return r.listSavedSearches(ctx, query, frequency, before)
+}
+
+// ListSavedSearchesMatchingJob returns the searches with the given frequency
+// whose filters match a job, leaving out the job's own poster. Filters behave
+// as they do on the job board.
+func (r *JobAlertRepository) ListSavedSearchesMatchingJob(ctx context.Context, jobID int, frequency string) ([]*models.SavedJobSearch, error) {
+	query := `SELECT ` + savedJobSearchColumns + `
+        FROM saved_job_searches s
+        JOIN jobs j ON j.id = $1
+        WHERE s.frequency = $2
+          AND s.user_id IS DISTINCT FROM j.created_by
+          AND (s.query = '' OR j.search_vector @@ websearch_to_tsquery('english', s.query))
+          AND (s.location = '' OR j.location ILIKE '%' || s.location || '%')
+          AND (s.job_type = '' OR s.job_type = j.job_type)
+          AND (s.experience_level = '' OR s.experience_level = j.experience_level)
+          AND (s.remote_policy = '' OR s.remote_policy = j.remote_policy)
+        ORDER BY s.id`
+
+	return r.listSavedSearches(ctx, query, jobID, frequency)
+}
+
+// MarkSavedSearchAlerted records when a saved search was last alerted
+func (r *JobAlertRepository) MarkSavedSearchAlerted(ctx context.Context, searchID int, alertedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE saved_job_searches SET last_alerted_at = $1 WHERE id = $2`, alertedAt, searchID); err != nil {
+		return fmt.Errorf("failed to mark saved job search alerted: %w", err)
+	}
+	return nil
+}
+
+func (r *JobAlertRepository) listSavedSearches(ctx context.Context, query string, args ...interface{}) ([]*models.SavedJobSearch, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved job searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []*models.SavedJobSearch{}
+	for rows.Next() {
+		search, err := scanSavedJobSearch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved job search: %w", err)
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved job searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// SaveJob bookmarks a job for the user; saving it again is a no-op
+func (r *JobAlertRepository) SaveJob(ctx context.Context, userID, jobID int) error {
+	query := `
+        INSERT INTO saved_jobs (user_id, job_id)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id, job_id) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, jobID); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+// UnsaveJob removes a bookmark; removing a missing one is a no-op
+func (r *JobAlertRepository) UnsaveJob(ctx context.Context, userID, jobID int) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM saved_jobs WHERE user_id = $1 AND job_id = $2`, userID, jobID); err != nil {
+		return fmt.Errorf("failed to unsave job: %w", err)
+	}
+	return nil
+}
+
+// ListSavedJobs returns the user's bookmarked jobs, most recently saved first
+func (r *JobAlertRepository) ListSavedJobs(ctx context.Context, userID int) ([]*models.SavedJob, error) {
+	query := `
+        SELECT ` + jobColumns + `, sj.created_at
+        FROM saved_jobs sj
+        JOIN jobs j ON j.id = sj.job_id
+        WHERE sj.user_id = $1
+        ORDER BY sj.created_at DESC, j.id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved jobs: %w", err)
+	}
+	defer rows.Close()
+
+	saved := []*models.SavedJob{}
+	for rows.Next() {
+		item := &models.SavedJob{}
+		job, err := scanJob(rows, &item.SavedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved job: %w", err)
+		}
+		item.Job = job
+		saved = append(saved, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved jobs: %w", err)
+	}
+
+	return saved, nil
+}
+
+func scanSavedJobSearch(row interface{ Scan(...interface{}) error }) (*models.SavedJobSearch, error) {
+	search := &models.SavedJobSearch{}
+	err := row.Scan(
+		&search.ID,
+		&search.UserID,
+		&search.Name,
+		&search.Text,
+		&search.Location,
+		&search.JobType,
+		&search.ExperienceLevel,
+		&search.RemotePolicy,
+		&search.Frequency,
+		&search.LastAlertedAt,
+		&search.CreatedAt,
+		&search.UpdatedAt,
+	)
+	return search, err
+}
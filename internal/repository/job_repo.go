@@ -0,0 +1,842 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrJobNotFound            = errors.New("job not found")
+	ErrJobApplicationNotFound = errors.New("job application not found")
+	ErrJobApplicationExists   = errors.New("you have already applied for this job")
+	ErrJobApplicationChanged  = errors.New("job application was changed by someone else")
+	ErrJobReferralNotFound    = errors.New("job referral not found")
+	ErrJobReferralExists      = errors.New("this mentee has already been referred to this job")
+	ErrJobExternalIDTaken     = errors.New("a job with this external_id was created by another import in the meantime")
+	ErrJobClosed              = errors.New("job was closed or deleted in the meantime")
+)
+
+const jobColumns = `
+        j.id, j.title, j.company, COALESCE(j.location, ''), j.description,
+        COALESCE(j.requirements, ''), COALESCE(j.salary_range, ''), j.job_type,
+        j.experience_level, j.remote_policy, COALESCE(j.contact_email, ''),
+        j.status, j.is_featured, COALESCE(j.created_by, 0), j.expires_at, j.renewal_count,
+        COALESCE(j.moderated_by, 0), j.moderated_at, j.moderation_note, COALESCE(j.external_id, ''),
+        j.created_at, j.updated_at`
+
+// jobPublishedAt is when a posting went on the board: its approval, or its
+// creation for postings from before moderation
+const jobPublishedAt = `COALESCE(j.moderated_at, j.created_at)`
+
+const jobApplicationColumns = `
+        a.id, a.job_id, j.title, j.company, a.user_id,
+        TRIM(COALESCE(p.first_name, '') || ' ' || COALESCE(p.last_name, '')),
+        a.status, a.cover_letter, a.resume_url, a.resume_key, a.resume_file_name,
+        a.resume_content_type, a.resume_size_bytes, COALESCE(a.referrer_id, 0),
+        TRIM(COALESCE(rp.first_name, '') || ' ' || COALESCE(rp.last_name, '')),
+        a.applied_at, a.updated_at`
+
+const jobApplicationJoins = `
+        FROM job_applications a
+        JOIN jobs j ON j.id = a.job_id
+        LEFT JOIN profiles p ON p.user_id = a.user_id
+        LEFT JOIN profiles rp ON rp.user_id = a.referrer_id`
+
+// jobReferralColumns derive a referral's status from the latest application
+// the referred mentee made for the job while credited to the mentor
+const jobReferralColumns = `
+        r.id, r.job_id, j.title, j.company, r.mentor_id,
+        TRIM(COALESCE(mp.first_name, '') || ' ' || COALESCE(mp.last_name, '')),
+        r.mentee_id,
+        TRIM(COALESCE(ep.first_name, '') || ' ' || COALESCE(ep.last_name, '')),
+        r.note, COALESCE(a.status, 'referred'), COALESCE(a.id, 0), a.applied_at,
+        r.created_at, COALESCE(a.updated_at, r.created_at)`
+
+const jobReferralJoins = `
+        FROM job_referrals r
+        JOIN jobs j ON j.id = r.job_id
+        LEFT JOIN profiles mp ON mp.user_id = r.mentor_id
+        LEFT JOIN profiles ep ON ep.user_id = r.mentee_id
+        LEFT JOIN LATERAL (
+            SELECT la.id, la.status, la.applied_at, la.updated_at
+            FROM job_applications la
+            WHERE la.job_id = r.job_id AND la.user_id = r.mentee_id AND la.referrer_id = r.mentor_id
+            ORDER BY la.applied_at DESC, la.id DESC
+            LIMIT 1
+        ) a ON true`
+
+type JobRepository struct {
+	db *sql.DB
+}
+
+func NewJobRepository(db *sql.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+const insertJobQuery = `
+        INSERT INTO jobs (
+            title, company, location, description, requirements, salary_range,
+            job_type, experience_level, remote_policy, contact_email,
+            status, is_featured, created_by, expires_at, moderated_by, moderated_at, external_id
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, 0), $14, NULLIF($15, 0), $16, NULLIF($17, ''))
+        RETURNING id, created_at, updated_at`
+
+func insertJobArgs(job *models.Job) []interface{} {
+	return []interface{}{
+		job.Title, job.Company, job.Location, job.Description,
+		job.Requirements, job.SalaryRange, job.JobType, job.ExperienceLevel,
+		job.RemotePolicy, job.ContactEmail, job.Status,
+		job.IsFeatured, job.CreatedBy, job.ExpiresAt,
+		job.ModeratedBy, job.ModeratedAt, job.ExternalID,
+	}
+}
+
+// CreateJob stores a new job posting
+func (r *JobRepository) CreateJob(ctx context.Context, job *models.Job) error {
+	err := r.db.QueryRowContext(ctx, insertJobQuery, insertJobArgs(job)...).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob returns a job posting whatever its status
+func (r *JobRepository) GetJob(ctx context.Context, jobID int) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs j WHERE j.id = $1`
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, jobID))
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
+func jobSearchFilter(query *models.JobSearchQuery) (string, []interface{}) {
+	args := []interface{}{models.JobStatus.Active}
+	clause := `
+        FROM jobs j
+        WHERE j.status = $1 AND (j.expires_at IS NULL OR j.expires_at > CURRENT_TIMESTAMP)`
+
+	if query.Text != "" {
+		args = append(args, query.Text)
+		clause += fmt.Sprintf(` AND j.search_vector @@ websearch_to_tsquery('english', $%d)`, len(args))
+	}
+	if query.Location != "" {
+		args = append(args, query.Location)
+		clause += fmt.Sprintf(` AND j.location ILIKE '%%' || $%d || '%%'`, len(args))
+	}
+	if query.JobType != "" {
+		args = append(args, query.JobType)
+		clause += fmt.Sprintf(` AND j.job_type = $%d`, len(args))
+	}
+	if query.ExperienceLevel != "" {
+		args = append(args, query.ExperienceLevel)
+		clause += fmt.Sprintf(` AND j.experience_level = $%d`, len(args))
+	}
+	if query.RemotePolicy != "" {
+		args = append(args, query.RemotePolicy)
+		clause += fmt.Sprintf(` AND j.remote_policy = $%d`, len(args))
+	}
+	if query.PublishedAfter != nil {
+		args = append(args, *query.PublishedAfter)
+		clause += fmt.Sprintf(` AND `+jobPublishedAt+` > $%d`, len(args))
+	}
+	if query.ExcludeAppliedBy != 0 {
+		args = append(args, query.ExcludeAppliedBy)
+		clause += fmt.Sprintf(` AND j.created_by IS DISTINCT FROM $%[1]d AND NOT EXISTS (
+            SELECT 1 FROM job_applications a
+            WHERE a.job_id = j.id AND a.user_id = $%[1]d AND a.status <> 'withdrawn')`, len(args))
+	}
+
+	return clause, args
+}
+
+// SearchJobs returns one page of active jobs matching the query. Featured jobs
+// come first, then the best keyword matches, then the newest, unless the
+// query asks for the most recently published first.
+func (r *JobRepository) SearchJobs(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error) {
+	filter, args := jobSearchFilter(query)
+
+	// The keywords are the filter's first argument after the status
+	rank := `0::float4`
+	if query.Text != "" {
+		rank = `ts_rank_cd(j.search_vector, websearch_to_tsquery('english', $2))`
+	}
+	order := `j.is_featured DESC, ` + rank + ` DESC, j.created_at DESC, j.id DESC`
+	if query.NewestFirst {
+		order = jobPublishedAt + ` DESC, j.id DESC`
+	}
+
+	pageArgs := append(append([]interface{}{}, args...), query.PageSize, (query.Page-1)*query.PageSize)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        SELECT %s, COUNT(*) OVER ()
+        %s
+        ORDER BY %s
+        LIMIT $%d OFFSET $%d`, jobColumns, filter, order, len(args)+1, len(args)+2), pageArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search jobs: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.JobSearchResult{
+		Jobs:     []*models.Job{},
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	for rows.Next() {
+		job, err := scanJob(rows, &result.Total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+
+	// A page past the end has no rows to carry the total
+	if len(result.Jobs) == 0 && query.Page > 1 {
+		if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+filter, args...).Scan(&result.Total); err != nil {
+			return nil, fmt.Errorf("failed to count jobs: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateJob saves a job posting's details, status, featured flag, expiry and
+// moderation, recording revision in the same transaction when it is not nil
+func (r *JobRepository) UpdateJob(ctx context.Context, job *models.Job, revision *models.JobRevision) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateJob(ctx, tx, job, revision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// importJobQuery is insertJobQuery for imports: an external ID that another
+// import has created since the jobs were read is skipped rather than failing
+// the whole transaction
+const importJobQuery = `
+        INSERT INTO jobs (
+            title, company, location, description, requirements, salary_range,
+            job_type, experience_level, remote_policy, contact_email,
+            status, is_featured, created_by, expires_at, moderated_by, moderated_at, external_id
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, 0), $14, NULLIF($15, 0), $16, NULLIF($17, ''))
+        ON CONFLICT (external_id) WHERE external_id IS NOT NULL DO NOTHING
+        RETURNING id, created_at, updated_at`
+
+// ImportJobs creates and updates the jobs of a bulk import in one
+// transaction. revisions[i] records the changes made by updates[i]. Updates
+// only write the imported content columns, so a renewal, moderation or edit
+// of anything else since the jobs were read is kept. Jobs that can no longer
+// be written, because another import created their external ID or they were
+// closed in the meantime, are left out and returned with the reason
+// (ErrJobExternalIDTaken, ErrJobClosed); everything else is committed.
+func (r *JobRepository) ImportJobs(ctx context.Context, creates, updates []*models.Job, revisions []*models.JobRevision) (map[*models.Job]error, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	skipped := make(map[*models.Job]error)
+	for _, job := range creates {
+		err := tx.QueryRowContext(ctx, importJobQuery, insertJobArgs(job)...).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+		if err == sql.ErrNoRows {
+			skipped[job] = ErrJobExternalIDTaken
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to import job %s: %w", job.ExternalID, err)
+		}
+	}
+	for i, job := range updates {
+		err := tx.QueryRowContext(ctx, `
+            UPDATE jobs
+            SET title = $1, company = $2, location = $3,
+                description = $4, requirements = $5, salary_range = $6,
+                job_type = $7, experience_level = $8,
+                remote_policy = $9, contact_email = $10
+            WHERE id = $11 AND status <> $12
+            RETURNING updated_at`,
+			job.Title, job.Company, job.Location,
+			job.Description, job.Requirements, job.SalaryRange,
+			job.JobType, job.ExperienceLevel,
+			job.RemotePolicy, job.ContactEmail,
+			job.ID, models.JobStatus.Closed,
+		).Scan(&job.UpdatedAt)
+		if err == sql.ErrNoRows {
+			skipped[job] = ErrJobClosed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to import job %s: %w", job.ExternalID, err)
+		}
+		if err := insertJobRevision(ctx, tx, job.ID, revisions[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return skipped, nil
+}
+
+func updateJob(ctx context.Context, tx *sql.Tx, job *models.Job, revision *models.JobRevision) error {
+	query := `
+        UPDATE jobs
+        SET title = $1, company = $2, location = $3,
+            description = $4, requirements = $5, salary_range = $6,
+            job_type = $7, experience_level = $8,
+            remote_policy = $9, contact_email = $10,
+            status = $11, is_featured = $12, expires_at = $13,
+            renewal_count = $14, moderated_by = NULLIF($15, 0),
+            moderated_at = $16, moderation_note = $17,
+            external_id = NULLIF($18, '')
+        WHERE id = $19
+        RETURNING updated_at`
+
+	err := tx.QueryRowContext(ctx, query,
+		job.Title, job.Company, job.Location,
+		job.Description, job.Requirements, job.SalaryRange,
+		job.JobType, job.ExperienceLevel,
+		job.RemotePolicy, job.ContactEmail,
+		job.Status, job.IsFeatured, job.ExpiresAt,
+		job.RenewalCount, job.ModeratedBy,
+		job.ModeratedAt, job.ModerationNote, job.ExternalID, job.ID,
+	).Scan(&job.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrJobNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	return insertJobRevision(ctx, tx, job.ID, revision)
+}
+
+// insertJobRevision records the changes an edit made to a job, if any
+func insertJobRevision(ctx context.Context, tx *sql.Tx, jobID int, revision *models.JobRevision) error {
+	if revision == nil {
+		return nil
+	}
+
+	changes, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode job changes: %w", err)
+	}
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO job_revisions (job_id, editor_id, changes)
+        VALUES ($1, NULLIF($2, 0), $3)
+        RETURNING id, created_at`,
+		jobID, revision.EditorID, changes,
+	).Scan(&revision.ID, &revision.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record job revision: %w", err)
+	}
+	revision.JobID = jobID
+
+	return nil
+}
+
+// ListJobs returns postings in any status, newest first, optionally limited to
+// one status and to the postings of one user
+func (r *JobRepository) ListJobs(ctx context.Context, status string, createdBy int) ([]*models.Job, error) {
+	query := `SELECT ` + jobColumns + `
+        FROM jobs j
+        WHERE ($1 = '' OR j.status = $1) AND ($2 = 0 OR j.created_by = $2)
+        ORDER BY j.created_at DESC, j.id DESC`
+
+	return r.listJobs(ctx, query, status, createdBy)
+}
+
+// ExpireJobs moves active postings whose expiry has passed to expired and
+// returns them
+func (r *JobRepository) ExpireJobs(ctx context.Context, now time.Time) ([]*models.Job, error) {
+	query := `
+        UPDATE jobs j SET status = $1
+        WHERE j.status = $2 AND j.expires_at <= $3
+        RETURNING ` + jobColumns
+
+	return r.listJobs(ctx, query, models.JobStatus.Expired, models.JobStatus.Active, now)
+}
+
+// GetJobsByExternalIDs returns the jobs imported with any of externalIDs,
+// whatever their status
+func (r *JobRepository) GetJobsByExternalIDs(ctx context.Context, externalIDs []string) ([]*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs j WHERE j.external_id = ANY($1)`
+
+	return r.listJobs(ctx, query, pq.Array(externalIDs))
+}
+
+func (r *JobRepository) listJobs(ctx context.Context, query string, args ...interface{}) ([]*models.Job, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// ListJobRevisions returns a posting's edit history, oldest first
+func (r *JobRepository) ListJobRevisions(ctx context.Context, jobID int) ([]*models.JobRevision, error) {
+	query := `
+        SELECT id, job_id, COALESCE(editor_id, 0), changes, created_at
+        FROM job_revisions
+        WHERE job_id = $1
+        ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*models.JobRevision{}
+	for rows.Next() {
+		revision := &models.JobRevision{}
+		var changes []byte
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.JobID,
+			&revision.EditorID,
+			&changes,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan job revision: %w", err)
+		}
+		if err := json.Unmarshal(changes, &revision.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode job changes: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// DeleteJob closes a job posting; applications keep referring to it
+func (r *JobRepository) DeleteJob(ctx context.Context, jobID int) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE jobs SET status = $1 WHERE id = $2`, models.JobStatus.Closed, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to close job: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// CreateApplication stores a new application, crediting the mentor who
+// referred the applicant to the job, if any. An applicant with a live
+// application for the job gets ErrJobApplicationExists.
+func (r *JobRepository) CreateApplication(ctx context.Context, app *models.JobApplication) error {
+	query := `
+        INSERT INTO job_applications (
+            job_id, user_id, status, cover_letter, resume_url, resume_key,
+            resume_file_name, resume_content_type, resume_size_bytes, referrer_id
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9,
+            (SELECT mentor_id FROM job_referrals WHERE job_id = $1 AND mentee_id = $2))
+        ON CONFLICT (job_id, user_id) WHERE status <> 'withdrawn' DO NOTHING
+        RETURNING id, COALESCE(referrer_id, 0), applied_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		app.JobID,
+		app.UserID,
+		app.Status,
+		app.CoverLetter,
+		app.ResumeURL,
+		app.ResumeKey,
+		app.ResumeFileName,
+		app.ResumeContentType,
+		app.ResumeSizeBytes,
+	).Scan(&app.ID, &app.ReferrerID, &app.AppliedAt, &app.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrJobApplicationExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create job application: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplication returns an application with its job title and applicant name
+func (r *JobRepository) GetApplication(ctx context.Context, applicationID int) (*models.JobApplication, error) {
+	query := `SELECT ` + jobApplicationColumns + jobApplicationJoins + ` WHERE a.id = $1`
+
+	app, err := scanJobApplication(r.db.QueryRowContext(ctx, query, applicationID))
+	if err == sql.ErrNoRows {
+		return nil, ErrJobApplicationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job application: %w", err)
+	}
+
+	return app, nil
+}
+
+// ListJobApplications returns a job's applications, optionally in one status, oldest first
+func (r *JobRepository) ListJobApplications(ctx context.Context, jobID int, status string) ([]*models.JobApplication, error) {
+	query := `SELECT ` + jobApplicationColumns + jobApplicationJoins + `
+        WHERE a.job_id = $1 AND ($2 = '' OR a.status = $2)
+        ORDER BY a.applied_at, a.id`
+
+	return r.listJobApplications(ctx, query, jobID, status)
+}
+
+// ListUserApplications returns an applicant's applications, newest first
+func (r *JobRepository) ListUserApplications(ctx context.Context, userID int) ([]*models.JobApplication, error) {
+	query := `SELECT ` + jobApplicationColumns + jobApplicationJoins + `
+        WHERE a.user_id = $1
+        ORDER BY a.applied_at DESC, a.id DESC`
+
+	return r.listJobApplications(ctx, query, userID)
+}
+
+func (r *JobRepository) listJobApplications(ctx context.Context, query string, args ...interface{}) ([]*models.JobApplication, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job applications: %w", err)
+	}
+	defer rows.Close()
+
+	applications := []*models.JobApplication{}
+	for rows.Next() {
+		app, err := scanJobApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job application: %w", err)
+		}
+		applications = append(applications, app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job applications: %w", err)
+	}
+
+	return applications, nil
+}
+
+// UpdateApplicationStatus moves an application from event.FromStatus to
+// event.ToStatus and records the event. If the application is no longer in
+// FromStatus nothing changes and ErrJobApplicationChanged is returned.
+func (r *JobRepository) UpdateApplicationStatus(ctx context.Context, event *models.JobApplicationEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE job_applications SET status = $1
+        WHERE id = $2 AND status = $3`,
+		event.ToStatus, event.ApplicationID, event.FromStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update job application status: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrJobApplicationChanged
+	}
+
+	if err := insertJobApplicationEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// AddApplicationEvent records a note on an application at its current status
+func (r *JobRepository) AddApplicationEvent(ctx context.Context, event *models.JobApplicationEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM job_applications WHERE id = $1 FOR UPDATE`, event.ApplicationID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrJobApplicationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock job application: %w", err)
+	}
+
+	event.FromStatus = status
+	event.ToStatus = status
+	if err := insertJobApplicationEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertJobApplicationEvent(ctx context.Context, tx *sql.Tx, event *models.JobApplicationEvent) error {
+	query := `
+        INSERT INTO job_application_events (application_id, author_id, from_status, to_status, note)
+        VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+        RETURNING id, created_at`
+
+	err := tx.QueryRowContext(ctx, query,
+		event.ApplicationID,
+		event.AuthorID,
+		event.FromStatus,
+		event.ToStatus,
+		event.Note,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record job application event: %w", err)
+	}
+
+	return nil
+}
+
+// ListApplicationEvents returns an application's history, oldest first
+func (r *JobRepository) ListApplicationEvents(ctx context.Context, applicationID int) ([]*models.JobApplicationEvent, error) {
+	query := `
+        SELECT id, application_id, COALESCE(author_id, 0), from_status, to_status, note, created_at
+        FROM job_application_events
+        WHERE application_id = $1
+        ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job application events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.JobApplicationEvent{}
+	for rows.Next() {
+		event := &models.JobApplicationEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.ApplicationID,
+			&event.AuthorID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.Note,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan job application event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job application events: %w", err)
+	}
+
+	return events, nil
+}
+
+// CreateReferral stores a referral. A mentee already referred to the job,
+// by any mentor, gets ErrJobReferralExists.
+func (r *JobRepository) CreateReferral(ctx context.Context, referral *models.JobReferral) error {
+	query := `
+        INSERT INTO job_referrals (job_id, mentor_id, mentee_id, note)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (job_id, mentee_id) DO NOTHING
+        RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		referral.JobID,
+		referral.MentorID,
+		referral.MenteeID,
+		referral.Note,
+	).Scan(&referral.ID, &referral.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrJobReferralExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create job referral: %w", err)
+	}
+
+	return nil
+}
+
+// GetReferral returns a referral with its job, the people involved and its status
+func (r *JobRepository) GetReferral(ctx context.Context, referralID int) (*models.JobReferral, error) {
+	query := `SELECT ` + jobReferralColumns + jobReferralJoins + ` WHERE r.id = $1`
+
+	referral, err := scanJobReferral(r.db.QueryRowContext(ctx, query, referralID))
+	if err == sql.ErrNoRows {
+		return nil, ErrJobReferralNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job referral: %w", err)
+	}
+
+	return referral, nil
+}
+
+// ListMentorReferrals returns the referrals a mentor made, newest first
+func (r *JobRepository) ListMentorReferrals(ctx context.Context, mentorID int) ([]*models.JobReferral, error) {
+	query := `SELECT ` + jobReferralColumns + jobReferralJoins + `
+        WHERE r.mentor_id = $1
+        ORDER BY r.created_at DESC, r.id DESC`
+
+	return r.listJobReferrals(ctx, query, mentorID)
+}
+
+// ListMenteeReferrals returns the referrals a mentee received, newest first
+func (r *JobRepository) ListMenteeReferrals(ctx context.Context, menteeID int) ([]*models.JobReferral, error) {
+	query := `SELECT ` + jobReferralColumns + jobReferralJoins + `
+        WHERE r.mentee_id = $1
+        ORDER BY r.created_at DESC, r.id DESC`
+
+	return r.listJobReferrals(ctx, query, menteeID)
+}
+
+func (r *JobRepository) listJobReferrals(ctx context.Context, query string, args ...interface{}) ([]*models.JobReferral, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job referrals: %w", err)
+	}
+	defer rows.Close()
+
+	referrals := []*models.JobReferral{}
+	for rows.Next() {
+		referral, err := scanJobReferral(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job referral: %w", err)
+		}
+		referrals = append(referrals, referral)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job referrals: %w", err)
+	}
+
+	return referrals, nil
+}
+
+func scanJob(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*models.Job, error) {
+	job := &models.Job{}
+	dest := []interface{}{
+		&job.ID,
+		&job.Title,
+		&job.Company,
+		&job.Location,
+		&job.Description,
+		&job.Requirements,
+		&job.SalaryRange,
+		&job.JobType,
+		&job.ExperienceLevel,
+		&job.RemotePolicy,
+		&job.ContactEmail,
+		&job.Status,
+		&job.IsFeatured,
+		&job.CreatedBy,
+		&job.ExpiresAt,
+		&job.RenewalCount,
+		&job.ModeratedBy,
+		&job.ModeratedAt,
+		&job.ModerationNote,
+		&job.ExternalID,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	return job, err
+}
+
+func scanJobApplication(row interface{ Scan(...interface{}) error }) (*models.JobApplication, error) {
+	app := &models.JobApplication{}
+	err := row.Scan(
+		&app.ID,
+		&app.JobID,
+		&app.JobTitle,
+		&app.Company,
+		&app.UserID,
+		&app.ApplicantName,
+		&app.Status,
+		&app.CoverLetter,
+		&app.ResumeURL,
+		&app.ResumeKey,
+		&app.ResumeFileName,
+		&app.ResumeContentType,
+		&app.ResumeSizeBytes,
+		&app.ReferrerID,
+		&app.ReferrerName,
+		&app.AppliedAt,
+		&app.UpdatedAt,
+	)
+	return app, err
+}
+
+func scanJobReferral(row interface{ Scan(...interface{}) error }) (*models.JobReferral, error) {
+	referral := &models.JobReferral{}
+	err := row.Scan(
+		&referral.ID,
+		&referral.JobID,
+		&referral.JobTitle,
+		&referral.Company,
+		&referral.MentorID,
+		&referral.MentorName,
+		&referral.MenteeID,
+		&referral.MenteeName,
+		&referral.Note,
+		&referral.Status,
+		&referral.ApplicationID,
+		&referral.AppliedAt,
+		&referral.CreatedAt,
+		&referral.UpdatedAt,
+	)
+	return referral, err
+}
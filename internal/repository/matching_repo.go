@@ -0,0 +1,533 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrMatchingRoundNotFound   = errors.New("matching round not found")
+	ErrMatchingRoundClosed     = errors.New("matching round is closed to changes")
+	ErrMatchingRoundNotMatched = errors.New("matching round has not been matched yet")
+	ErrMatchingPairNotFound    = errors.New("matching pair not found")
+)
+
+const matchingRoundColumns = `
+        r.id, r.name, r.description, r.status, r.created_by,
+        (SELECT COUNT(*) FROM matching_round_mentors m WHERE m.round_id = r.id),
+        (SELECT COUNT(*) FROM matching_round_mentees m WHERE m.round_id = r.id),
+        r.matched_at, r.published_at, r.created_at, r.updated_at`
+
+// participantName is a user's display name from their profile
+const participantName = `TRIM(COALESCE(p.first_name, '') || ' ' || COALESCE(p.last_name, ''))`
+
+type MatchingRepository struct {
+	db *sql.DB
+}
+
+func NewMatchingRepository(db *sql.DB) *MatchingRepository {
+	return &MatchingRepository{
+		db: db,
+	}
+}
+
+// CreateRound opens a matching round
+func (r *MatchingRepository) CreateRound(ctx context.Context, round *models.MatchingRound) error {
+	query := `
+        INSERT INTO matching_rounds (name, description, status, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		round.Name,
+		round.Description,
+		round.Status,
+		round.CreatedBy,
+	).Scan(&round.ID, &round.CreatedAt, &round.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create matching round: %w", err)
+	}
+
+	return nil
+}
+
+// GetRound returns a matching round with its participant counts
+func (r *MatchingRepository) GetRound(ctx context.Context, roundID int) (*models.MatchingRound, error) {
+	query := `SELECT ` + matchingRoundColumns + ` FROM matching_rounds r WHERE r.id = $1`
+
+	round, err := scanMatchingRound(r.db.QueryRowContext(ctx, query, roundID))
+	if err == sql.ErrNoRows {
+		return nil, ErrMatchingRoundNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matching round: %w", err)
+	}
+
+	return round, nil
+}
+
+// ListRounds returns matching rounds, newest first, optionally only those in a status
+func (r *MatchingRepository) ListRounds(ctx context.Context, status string) ([]*models.MatchingRound, error) {
+	query := `
+        SELECT ` + matchingRoundColumns + `
+        FROM matching_rounds r
+        WHERE $1 = '' OR r.status = $1
+        ORDER BY r.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching rounds: %w", err)
+	}
+	defer rows.Close()
+
+	rounds := []*models.MatchingRound{}
+	for rows.Next() {
+		round, err := scanMatchingRound(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan matching round: %w", err)
+		}
+		rounds = append(rounds, round)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matching rounds: %w", err)
+	}
+
+	return rounds, nil
+}
+
+// SaveMentor adds a mentor to an open round or updates their entry
+func (r *MatchingRepository) SaveMentor(ctx context.Context, mentor *models.MatchingMentor) error {
+	query := `
+        INSERT INTO matching_round_mentors (round_id, mentor_id, program_id, capacity, team, preferences)
+        SELECT $1, $2, $3, $4, $5, $6
+        WHERE EXISTS (SELECT 1 FROM matching_rounds WHERE id = $1 AND status = 'open')
+        ON CONFLICT (round_id, mentor_id) DO UPDATE SET
+            program_id = EXCLUDED.program_id,
+            capacity = EXCLUDED.capacity,
+            team = EXCLUDED.team,
+            preferences = EXCLUDED.preferences,
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		mentor.RoundID,
+		mentor.MentorID,
+		mentor.ProgramID,
+		mentor.Capacity,
+		mentor.Team,
+		pq.Array(mentor.Preferences),
+	).Scan(&mentor.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrMatchingRoundClosed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save matching mentor: %w", err)
+	}
+
+	return nil
+}
+
+// SaveMentee adds a mentee to an open round or updates their preferences
+func (r *MatchingRepository) SaveMentee(ctx context.Context, mentee *models.MatchingMentee) error {
+	query := `
+        INSERT INTO matching_round_mentees (round_id, mentee_id, team, preferences)
+        SELECT $1, $2, $3, $4
+        WHERE EXISTS (SELECT 1 FROM matching_rounds WHERE id = $1 AND status = 'open')
+        ON CONFLICT (round_id, mentee_id) DO UPDATE SET
+            team = EXCLUDED.team,
+            preferences = EXCLUDED.preferences,
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		mentee.RoundID,
+		mentee.MenteeID,
+		mentee.Team,
+		pq.Array(mentee.Preferences),
+	).Scan(&mentee.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrMatchingRoundClosed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save matching mentee: %w", err)
+	}
+
+	return nil
+}
+
+// ListMentors returns the mentors in a round, in the order they joined
+func (r *MatchingRepository) ListMentors(ctx context.Context, roundID int) ([]*models.MatchingMentor, error) {
+	query := `
+        SELECT m.round_id, m.mentor_id, ` + participantName + `, m.program_id, m.capacity, m.team,
+               m.preferences, m.updated_at
+        FROM matching_round_mentors m
+        LEFT JOIN profiles p ON p.user_id = m.mentor_id
+        WHERE m.round_id = $1
+        ORDER BY m.mentor_id`
+
+	rows, err := r.db.QueryContext(ctx, query, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching mentors: %w", err)
+	}
+	defer rows.Close()
+
+	mentors := []*models.MatchingMentor{}
+	for rows.Next() {
+		mentor := &models.MatchingMentor{}
+		var preferences pq.Int64Array
+		if err := rows.Scan(
+			&mentor.RoundID,
+			&mentor.MentorID,
+			&mentor.Name,
+			&mentor.ProgramID,
+			&mentor.Capacity,
+			&mentor.Team,
+			&preferences,
+			&mentor.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan matching mentor: %w", err)
+		}
+		mentor.Preferences = preferences
+		mentors = append(mentors, mentor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matching mentors: %w", err)
+	}
+
+	return mentors, nil
+}
+
+// ListMentees returns the mentees in a round, earliest submission first
+func (r *MatchingRepository) ListMentees(ctx context.Context, roundID int) ([]*models.MatchingMentee, error) {
+	query := `
+        SELECT m.round_id, m.mentee_id, ` + participantName + `, m.team, m.preferences, m.updated_at
+        FROM matching_round_mentees m
+        LEFT JOIN profiles p ON p.user_id = m.mentee_id
+        WHERE m.round_id = $1
+        ORDER BY m.updated_at, m.mentee_id`
+
+	rows, err := r.db.QueryContext(ctx, query, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching mentees: %w", err)
+	}
+	defer rows.Close()
+
+	mentees := []*models.MatchingMentee{}
+	for rows.Next() {
+		mentee := &models.MatchingMentee{}
+		var preferences pq.Int64Array
+		if err := rows.Scan(
+			&mentee.RoundID,
+			&mentee.MenteeID,
+			&mentee.Name,
+			&mentee.Team,
+			&preferences,
+			&mentee.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan matching mentee: %w", err)
+		}
+		mentee.Preferences = preferences
+		mentees = append(mentees, mentee)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matching mentees: %w", err)
+	}
+
+	return mentees, nil
+}
+
+// ListPairs returns the proposed pairs of a round
+func (r *MatchingRepository) ListPairs(ctx context.Context, roundID int) ([]*models.MatchingPair, error) {
+	query := `
+        SELECT mp.id, mp.round_id, mp.mentee_id,
+               TRIM(COALESCE(pe.first_name, '') || ' ' || COALESCE(pe.last_name, '')),
+               mp.mentor_id,
+               TRIM(COALESCE(pm.first_name, '') || ' ' || COALESCE(pm.last_name, '')),
+               COALESCE(array_position(me.preferences, mp.mentor_id), 0),
+               mp.source, mp.request_id, mp.created_at
+        FROM matching_pairs mp
+        LEFT JOIN matching_round_mentees me ON me.round_id = mp.round_id AND me.mentee_id = mp.mentee_id
+        LEFT JOIN profiles pe ON pe.user_id = mp.mentee_id
+        LEFT JOIN profiles pm ON pm.user_id = mp.mentor_id
+        WHERE mp.round_id = $1
+        ORDER BY mp.mentor_id, mp.mentee_id`
+
+	rows, err := r.db.QueryContext(ctx, query, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching pairs: %w", err)
+	}
+	defer rows.Close()
+
+	pairs := []*models.MatchingPair{}
+	for rows.Next() {
+		pair := &models.MatchingPair{}
+		var requestID sql.NullInt64
+		if err := rows.Scan(
+			&pair.ID,
+			&pair.RoundID,
+			&pair.MenteeID,
+			&pair.MenteeName,
+			&pair.MentorID,
+			&pair.MentorName,
+			&pair.Rank,
+			&pair.Source,
+			&requestID,
+			&pair.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan matching pair: %w", err)
+		}
+		if requestID.Valid {
+			id := int(requestID.Int64)
+			pair.RequestID = &id
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matching pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// ReplacePairs stores the matcher's proposals for a round, discarding any
+// earlier proposals and admin changes, and marks the round matched
+func (r *MatchingRepository) ReplacePairs(ctx context.Context, roundID int, pairs []*models.MatchingPair) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockMatchingRound(ctx, tx, roundID)
+	if err != nil {
+		return err
+	}
+	if status == models.MatchingRoundStatus.Published {
+		return ErrMatchingRoundClosed
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM matching_pairs WHERE round_id = $1`, roundID); err != nil {
+		return fmt.Errorf("failed to clear matching pairs: %w", err)
+	}
+
+	for _, pair := range pairs {
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO matching_pairs (round_id, mentee_id, mentor_id, source)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id, created_at`,
+			roundID, pair.MenteeID, pair.MentorID, pair.Source,
+		).Scan(&pair.ID, &pair.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create matching pair: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE matching_rounds SET status = 'matched', matched_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, roundID)
+	if err != nil {
+		return fmt.Errorf("failed to mark matching round matched: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SavePair assigns a mentee to a mentor in a matched round, replacing any
+// proposal for that mentee
+func (r *MatchingRepository) SavePair(ctx context.Context, pair *models.MatchingPair) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := requireMatchedRound(ctx, tx, pair.RoundID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO matching_pairs (round_id, mentee_id, mentor_id, source)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (round_id, mentee_id) DO UPDATE SET
+            mentor_id = EXCLUDED.mentor_id,
+            source = EXCLUDED.source
+        RETURNING id, created_at`,
+		pair.RoundID, pair.MenteeID, pair.MentorID, pair.Source,
+	).Scan(&pair.ID, &pair.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save matching pair: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeletePair leaves a mentee unmatched in a matched round
+func (r *MatchingRepository) DeletePair(ctx context.Context, roundID, menteeID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := requireMatchedRound(ctx, tx, roundID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM matching_pairs WHERE round_id = $1 AND mentee_id = $2`, roundID, menteeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete matching pair: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrMatchingPairNotFound
+	}
+
+	return tx.Commit()
+}
+
+// PublishRound creates a pending mentorship request for every pair in a
+// matched round, against the program each mentor chose, and closes the round.
+// The requests still have to be approved. A round that would give a program
+// more mentees than it has room for gets ErrProgramFull and is left matched.
+func (r *MatchingRepository) PublishRound(ctx context.Context, roundID int, message string) ([]*models.MentorshipRequest, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := requireMatchedRound(ctx, tx, roundID); err != nil {
+		return nil, err
+	}
+
+	// Approved mentorships and those waiting on their agreement hold a place
+	var title string
+	var seated, pairs, maxMentees int
+	err = tx.QueryRowContext(ctx, `
+        SELECT p.title, p.max_mentees, COUNT(mp.id),
+               (SELECT COUNT(*) FROM mentorship_requests mr
+                WHERE mr.program_id = p.id AND mr.status IN ('approved', 'awaiting_agreement'))
+        FROM matching_pairs mp
+        JOIN matching_round_mentors m ON m.round_id = mp.round_id AND m.mentor_id = mp.mentor_id
+        JOIN mentorship_programs p ON p.id = m.program_id
+        WHERE mp.round_id = $1
+        GROUP BY p.id
+        HAVING COUNT(mp.id) + (SELECT COUNT(*) FROM mentorship_requests mr
+                               WHERE mr.program_id = p.id AND mr.status IN ('approved', 'awaiting_agreement')) > p.max_mentees
+        ORDER BY p.id
+        LIMIT 1`, roundID).Scan(&title, &maxMentees, &pairs, &seated)
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s has room for %d more mentees but %d were matched to it", ErrProgramFull, title, max(maxMentees-seated, 0), pairs)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to check program capacity: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT mp.id, mp.mentee_id, mp.mentor_id, m.program_id
+        FROM matching_pairs mp
+        JOIN matching_round_mentors m ON m.round_id = mp.round_id AND m.mentor_id = mp.mentor_id
+        WHERE mp.round_id = $1
+        ORDER BY mp.id`, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching pairs: %w", err)
+	}
+
+	var pairIDs []int
+	var requests []*models.MentorshipRequest
+	for rows.Next() {
+		var pairID int
+		request := &models.MentorshipRequest{
+			Status:  models.RequestStatus.Pending,
+			Message: message,
+		}
+		if err := rows.Scan(&pairID, &request.MenteeID, &request.MentorID, &request.ProgramID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan matching pair: %w", err)
+		}
+		pairIDs = append(pairIDs, pairID)
+		requests = append(requests, request)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matching pairs: %w", err)
+	}
+
+	for i, request := range requests {
+		if err := createRequest(ctx, tx, request); err != nil {
+			return nil, fmt.Errorf("failed to create mentorship request: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE matching_pairs SET request_id = $1 WHERE id = $2`, request.ID, pairIDs[i]); err != nil {
+			return nil, fmt.Errorf("failed to link matching pair: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE matching_rounds SET status = 'published', published_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish matching round: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return requests, nil
+}
+
+// lockMatchingRound locks a round for the rest of the transaction and returns its status
+func lockMatchingRound(ctx context.Context, tx *sql.Tx, roundID int) (string, error) {
+	var status string
+	err := tx.QueryRowContext(ctx, `SELECT status FROM matching_rounds WHERE id = $1 FOR UPDATE`, roundID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", ErrMatchingRoundNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to lock matching round: %w", err)
+	}
+	return status, nil
+}
+
+// requireMatchedRound locks a round and checks its proposals can still be changed
+func requireMatchedRound(ctx context.Context, tx *sql.Tx, roundID int) error {
+	status, err := lockMatchingRound(ctx, tx, roundID)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case models.MatchingRoundStatus.Matched:
+		return nil
+	case models.MatchingRoundStatus.Published:
+		return ErrMatchingRoundClosed
+	}
+	return ErrMatchingRoundNotMatched
+}
+
+func scanMatchingRound(row interface{ Scan(...interface{}) error }) (*models.MatchingRound, error) {
+	round := &models.MatchingRound{}
+	err := row.Scan(
+		&round.ID,
+		&round.Name,
+		&round.Description,
+		&round.Status,
+		&round.CreatedBy,
+		&round.MentorCount,
+		&round.MenteeCount,
+		&round.MatchedAt,
+		&round.PublishedAt,
+		&round.CreatedAt,
+		&round.UpdatedAt,
+	)
+	return round, err
+}
@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"mentorApp/internal/models"
+	"time"
 )
 
 var (
 	ErrProgramNotFound = errors.New("mentorship program not found")
+	ErrRequestNotFound = errors.New("mentorship request not found")
 	ErrProgramFull     = errors.New("mentorship program is full")
 	ErrInvalidStatus   = errors.New("invalid status transition")
+	ErrProgramInUse    = errors.New("program has mentorship requests and can only be archived")
 )
 
 type MentorshipRepository struct {
@@ -112,8 +115,18 @@ func (r *MentorshipRepository) CreateRequest(ctx context.Context, request *model
 	}
 	defer tx.Rollback()
 
+	if err := createRequest(ctx, tx, request); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// createRequest stores a mentorship request within tx, for callers that write
+// more alongside it
+func createRequest(ctx context.Context, tx *sql.Tx, request *models.MentorshipRequest) error {
 	var maxMentees int
-	err = tx.QueryRowContext(ctx, "SELECT max_mentees FROM mentorship_programs WHERE id = $1", request.ProgramID).Scan(&maxMentees)
+	err := tx.QueryRowContext(ctx, "SELECT max_mentees FROM mentorship_programs WHERE id = $1", request.ProgramID).Scan(&maxMentees)
 	if err == sql.ErrNoRows {
 		return ErrProgramNotFound
 	}
@@ -127,18 +140,13 @@ func (r *MentorshipRepository) CreateRequest(ctx context.Context, request *model
         VALUES ($1, $2, $3, $4, $5)
         RETURNING id, created_at, updated_at`
 
-	err = tx.QueryRowContext(ctx, query,
+	return tx.QueryRowContext(ctx, query,
 		request.MentorID,
 		request.MenteeID,
 		request.ProgramID,
 		request.Status,
 		request.Message,
 	).Scan(&request.ID, &request.CreatedAt, &request.UpdatedAt)
-	if err != nil {
-		return err
-	}
-
-	return tx.Commit()
 }
 
 // GetRequest retrieves a request by ID
@@ -235,36 +243,38 @@ func (r *MentorshipRepository) ListActivePrograms(ctx context.Context) ([]*model
 	return programs, nil
 }
 
-// CreateSessionFeedback creates a new feedback entry for a session
-func (r *MentorshipRepository) CreateSessionFeedback(ctx context.Context, feedback *models.SessionFeedback) error {
-	query := `
-        INSERT INTO session_feedback (session_id, user_id, rating, comment, created_at)
-        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
-        RETURNING id`
+// CreateSession creates a new mentorship session
+func (r *MentorshipRepository) CreateSession(ctx context.Context, session *models.MentorshipSession) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	return r.db.QueryRowContext(ctx, query,
-		feedback.SessionID,
-		feedback.UserID,
-		feedback.Rating,
-		feedback.Comment,
-	).Scan(&feedback.ID)
+	if err := createSession(ctx, tx, session); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// CreateSession creates a new mentorship session
-func (r *MentorshipRepository) CreateSession(ctx context.Context, session *models.MentorshipSession) error {
+// createSession stores a session within tx, for callers that write more alongside it
+func createSession(ctx context.Context, tx *sql.Tx, session *models.MentorshipSession) error {
 	query := `
-        INSERT INTO mentorship_sessions (request_id, title, start_time, end_time, status, notes)
-        VALUES ($1, $2, $3, $4, $5, $6)
-        RETURNING id, created_at, updated_at`
+        INSERT INTO mentorship_sessions (request_id, title, start_time, end_time, status, notes, meeting_provider, meeting_url)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, sequence, created_at, updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := tx.QueryRowContext(ctx, query,
 		session.RequestID,
 		session.Title,
 		session.StartTime,
 		session.EndTime,
 		session.Status,
 		session.Notes,
-	).Scan(&session.ID, &session.CreatedAt, &session.UpdatedAt)
+		session.MeetingProvider,
+		session.MeetingURL,
+	).Scan(&session.ID, &session.Sequence, &session.CreatedAt, &session.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
@@ -276,22 +286,14 @@ func (r *MentorshipRepository) CreateSession(ctx context.Context, session *model
 // GetSession retrieves a session by ID
 func (r *MentorshipRepository) GetSession(ctx context.Context, sessionID int) (*models.MentorshipSession, error) {
 	query := `
-        SELECT id, request_id, title, start_time, end_time, status, notes, created_at, updated_at
+        SELECT id, request_id, title, start_time, end_time, status, COALESCE(notes, ''),
+               COALESCE(meeting_provider, ''), COALESCE(meeting_url, ''), sequence, reminder_sent_at,
+               created_at, updated_at
         FROM mentorship_sessions
         WHERE id = $1`
 
 	session := &models.MentorshipSession{}
-	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
-		&session.ID,
-		&session.RequestID,
-		&session.Title,
-		&session.StartTime,
-		&session.EndTime,
-		&session.Status,
-		&session.Notes,
-		&session.CreatedAt,
-		&session.UpdatedAt,
-	)
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(sessionScanFields(session)...)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -330,7 +332,9 @@ func (r *MentorshipRepository) UpdateSessionStatus(ctx context.Context, sessionI
 // ListSessionsByRequest retrieves all sessions for a mentorship request
 func (r *MentorshipRepository) ListSessionsByRequest(ctx context.Context, requestID int) ([]*models.MentorshipSession, error) {
 	query := `
-        SELECT id, request_id, title, start_time, end_time, status, notes, created_at, updated_at
+        SELECT id, request_id, title, start_time, end_time, status, COALESCE(notes, ''),
+               COALESCE(meeting_provider, ''), COALESCE(meeting_url, ''), sequence, reminder_sent_at,
+               created_at, updated_at
         FROM mentorship_sessions
         WHERE request_id = $1
         ORDER BY start_time ASC`
@@ -344,17 +348,7 @@ func (r *MentorshipRepository) ListSessionsByRequest(ctx context.Context, reques
 	var sessions []*models.MentorshipSession
 	for rows.Next() {
 		session := &models.MentorshipSession{}
-		err := rows.Scan(
-			&session.ID,
-			&session.RequestID,
-			&session.Title,
-			&session.StartTime,
-			&session.EndTime,
-			&session.Status,
-			&session.Notes,
-			&session.CreatedAt,
-			&session.UpdatedAt,
-		)
+		err := rows.Scan(sessionScanFields(session)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
@@ -368,22 +362,131 @@ func (r *MentorshipRepository) ListSessionsByRequest(ctx context.Context, reques
 	return sessions, nil
 }
 
-// GetAverageRating calculates the average rating for a mentor
-func (r *MentorshipRepository) GetAverageRating(ctx context.Context, mentorID int) (float64, error) {
+// ListScheduledSessionsBetween retrieves the scheduled sessions a user takes
+// part in, as mentor or mentee, that overlap [from, to)
+func (r *MentorshipRepository) ListScheduledSessionsBetween(ctx context.Context, userID int, from, to time.Time) ([]*models.MentorshipSession, error) {
+	query := `
+        SELECT s.id, s.request_id, s.title, s.start_time, s.end_time, s.status, COALESCE(s.notes, ''),
+               COALESCE(s.meeting_provider, ''), COALESCE(s.meeting_url, ''), s.sequence, s.reminder_sent_at,
+               s.created_at, s.updated_at
+        FROM mentorship_sessions s
+        JOIN mentorship_requests mr ON mr.id = s.request_id
+        WHERE (mr.mentor_id = $1 OR mr.mentee_id = $1)
+          AND s.status = 'scheduled'
+          AND s.start_time < $3 AND s.end_time > $2
+        ORDER BY s.start_time ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.MentorshipSession
+	for rows.Next() {
+		session := &models.MentorshipSession{}
+		if err := rows.Scan(sessionScanFields(session)...); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// UpdateSessionSchedule moves a session to new times and stores its new join
+// details, bumping the invite sequence and re-arming the reminder
+func (r *MentorshipRepository) UpdateSessionSchedule(ctx context.Context, session *models.MentorshipSession) error {
 	query := `
-        SELECT COALESCE(AVG(sf.rating), 0)
-        FROM session_feedback sf
-        JOIN mentorship_sessions ms ON sf.session_id = ms.id
-        JOIN mentorship_requests mr ON ms.request_id = mr.id
-        WHERE mr.mentor_id = $1 AND sf.rating IS NOT NULL`
-
-	var avgRating float64
-	err := r.db.QueryRowContext(ctx, query, mentorID).Scan(&avgRating)
+        UPDATE mentorship_sessions
+        SET start_time = $1, end_time = $2, meeting_provider = $3, meeting_url = $4,
+            sequence = sequence + 1, reminder_sent_at = NULL, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $5
+        RETURNING sequence, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		session.StartTime,
+		session.EndTime,
+		session.MeetingProvider,
+		session.MeetingURL,
+		session.ID,
+	).Scan(&session.Sequence, &session.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("session not found")
+	}
 	if err != nil {
-		return 0, fmt.Errorf("failed to get average rating: %w", err)
+		return fmt.Errorf("failed to reschedule session: %w", err)
 	}
 
-	return avgRating, nil
+	session.ReminderSentAt = nil
+	return nil
+}
+
+// ListSessionsDueForReminder retrieves scheduled sessions starting before the
+// given time whose participants have not been reminded yet
+func (r *MentorshipRepository) ListSessionsDueForReminder(ctx context.Context, before time.Time) ([]*models.MentorshipSession, error) {
+	query := `
+        SELECT id, request_id, title, start_time, end_time, status, COALESCE(notes, ''),
+               COALESCE(meeting_provider, ''), COALESCE(meeting_url, ''), sequence, reminder_sent_at,
+               created_at, updated_at
+        FROM mentorship_sessions
+        WHERE status = 'scheduled' AND reminder_sent_at IS NULL
+          AND start_time > CURRENT_TIMESTAMP AND start_time <= $1
+        ORDER BY start_time ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions due for reminder: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.MentorshipSession
+	for rows.Next() {
+		session := &models.MentorshipSession{}
+		if err := rows.Scan(sessionScanFields(session)...); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// MarkReminderSent records that a session's reminder went out
+func (r *MentorshipRepository) MarkReminderSent(ctx context.Context, sessionID int) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE mentorship_sessions SET reminder_sent_at = CURRENT_TIMESTAMP WHERE id = $1`, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	}
+	return nil
+}
+
+// sessionScanFields lists the destinations for the standard session column set
+func sessionScanFields(session *models.MentorshipSession) []interface{} {
+	return []interface{}{
+		&session.ID,
+		&session.RequestID,
+		&session.Title,
+		&session.StartTime,
+		&session.EndTime,
+		&session.Status,
+		&session.Notes,
+		&session.MeetingProvider,
+		&session.MeetingURL,
+		&session.Sequence,
+		&session.ReminderSentAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	}
 }
 
 // UpdateProgramStatus updates the status of a mentorship program
@@ -404,20 +507,127 @@ func (r *MentorshipRepository) UpdateProgramStatus(ctx context.Context, programI
 	}
 
 	if rows == 0 {
-		return errors.New("program not found")
+		return ErrProgramNotFound
 	}
 
 	return nil
 }
 
+// UpdateProgram saves the editable details of a mentorship program
+func (r *MentorshipRepository) UpdateProgram(ctx context.Context, program *models.MentorshipProgram) error {
+	query := `
+        UPDATE mentorship_programs
+        SET title = $1, description = $2, duration = $3, price = $4, max_mentees = $5
+        WHERE id = $6
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		program.Title,
+		program.Description,
+		program.Duration,
+		program.Price,
+		program.MaxMentees,
+		program.ID,
+	).Scan(&program.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrProgramNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update program: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProgram removes a program that never received a mentorship request
+func (r *MentorshipRepository) DeleteProgram(ctx context.Context, programID int) error {
+	query := `
+        DELETE FROM mentorship_programs
+        WHERE id = $1
+        AND NOT EXISTS (SELECT 1 FROM mentorship_requests WHERE program_id = $1)`
+
+	result, err := r.db.ExecContext(ctx, query, programID)
+	if err != nil {
+		return fmt.Errorf("failed to delete program: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		var exists bool
+		err = r.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM mentorship_programs WHERE id = $1)", programID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check program: %w", err)
+		}
+		if !exists {
+			return ErrProgramNotFound
+		}
+		return ErrProgramInUse
+	}
+
+	return nil
+}
+
+// GetProgramEnrollment counts pending and approved requests against a program.
+// Requests waiting on the agreement count as pending.
+func (r *MentorshipRepository) GetProgramEnrollment(ctx context.Context, programID int) (*models.ProgramEnrollment, error) {
+	query := `
+        SELECT
+            COUNT(*) FILTER (WHERE status IN ('pending', 'awaiting_agreement')),
+            COUNT(*) FILTER (WHERE status = 'awaiting_agreement'),
+            COUNT(*) FILTER (WHERE status = 'approved'),
+            COUNT(*)
+        FROM mentorship_requests
+        WHERE program_id = $1`
+
+	enrollment := &models.ProgramEnrollment{}
+	err := r.db.QueryRowContext(ctx, query, programID).Scan(
+		&enrollment.Pending,
+		&enrollment.AwaitingAgreement,
+		&enrollment.Active,
+		&enrollment.Total,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count program enrollment: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+// GetMentorActivity counts a mentor's completed sessions and the distinct
+// mentees of their approved and completed mentorships
+func (r *MentorshipRepository) GetMentorActivity(ctx context.Context, mentorID int) (*models.MentorActivity, error) {
+	query := `
+        SELECT
+            (SELECT COUNT(*)
+             FROM mentorship_sessions s
+             JOIN mentorship_requests mr ON mr.id = s.request_id
+             WHERE mr.mentor_id = $1 AND s.status = 'completed'),
+            (SELECT COUNT(DISTINCT mentee_id)
+             FROM mentorship_requests
+             WHERE mentor_id = $1 AND status IN ('approved', 'completed'))`
+
+	activity := &models.MentorActivity{}
+	err := r.db.QueryRowContext(ctx, query, mentorID).Scan(&activity.CompletedSessions, &activity.Mentees)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count mentor activity: %w", err)
+	}
+
+	return activity, nil
+}
+
 // Helper function to validate status transitions
 func isValidStatusTransition(current, new string) bool {
 	transitions := map[string][]string{
-		"pending":   {"approved", "rejected"},
-		"approved":  {"completed", "cancelled"},
-		"rejected":  {},
-		"completed": {},
-		"cancelled": {},
+		"pending":            {"approved", "awaiting_agreement", "rejected"},
+		"awaiting_agreement": {"approved", "cancelled"},
+		"approved":           {"completed", "cancelled"},
+		"rejected":           {},
+		"completed":          {},
+		"cancelled":          {},
 	}
 
 	validTransitions, exists := transitions[current]
@@ -433,52 +643,87 @@ func isValidStatusTransition(current, new string) bool {
 	return false
 }
 
-// GetSessionFeedback retrieves feedback for a specific session
-func (r *MentorshipRepository) GetSessionFeedback(ctx context.Context, sessionID int) ([]*models.SessionFeedback, error) {
-	query := `
-        SELECT id, session_id, user_id, rating, comment, created_at
-        FROM session_feedback
-        WHERE session_id = $1
-        ORDER BY created_at DESC`
+// ApproveRequest approves a pending request if its program has room, holding
+// the program's row lock from the seat count to the status change
+func (r *MentorshipRepository) ApproveRequest(ctx context.Context, requestID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	rows, err := r.db.QueryContext(ctx, query, sessionID)
+	if err := checkProgramSeat(ctx, tx, requestID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE mentorship_requests
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND status = $3`,
+		models.RequestStatus.Approved, requestID, models.RequestStatus.Pending)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get session feedback: %w", err)
+		return fmt.Errorf("failed to update request status: %w", err)
 	}
-	defer rows.Close()
 
-	var feedbacks []*models.SessionFeedback
-	for rows.Next() {
-		feedback := &models.SessionFeedback{}
-		err := rows.Scan(
-			&feedback.ID,
-			&feedback.SessionID,
-			&feedback.UserID,
-			&feedback.Rating,
-			&feedback.Comment,
-			&feedback.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan feedback: %w", err)
-		}
-		feedbacks = append(feedbacks, feedback)
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: request is no longer %s", ErrInvalidStatus, models.RequestStatus.Pending)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating feedback: %w", err)
+	return tx.Commit()
+}
+
+// checkProgramSeat locks the program of a request within tx and checks it has
+// room for one more mentee, so concurrent approvals are counted one after the
+// other. Approved mentorships and those awaiting their agreement hold a seat.
+func checkProgramSeat(ctx context.Context, tx *sql.Tx, requestID int) error {
+	var title string
+	var maxMentees int
+	err := tx.QueryRowContext(ctx, `
+        SELECT p.title, p.max_mentees
+        FROM mentorship_programs p
+        JOIN mentorship_requests mr ON mr.program_id = p.id
+        WHERE mr.id = $1
+        FOR UPDATE OF p`, requestID).Scan(&title, &maxMentees)
+	if err == sql.ErrNoRows {
+		return ErrRequestNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock program: %w", err)
+	}
+
+	var seated int
+	err = tx.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM mentorship_requests
+        WHERE program_id = (SELECT program_id FROM mentorship_requests WHERE id = $1)
+          AND status IN ('approved', 'awaiting_agreement')`, requestID).Scan(&seated)
+	if err != nil {
+		return fmt.Errorf("failed to count program enrollment: %w", err)
+	}
+	if seated >= maxMentees {
+		return fmt.Errorf("%w: %s already has %d of %d mentees", ErrProgramFull, title, seated, maxMentees)
 	}
 
-	return feedbacks, nil
+	return nil
 }
 
-// UpdateRequestStatus updates the status of a mentorship request
-func (r *MentorshipRepository) UpdateRequestStatus(ctx context.Context, requestID int, status string) error {
+// UpdateRequestStatus moves a mentorship request from status from to status to.
+// Transitions the request lifecycle does not allow, and requests that are no
+// longer in from, get ErrInvalidStatus.
+func (r *MentorshipRepository) UpdateRequestStatus(ctx context.Context, requestID int, from, to string) error {
+	if !isValidStatusTransition(from, to) {
+		return fmt.Errorf("%w: a %s request cannot become %s", ErrInvalidStatus, from, to)
+	}
+
 	query := `
-        UPDATE mentorship_requests 
+        UPDATE mentorship_requests
         SET status = $1, updated_at = CURRENT_TIMESTAMP
-        WHERE id = $2`
+        WHERE id = $2 AND status = $3`
 
-	result, err := r.db.ExecContext(ctx, query, status, requestID)
+	result, err := r.db.ExecContext(ctx, query, to, requestID, from)
 	if err != nil {
 		return fmt.Errorf("failed to update request status: %w", err)
 	}
@@ -489,7 +734,7 @@ func (r *MentorshipRepository) UpdateRequestStatus(ctx context.Context, requestI
 	}
 
 	if rows == 0 {
-		return errors.New("request not found")
+		return fmt.Errorf("%w: request is no longer %s", ErrInvalidStatus, from)
 	}
 
 	return nil
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+// CreateNotification stores a new notification for a user
+func (r *NotificationRepository) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	query := `
+        INSERT INTO notifications (user_id, type, title, message)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, read, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+	).Scan(&notification.ID, &notification.Read, &notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotifications retrieves a user's notifications, newest first
+func (r *NotificationRepository) ListNotifications(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error) {
+	query := `
+        SELECT id, user_id, type, title, message, read, created_at
+        FROM notifications
+        WHERE user_id = $1 AND ($2 = false OR read = false)
+        ORDER BY created_at DESC
+        LIMIT 100`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification := &models.Notification{}
+		if err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&notification.Read,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkNotificationRead marks one of a user's notifications as read
+func (r *NotificationRepository) MarkNotificationRead(ctx context.Context, userID, notificationID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE notifications SET read = true WHERE id = $1 AND user_id = $2`,
+		notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
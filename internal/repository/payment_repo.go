@@ -0,0 +1,480 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+)
+
+var (
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrInvoiceExists        = errors.New("mentorship already has an invoice")
+	ErrInvoiceNotOpen       = errors.New("invoice is not open for payment")
+	ErrInvoiceUnpaid        = errors.New("the mentorship's invoice has not been paid")
+	ErrRefundExceedsBalance = errors.New("refund exceeds the refundable amount")
+	ErrUnbalancedLedger     = errors.New("ledger transaction does not balance")
+)
+
+const invoiceColumns = `
+        i.id, COALESCE(i.number, ''), i.request_id, i.mentee_id, i.mentor_id, i.program_id, i.description,
+        i.currency, i.amount_cents, i.platform_fee_cents, i.refunded_cents, i.status, i.issued_at, i.paid_at,
+        i.updated_at, mentee.username, mentor.username, p.title`
+
+const invoiceJoins = `
+        FROM invoices i
+        JOIN users mentee ON i.mentee_id = mentee.id
+        JOIN users mentor ON i.mentor_id = mentor.id
+        JOIN mentorship_programs p ON i.program_id = p.id`
+
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+// CreateInvoice issues an invoice and assigns its number
+func (r *PaymentRepository) CreateInvoice(ctx context.Context, invoice *models.Invoice) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO invoices (request_id, mentee_id, mentor_id, program_id, description, currency,
+                              amount_cents, platform_fee_cents, status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (request_id) DO NOTHING
+        RETURNING id, issued_at, updated_at`,
+		invoice.RequestID,
+		invoice.MenteeID,
+		invoice.MentorID,
+		invoice.ProgramID,
+		invoice.Description,
+		invoice.Currency,
+		invoice.AmountCents,
+		invoice.PlatformFeeCents,
+		invoice.Status,
+	).Scan(&invoice.ID, &invoice.IssuedAt, &invoice.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrInvoiceExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	invoice.Number = fmt.Sprintf("INV-%06d", invoice.ID)
+	if _, err := tx.ExecContext(ctx, "UPDATE invoices SET number = $1 WHERE id = $2", invoice.Number, invoice.ID); err != nil {
+		return fmt.Errorf("failed to number invoice: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetInvoice retrieves an invoice with its payment history
+func (r *PaymentRepository) GetInvoice(ctx context.Context, invoiceID int) (*models.Invoice, error) {
+	return r.getInvoice(ctx, `WHERE i.id = $1`, invoiceID)
+}
+
+// GetInvoiceByRequest retrieves the invoice of a mentorship with its payment history
+func (r *PaymentRepository) GetInvoiceByRequest(ctx context.Context, requestID int) (*models.Invoice, error) {
+	return r.getInvoice(ctx, `WHERE i.request_id = $1`, requestID)
+}
+
+// ListInvoices retrieves the invoices a user was billed for or is paid through, newest first
+func (r *PaymentRepository) ListInvoices(ctx context.Context, userID int) ([]*models.Invoice, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+invoiceColumns+invoiceJoins+`
+        WHERE i.mentee_id = $1 OR i.mentor_id = $1
+        ORDER BY i.issued_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	defer rows.Close()
+
+	invoices := []*models.Invoice{}
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice: %w", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// RecordCharge stores a successful charge, marks the invoice paid and posts the
+// ledger transaction, all or nothing. ErrInvoiceNotOpen means the invoice was
+// settled concurrently and the charge must be reversed with the provider.
+func (r *PaymentRepository) RecordCharge(ctx context.Context, payment *models.Payment, entry *models.LedgerTransaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM invoices WHERE id = $1 FOR UPDATE", payment.InvoiceID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrInvoiceNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock invoice: %w", err)
+	}
+	if status != models.InvoiceStatus.Open {
+		return ErrInvoiceNotOpen
+	}
+
+	if err := insertPayment(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE invoices
+        SET status = $1, paid_at = CURRENT_TIMESTAMP
+        WHERE id = $2`, models.InvoiceStatus.Paid, payment.InvoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to mark invoice paid: %w", err)
+	}
+
+	if err := insertLedgerTransaction(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RecordFailedPayment stores a charge or refund the provider declined
+func (r *PaymentRepository) RecordFailedPayment(ctx context.Context, payment *models.Payment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPayment(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReserveRefund checks that a refund fits within what is still refundable and
+// counts it against the invoice before the provider is called, so concurrent
+// refunds cannot exceed the amount paid. It returns the amount refunded before this one.
+func (r *PaymentRepository) ReserveRefund(ctx context.Context, invoiceID int, amountCents int64) (int64, error) {
+	var previous int64
+	err := r.db.QueryRowContext(ctx, `
+        UPDATE invoices
+        SET refunded_cents = refunded_cents + $1,
+            status = CASE WHEN refunded_cents + $1 = amount_cents THEN $2 ELSE $3 END
+        WHERE id = $4
+        AND paid_at IS NOT NULL
+        AND refunded_cents + $1 <= amount_cents
+        RETURNING refunded_cents - $1`,
+		amountCents, models.InvoiceStatus.Refunded, models.InvoiceStatus.PartiallyRefunded, invoiceID).Scan(&previous)
+	if err == sql.ErrNoRows {
+		return 0, ErrRefundExceedsBalance
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve refund: %w", err)
+	}
+
+	return previous, nil
+}
+
+// ReleaseRefund undoes a reservation after the provider declined the refund
+func (r *PaymentRepository) ReleaseRefund(ctx context.Context, invoiceID int, amountCents int64) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE invoices
+        SET refunded_cents = refunded_cents - $1,
+            status = CASE WHEN refunded_cents - $1 = 0 THEN $2 ELSE $3 END
+        WHERE id = $4`,
+		amountCents, models.InvoiceStatus.Paid, models.InvoiceStatus.PartiallyRefunded, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to release refund: %w", err)
+	}
+
+	return nil
+}
+
+// VoidInvoice cancels an invoice that was never paid
+func (r *PaymentRepository) VoidInvoice(ctx context.Context, invoiceID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE invoices
+        SET status = $1
+        WHERE id = $2 AND status = $3`,
+		models.InvoiceStatus.Void, invoiceID, models.InvoiceStatus.Open)
+	if err != nil {
+		return fmt.Errorf("failed to void invoice: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrInvoiceNotOpen
+	}
+
+	return nil
+}
+
+// RecordRefund stores a completed refund and posts its ledger transaction
+func (r *PaymentRepository) RecordRefund(ctx context.Context, payment *models.Payment, entry *models.LedgerTransaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPayment(ctx, tx, payment); err != nil {
+		return err
+	}
+	if err := insertLedgerTransaction(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListLedgerTransactions retrieves the ledger postings of an invoice, oldest first
+func (r *PaymentRepository) ListLedgerTransactions(ctx context.Context, invoiceID int) ([]*models.LedgerTransaction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT t.id, t.invoice_id, t.kind, t.description, t.created_at, e.id, e.account, e.amount_cents
+        FROM ledger_transactions t
+        JOIN ledger_entries e ON e.transaction_id = t.id
+        WHERE t.invoice_id = $1
+        ORDER BY t.created_at ASC, t.id ASC, e.id ASC`, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []*models.LedgerTransaction{}
+	var current *models.LedgerTransaction
+	for rows.Next() {
+		txn := &models.LedgerTransaction{}
+		entry := &models.LedgerEntry{}
+		if err := rows.Scan(
+			&txn.ID,
+			&txn.InvoiceID,
+			&txn.Kind,
+			&txn.Description,
+			&txn.CreatedAt,
+			&entry.ID,
+			&entry.Account,
+			&entry.AmountCents,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		if current == nil || current.ID != txn.ID {
+			current = txn
+			current.Entries = []*models.LedgerEntry{}
+			transactions = append(transactions, current)
+		}
+		entry.TransactionID = current.ID
+		current.Entries = append(current.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ledger entries: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// ListProgramPayouts totals a mentor's ledger postings per program
+func (r *PaymentRepository) ListProgramPayouts(ctx context.Context, mentorID int) ([]*models.ProgramPayout, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT
+            i.program_id,
+            p.title,
+            COUNT(DISTINCT i.id),
+            COALESCE(SUM(e.amount_cents) FILTER (WHERE e.account = $2 AND t.kind = $3), 0),
+            COALESCE(-SUM(e.amount_cents) FILTER (WHERE e.account = $4), 0),
+            COALESCE(-SUM(e.amount_cents) FILTER (WHERE e.account = $2 AND t.kind = $5), 0),
+            COALESCE(-SUM(e.amount_cents) FILTER (WHERE e.account = $6), 0)
+        FROM invoices i
+        JOIN mentorship_programs p ON i.program_id = p.id
+        JOIN ledger_transactions t ON t.invoice_id = i.id
+        JOIN ledger_entries e ON e.transaction_id = t.id
+        WHERE i.mentor_id = $1
+        GROUP BY i.program_id, p.title
+        ORDER BY p.title`,
+		mentorID,
+		models.LedgerAccount.Cash,
+		models.PaymentKind.Charge,
+		models.LedgerAccount.PlatformFees,
+		models.PaymentKind.Refund,
+		models.MentorPayableAccount(mentorID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list program payouts: %w", err)
+	}
+	defer rows.Close()
+
+	payouts := []*models.ProgramPayout{}
+	for rows.Next() {
+		payout := &models.ProgramPayout{}
+		if err := rows.Scan(
+			&payout.ProgramID,
+			&payout.ProgramTitle,
+			&payout.PaidInvoices,
+			&payout.GrossCents,
+			&payout.FeeCents,
+			&payout.RefundedCents,
+			&payout.NetCents,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan program payout: %w", err)
+		}
+		payouts = append(payouts, payout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating program payouts: %w", err)
+	}
+
+	return payouts, nil
+}
+
+func (r *PaymentRepository) getInvoice(ctx context.Context, where string, arg interface{}) (*models.Invoice, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+invoiceColumns+invoiceJoins+` `+where, arg)
+
+	invoice, err := scanInvoice(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, invoice_id, kind, provider, provider_ref, amount_cents, status, failure_reason, created_at
+        FROM payments
+        WHERE invoice_id = $1
+        ORDER BY created_at ASC`, invoice.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	defer rows.Close()
+
+	invoice.Payments = []*models.Payment{}
+	for rows.Next() {
+		payment := &models.Payment{}
+		if err := rows.Scan(
+			&payment.ID,
+			&payment.InvoiceID,
+			&payment.Kind,
+			&payment.Provider,
+			&payment.ProviderRef,
+			&payment.AmountCents,
+			&payment.Status,
+			&payment.FailureReason,
+			&payment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		invoice.Payments = append(invoice.Payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payments: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// scanInvoice scans invoiceColumns from a *sql.Row or *sql.Rows
+func scanInvoice(row interface{ Scan(...interface{}) error }) (*models.Invoice, error) {
+	invoice := &models.Invoice{}
+	if err := row.Scan(
+		&invoice.ID,
+		&invoice.Number,
+		&invoice.RequestID,
+		&invoice.MenteeID,
+		&invoice.MentorID,
+		&invoice.ProgramID,
+		&invoice.Description,
+		&invoice.Currency,
+		&invoice.AmountCents,
+		&invoice.PlatformFeeCents,
+		&invoice.RefundedCents,
+		&invoice.Status,
+		&invoice.IssuedAt,
+		&invoice.PaidAt,
+		&invoice.UpdatedAt,
+		&invoice.MenteeName,
+		&invoice.MentorName,
+		&invoice.ProgramTitle,
+	); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func insertPayment(ctx context.Context, tx *sql.Tx, payment *models.Payment) error {
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO payments (invoice_id, kind, provider, provider_ref, amount_cents, status, failure_reason)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at`,
+		payment.InvoiceID,
+		payment.Kind,
+		payment.Provider,
+		payment.ProviderRef,
+		payment.AmountCents,
+		payment.Status,
+		payment.FailureReason,
+	).Scan(&payment.ID, &payment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record payment: %w", err)
+	}
+	return nil
+}
+
+// insertLedgerTransaction posts a transaction whose entries must sum to zero;
+// the database enforces the same rule when the surrounding transaction commits
+func insertLedgerTransaction(ctx context.Context, tx *sql.Tx, txn *models.LedgerTransaction) error {
+	var sum int64
+	for _, entry := range txn.Entries {
+		sum += entry.AmountCents
+	}
+	if sum != 0 || len(txn.Entries) < 2 {
+		return ErrUnbalancedLedger
+	}
+
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO ledger_transactions (invoice_id, kind, description)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at`,
+		txn.InvoiceID, txn.Kind, txn.Description,
+	).Scan(&txn.ID, &txn.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger transaction: %w", err)
+	}
+
+	for _, entry := range txn.Entries {
+		if entry.AmountCents == 0 {
+			continue
+		}
+		entry.TransactionID = txn.ID
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO ledger_entries (transaction_id, account, amount_cents)
+            VALUES ($1, $2, $3)
+            RETURNING id`,
+			entry.TransactionID, entry.Account, entry.AmountCents,
+		).Scan(&entry.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create ledger entry: %w", err)
+		}
+	}
+
+	return nil
+}
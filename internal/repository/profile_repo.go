@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"mentorApp/internal/models"
+	"strings"
 )
 
 type ProfileRepository struct {
@@ -22,9 +23,9 @@ func (r *ProfileRepository) CreateProfile(ctx context.Context, profile *models.P
 	query := `INSERT INTO profiles (
         user_id, first_name, last_name, bio, skills, experience, linkedin,
         github, twitter, rate, available, timezone, profile_picture,
-        notification_preferences, privacy_settings
+        notification_preferences, privacy_settings, specialties
     ) VALUES (
-        $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+        $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
     ) RETURNING id`
 
 	return r.db.QueryRowContext(ctx, query,
@@ -43,6 +44,7 @@ func (r *ProfileRepository) CreateProfile(ctx context.Context, profile *models.P
 		profile.ProfilePicture,
 		profile.NotificationPreferences,
 		profile.PrivacySettings,
+		profile.Specialties,
 	).Scan(&profile.Id)
 }
 
@@ -50,7 +52,7 @@ func (r *ProfileRepository) CreateProfile(ctx context.Context, profile *models.P
 func (r *ProfileRepository) GetProfileByUserID(ctx context.Context, userID int) (*models.Profile, error) {
 	query := `SELECT id, user_id, first_name, last_name, bio, skills, experience,
         linkedin, github, twitter, rate, available, timezone, profile_picture,
-        notification_preferences, privacy_settings, created_at, updated_at
+        notification_preferences, privacy_settings, specialties, created_at, updated_at
         FROM profiles WHERE user_id = $1`
 
 	profile := &models.Profile{}
@@ -71,6 +73,7 @@ func (r *ProfileRepository) GetProfileByUserID(ctx context.Context, userID int)
 		&profile.ProfilePicture,
 		&profile.NotificationPreferences,
 		&profile.PrivacySettings,
+		&profile.Specialties,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
 	)
@@ -98,8 +101,9 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.P
         profile_picture = $12,
         notification_preferences = $13,
         privacy_settings = $14,
+        specialties = $15,
         updated_at = CURRENT_TIMESTAMP
-        WHERE user_id = $15`
+        WHERE user_id = $16`
 
 	result, err := r.db.ExecContext(ctx, query,
 		profile.FirstName,
@@ -116,6 +120,7 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.P
 		profile.ProfilePicture,
 		profile.NotificationPreferences,
 		profile.PrivacySettings,
+		profile.Specialties,
 		profile.UserId,
 	)
 
@@ -135,33 +140,24 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.P
 	return nil
 }
 
-// GetUserRatings gets all ratings for a user
-func (r *ProfileRepository) GetUserRatings(ctx context.Context, userID int) ([]*models.Rating, error) {
-	query := `SELECT id, from_user_id, rating, comment, created_at 
-              FROM ratings WHERE to_user_id = $1`
+// GetRatingSummary reads the denormalized rating counters kept on a mentor's profile
+func (r *ProfileRepository) GetRatingSummary(ctx context.Context, userID int) (*models.RatingSummary, error) {
+	query := `SELECT rating_sum, rating_count FROM profiles WHERE user_id = $1`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	var sum, count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&sum, &count)
+	if err == sql.ErrNoRows {
+		return &models.RatingSummary{MentorID: userID}, nil
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get rating summary: %w", err)
 	}
-	defer rows.Close()
 
-	var ratings []*models.Rating
-	for rows.Next() {
-		rating := &models.Rating{}
-		err := rows.Scan(
-			&rating.Id,
-			&rating.FromUserId,
-			&rating.Rating,
-			&rating.Comment,
-			&rating.CreatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		ratings = append(ratings, rating)
+	summary := &models.RatingSummary{MentorID: userID, RatingCount: count}
+	if count > 0 {
+		summary.AverageRating = float64(sum) / float64(count)
 	}
-	return ratings, nil
+	return summary, nil
 }
 
 // AddExperience adds a new experience entry
@@ -267,9 +263,13 @@ func (r *ProfileRepository) AddEducation(ctx context.Context, education *models.
 		education.EndDate).Scan(&education.ID)
 }
 
-// SearchProfiles searches for profiles based on filters
+// SearchProfiles lists approved mentor profiles, optionally filtered by skill,
+// rate range and timezone
 func (r *ProfileRepository) SearchProfiles(ctx context.Context, filters map[string]interface{}) ([]*models.Profile, error) {
-	query := `SELECT p.* FROM profiles p
+	query := `SELECT p.id, p.user_id, p.first_name, p.last_name, COALESCE(p.bio, ''), COALESCE(p.skills, ''),
+              p.specialties, COALESCE(p.experience, ''), COALESCE(p.rate, 0), COALESCE(p.available, true),
+              p.is_approved, COALESCE(p.timezone, ''), COALESCE(p.profile_picture, ''), p.created_at, p.updated_at
+              FROM profiles p
               INNER JOIN users u ON p.user_id = u.id
               WHERE u.is_mentor = true AND u.is_approved = true`
 
@@ -277,8 +277,8 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, filters map[stri
 	argCount := 1
 
 	if skills, ok := filters["skills"].(string); ok && skills != "" {
-		query += fmt.Sprintf(` AND p.skills ILIKE $%d`, argCount)
-		args = append(args, "%"+skills+"%")
+		query += fmt.Sprintf(` AND `+hasSkillCondition, argCount)
+		args = append(args, strings.TrimSpace(skills))
 		argCount++
 	}
 
@@ -304,11 +304,11 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, filters map[stri
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to search profiles: %w", err)
 	}
 	defer rows.Close()
 
-	var profiles []*models.Profile
+	profiles := []*models.Profile{}
 	for rows.Next() {
 		profile := &models.Profile{}
 		if err := rows.Scan(
@@ -318,25 +318,250 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, filters map[stri
 			&profile.LastName,
 			&profile.Bio,
 			&profile.Skills,
+			&profile.Specialties,
+			&profile.Experience,
 			&profile.Rate,
 			&profile.Available,
+			&profile.IsApproved,
 			&profile.Timezone,
 			&profile.ProfilePicture,
 			&profile.CreatedAt,
 			&profile.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
 		}
 		profiles = append(profiles, profile)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating profiles: %w", err)
+	}
+
 	return profiles, nil
 }
 
+// hasSkillCondition matches profiles listing the skill in their comma-separated
+// skills, ignoring case and surrounding spaces. Format it with the argument number.
+const hasSkillCondition = `EXISTS (
+        SELECT 1 FROM unnest(string_to_array(COALESCE(p.skills, ''), ',')) AS s(skill)
+        WHERE lower(trim(s.skill)) = lower($%d))`
+
+// mentorRatingExpr is a profile's average rating, zero while it has none
+const mentorRatingExpr = `CASE WHEN p.rating_count > 0 THEN p.rating_sum::float8 / p.rating_count ELSE 0 END`
+
+// mentorSearchOrder maps search sort orders to ORDER BY clauses
+var mentorSearchOrder = map[string]string{
+	models.MentorSearchSort.Relevance: `rank DESC, rating DESC, p.rating_count DESC, p.id`,
+	models.MentorSearchSort.Rating:    `rating DESC, p.rating_count DESC, p.id`,
+	models.MentorSearchSort.PriceAsc:  `p.rate ASC NULLS LAST, rating DESC, p.id`,
+	models.MentorSearchSort.PriceDesc: `p.rate DESC NULLS LAST, rating DESC, p.id`,
+	models.MentorSearchSort.Newest:    `p.created_at DESC, p.id`,
+}
+
+// mentorSearchFilter builds the FROM and WHERE clauses shared by a search's
+// result page and its facet counts. The text query, when present, is always $1.
+func mentorSearchFilter(query *models.MentorSearchQuery) (string, []interface{}) {
+	clause := `
+        FROM profiles p
+        JOIN users u ON p.user_id = u.id
+        WHERE u.is_mentor = true AND u.is_approved = true`
+	var args []interface{}
+
+	if query.Text != "" {
+		args = append(args, query.Text)
+		clause += fmt.Sprintf(` AND p.search_vector @@ websearch_to_tsquery('english', $%d)`, len(args))
+	}
+	for _, skill := range query.Skills {
+		args = append(args, skill)
+		clause += fmt.Sprintf(` AND `+hasSkillCondition, len(args))
+	}
+	if query.RateMin != nil {
+		args = append(args, *query.RateMin)
+		clause += fmt.Sprintf(` AND COALESCE(p.rate, 0) >= $%d`, len(args))
+	}
+	if query.RateMax != nil {
+		args = append(args, *query.RateMax)
+		clause += fmt.Sprintf(` AND COALESCE(p.rate, 0) <= $%d`, len(args))
+	}
+	if query.Timezone != "" {
+		args = append(args, query.Timezone)
+		clause += fmt.Sprintf(` AND p.timezone = $%d`, len(args))
+	}
+	if query.Available != nil {
+		args = append(args, *query.Available)
+		clause += fmt.Sprintf(` AND COALESCE(p.available, true) = $%d`, len(args))
+	}
+
+	return clause, args
+}
+
+// SearchMentors runs a ranked full-text search over approved mentors and returns
+// one page of results with facet counts over every match
+func (r *ProfileRepository) SearchMentors(ctx context.Context, query *models.MentorSearchQuery) (*models.MentorSearchResult, error) {
+	filter, args := mentorSearchFilter(query)
+
+	rank := `0::float8`
+	if query.Text != "" {
+		rank = `ts_rank_cd(p.search_vector, websearch_to_tsquery('english', $1))`
+	}
+	order, ok := mentorSearchOrder[query.Sort]
+	if !ok {
+		order = mentorSearchOrder[models.MentorSearchSort.Relevance]
+	}
+
+	pageArgs := append(append([]interface{}{}, args...), query.PageSize, (query.Page-1)*query.PageSize)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        SELECT p.user_id, p.first_name, p.last_name, COALESCE(p.bio, ''), COALESCE(p.skills, ''), p.specialties,
+               COALESCE(p.rate, 0), COALESCE(p.timezone, ''), COALESCE(p.available, true),
+               COALESCE(p.profile_picture, ''), %s AS rating, p.rating_count, %s AS rank,
+               COUNT(*) OVER ()
+        %s
+        ORDER BY %s
+        LIMIT $%d OFFSET $%d`, mentorRatingExpr, rank, filter, order, len(args)+1, len(args)+2), pageArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search mentors: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.MentorSearchResult{
+		Mentors:  []*models.MentorSearchHit{},
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Sort:     query.Sort,
+	}
+	for rows.Next() {
+		hit := &models.MentorSearchHit{}
+		if err := rows.Scan(
+			&hit.UserID,
+			&hit.FirstName,
+			&hit.LastName,
+			&hit.Bio,
+			&hit.Skills,
+			&hit.Specialties,
+			&hit.Rate,
+			&hit.Timezone,
+			&hit.Available,
+			&hit.ProfilePicture,
+			&hit.AverageRating,
+			&hit.RatingCount,
+			&hit.Rank,
+			&result.Total,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mentor search result: %w", err)
+		}
+		result.Mentors = append(result.Mentors, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating mentor search results: %w", err)
+	}
+
+	// A page past the end has no rows to carry the total
+	if len(result.Mentors) == 0 && query.Page > 1 {
+		if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+filter, args...).Scan(&result.Total); err != nil {
+			return nil, fmt.Errorf("failed to count mentor search results: %w", err)
+		}
+	}
+
+	result.Facets, err = r.mentorSearchFacets(ctx, filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mentorSearchFacets counts the matches of a search by skill, rate band,
+// timezone and availability
+func (r *ProfileRepository) mentorSearchFacets(ctx context.Context, filter string, args []interface{}) (*models.MentorSearchFacets, error) {
+	facets := &models.MentorSearchFacets{}
+	matches := `WITH matches AS (SELECT p.* ` + filter + `) `
+
+	var err error
+	facets.Skills, err = r.facetCounts(ctx, matches+`
+        SELECT lower(trim(s.skill)), COUNT(DISTINCT m.id)
+        FROM matches m
+        CROSS JOIN LATERAL unnest(string_to_array(COALESCE(m.skills, ''), ',')) AS s(skill)
+        WHERE trim(s.skill) <> ''
+        GROUP BY 1
+        ORDER BY 2 DESC, 1
+        LIMIT 25`, args)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Timezones, err = r.facetCounts(ctx, matches+`
+        SELECT COALESCE(NULLIF(m.timezone, ''), 'UTC'), COUNT(*)
+        FROM matches m
+        GROUP BY 1
+        ORDER BY 2 DESC, 1`, args)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Availability, err = r.facetCounts(ctx, matches+`
+        SELECT CASE WHEN COALESCE(m.available, true) THEN 'available' ELSE 'unavailable' END, COUNT(*)
+        FROM matches m
+        GROUP BY 1
+        ORDER BY 1`, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// One filtered count per band, in band order
+	bandArgs := append([]interface{}{}, args...)
+	counts := make([]string, len(models.MentorRateBands))
+	for i, band := range models.MentorRateBands {
+		bandArgs = append(bandArgs, band.Min)
+		condition := fmt.Sprintf(`COALESCE(m.rate, 0) >= $%d`, len(bandArgs))
+		if band.Max != nil {
+			bandArgs = append(bandArgs, *band.Max)
+			condition += fmt.Sprintf(` AND COALESCE(m.rate, 0) < $%d`, len(bandArgs))
+		}
+		counts[i] = `COUNT(*) FILTER (WHERE ` + condition + `)`
+	}
+
+	bandCounts := make([]int, len(models.MentorRateBands))
+	dest := make([]interface{}, len(bandCounts))
+	for i := range bandCounts {
+		dest[i] = &bandCounts[i]
+	}
+	err = r.db.QueryRowContext(ctx, matches+`SELECT `+strings.Join(counts, ", ")+` FROM matches m`, bandArgs...).Scan(dest...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rate bands: %w", err)
+	}
+	for i, band := range models.MentorRateBands {
+		facets.RateBands = append(facets.RateBands, &models.RateBandCount{RateBand: band, Count: bandCounts[i]})
+	}
+
+	return facets, nil
+}
+
+func (r *ProfileRepository) facetCounts(ctx context.Context, query string, args []interface{}) ([]*models.FacetCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search facet: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []*models.FacetCount{}
+	for rows.Next() {
+		count := &models.FacetCount{}
+		if err := rows.Scan(&count.Value, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan search facet: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search facet: %w", err)
+	}
+
+	return counts, nil
+}
+
 // SetAvailability sets availability for a profile
 func (r *ProfileRepository) SetAvailability(ctx context.Context, availability *models.Availability) error {
-	query := `INSERT INTO availability (profile_id, day_of_week, start_time, end_time)
+	query := `INSERT INTO availability (mentor_id, day_of_week, start_time, end_time)
               VALUES ($1, $2, $3, $4)
-              ON CONFLICT (profile_id, day_of_week) DO UPDATE
+              ON CONFLICT (mentor_id, day_of_week) DO UPDATE
               SET start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -350,8 +575,8 @@ func (r *ProfileRepository) SetAvailability(ctx context.Context, availability *m
 
 // GetProfileAvailability gets all availability slots for a profile
 func (r *ProfileRepository) GetProfileAvailability(ctx context.Context, profileID int) ([]models.Availability, error) {
-	query := `SELECT profile_id, day_of_week, start_time, end_time, created_at, updated_at
-              FROM availability WHERE profile_id = $1 ORDER BY day_of_week, start_time`
+	query := `SELECT id, mentor_id, day_of_week, start_time, end_time, created_at, updated_at
+              FROM availability WHERE mentor_id = $1 ORDER BY day_of_week, start_time`
 
 	rows, err := r.db.QueryContext(ctx, query, profileID)
 	if err != nil {
@@ -363,6 +588,7 @@ func (r *ProfileRepository) GetProfileAvailability(ctx context.Context, profileI
 	for rows.Next() {
 		slot := models.Availability{}
 		if err := rows.Scan(
+			&slot.ID,
 			&slot.ProfileID,
 			&slot.DayOfWeek,
 			&slot.StartTime,
@@ -381,7 +607,7 @@ func (r *ProfileRepository) GetProfileAvailability(ctx context.Context, profileI
 func (r *ProfileRepository) UpdateAvailability(ctx context.Context, availability *models.Availability) error {
 	query := `UPDATE availability 
               SET day_of_week = $1, start_time = $2, end_time = $3, updated_at = CURRENT_TIMESTAMP
-              WHERE profile_id = $4`
+              WHERE mentor_id = $4`
 
 	result, err := r.db.ExecContext(ctx, query,
 		availability.DayOfWeek,
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type RecommendationRepository struct {
+	db *sql.DB
+}
+
+func NewRecommendationRepository(db *sql.DB) *RecommendationRepository {
+	return &RecommendationRepository{
+		db: db,
+	}
+}
+
+// ListMenteeInterests returns the skills a mentee wants to grow: those on their
+// profile plus any skill or synonym named in the goals of their mentorships
+func (r *RecommendationRepository) ListMenteeInterests(ctx context.Context, menteeID int) ([]int, error) {
+	query := `
+        WITH goal_text AS (
+            SELECT ' ' || regexp_replace(lower(string_agg(g.title || ' ' || g.specific || ' ' || g.relevant, ' ')),
+                                         '[^a-z0-9+#.]+', ' ', 'g') || ' ' AS words
+            FROM mentorship_goals g
+            JOIN mentorship_requests mr ON g.request_id = mr.id
+            WHERE mr.mentee_id = $1 AND g.status = 'active'
+        )
+        SELECT ps.skill_id
+        FROM profile_skills ps
+        JOIN profiles p ON ps.profile_id = p.id
+        WHERE p.user_id = $1
+        UNION
+        SELECT s.id
+        FROM skills s, goal_text t
+        WHERE t.words IS NOT NULL AND (
+            strpos(t.words, ' ' || replace(s.slug, '-', ' ') || ' ') > 0
+            OR EXISTS (
+                SELECT 1 FROM skill_synonyms sy
+                WHERE sy.skill_id = s.id AND strpos(t.words, ' ' || replace(sy.slug, '-', ' ') || ' ') > 0))`
+
+	rows, err := r.db.QueryContext(ctx, query, menteeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mentee interests: %w", err)
+	}
+	defer rows.Close()
+
+	var skillIDs []int
+	for rows.Next() {
+		var skillID int
+		if err := rows.Scan(&skillID); err != nil {
+			return nil, fmt.Errorf("failed to scan mentee interest: %w", err)
+		}
+		skillIDs = append(skillIDs, skillID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating mentee interests: %w", err)
+	}
+
+	return skillIDs, nil
+}
+
+// ListCandidates returns the approved mentors a mentee could be recommended.
+// It leaves out mentors the mentee already has an open or active mentorship
+// with, mentors marked not interested, and mentors dismissed since dismissedSince.
+func (r *RecommendationRepository) ListCandidates(ctx context.Context, menteeID int, dismissedSince time.Time) ([]*models.MentorCandidate, error) {
+	query := fmt.Sprintf(`
+        WITH open_spots AS (
+            SELECT mp.mentor_id, SUM(GREATEST(mp.max_mentees - COALESCE(e.active, 0), 0)) AS spots
+            FROM mentorship_programs mp
+            LEFT JOIN (
+                SELECT program_id, COUNT(*) AS active
+                FROM mentorship_requests
+                WHERE status = 'approved'
+                GROUP BY program_id
+            ) e ON e.program_id = mp.id
+            WHERE mp.status = 'active'
+            GROUP BY mp.mentor_id
+        )
+        SELECT p.user_id, p.first_name, p.last_name, COALESCE(p.bio, ''), COALESCE(p.rate, 0),
+               COALESCE(p.timezone, ''), COALESCE(p.available, true), COALESCE(p.profile_picture, ''),
+               %s, p.rating_count, COALESCE(o.spots, 0),
+               (SELECT COUNT(*) FROM mentorship_requests mr
+                WHERE mr.mentor_id = p.user_id AND mr.mentee_id = $1 AND mr.status = 'rejected'),
+               ARRAY(SELECT ps.skill_id FROM profile_skills ps WHERE ps.profile_id = p.id ORDER BY ps.skill_id),
+               ARRAY(SELECT s.name FROM profile_skills ps JOIN skills s ON ps.skill_id = s.id
+                     WHERE ps.profile_id = p.id ORDER BY ps.skill_id)
+        FROM profiles p
+        JOIN users u ON p.user_id = u.id
+        LEFT JOIN open_spots o ON o.mentor_id = p.user_id
+        WHERE u.is_mentor = true AND u.is_approved = true AND p.user_id <> $1
+          AND NOT EXISTS (
+              SELECT 1 FROM mentor_recommendation_feedback f
+              WHERE f.mentee_id = $1 AND f.mentor_id = p.user_id
+                AND (f.kind = 'not_interested' OR f.created_at > $2))
+          AND NOT EXISTS (
+              SELECT 1 FROM mentorship_requests mr
+              WHERE mr.mentee_id = $1 AND mr.mentor_id = p.user_id
+                AND mr.status IN ('pending', 'awaiting_agreement', 'approved'))`, mentorRatingExpr)
+
+	rows, err := r.db.QueryContext(ctx, query, menteeID, dismissedSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mentor candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*models.MentorCandidate
+	for rows.Next() {
+		candidate := &models.MentorCandidate{}
+		var skillIDs pq.Int64Array
+		var skillNames pq.StringArray
+		if err := rows.Scan(
+			&candidate.UserID,
+			&candidate.FirstName,
+			&candidate.LastName,
+			&candidate.Bio,
+			&candidate.Rate,
+			&candidate.Timezone,
+			&candidate.Available,
+			&candidate.ProfilePicture,
+			&candidate.AverageRating,
+			&candidate.RatingCount,
+			&candidate.OpenSpots,
+			&candidate.Rejections,
+			&skillIDs,
+			&skillNames,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mentor candidate: %w", err)
+		}
+		candidate.SkillIDs = skillIDs
+		candidate.SkillNames = skillNames
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating mentor candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// SaveFeedback records that a mentee dismissed a mentor or is not interested,
+// replacing any earlier feedback on the same mentor
+func (r *RecommendationRepository) SaveFeedback(ctx context.Context, menteeID, mentorID int, kind string) error {
+	query := `
+        INSERT INTO mentor_recommendation_feedback (mentee_id, mentor_id, kind)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (mentee_id, mentor_id)
+        DO UPDATE SET kind = EXCLUDED.kind, created_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.ExecContext(ctx, query, menteeID, mentorID, kind); err != nil {
+		return fmt.Errorf("failed to save recommendation feedback: %w", err)
+	}
+	return nil
+}
+
+// DeleteFeedback lets a mentor be recommended to the mentee again
+func (r *RecommendationRepository) DeleteFeedback(ctx context.Context, menteeID, mentorID int) error {
+	query := `DELETE FROM mentor_recommendation_feedback WHERE mentee_id = $1 AND mentor_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, menteeID, mentorID); err != nil {
+		return fmt.Errorf("failed to delete recommendation feedback: %w", err)
+	}
+	return nil
+}
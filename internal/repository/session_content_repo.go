@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+var (
+	ErrContentConflict    = errors.New("content was changed by someone else; reload and try again")
+	ErrActionItemNotFound = errors.New("action item not found")
+)
+
+type SessionContentRepository struct {
+	db *sql.DB
+}
+
+func NewSessionContentRepository(db *sql.DB) *SessionContentRepository {
+	return &SessionContentRepository{
+		db: db,
+	}
+}
+
+// GetContent retrieves a session's agenda and shared notes. Sessions nobody
+// has written anything for yet return empty content.
+func (r *SessionContentRepository) GetContent(ctx context.Context, sessionID int) (*models.SessionContent, error) {
+	query := `
+        SELECT session_id, agenda, agenda_version, agenda_updated_by,
+               shared_notes, shared_notes_version, shared_notes_updated_by, updated_at
+        FROM session_contents
+        WHERE session_id = $1`
+
+	content := &models.SessionContent{}
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&content.SessionID,
+		&content.Agenda,
+		&content.AgendaVersion,
+		&content.AgendaUpdatedBy,
+		&content.SharedNotes,
+		&content.SharedNotesVersion,
+		&content.SharedNotesUpdatedBy,
+		&content.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return &models.SessionContent{SessionID: sessionID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session content: %w", err)
+	}
+
+	return content, nil
+}
+
+// SaveAgenda stores a new agenda if the caller edited the latest version
+func (r *SessionContentRepository) SaveAgenda(ctx context.Context, sessionID, userID int, agenda string, expectedVersion int) (int, error) {
+	query := `
+        INSERT INTO session_contents (session_id, agenda, agenda_version, agenda_updated_by)
+        VALUES ($1, $2, 1, $3)
+        ON CONFLICT (session_id) DO UPDATE
+        SET agenda = EXCLUDED.agenda,
+            agenda_version = session_contents.agenda_version + 1,
+            agenda_updated_by = EXCLUDED.agenda_updated_by
+        WHERE session_contents.agenda_version = $4
+        RETURNING agenda_version`
+
+	return r.saveVersioned(ctx, query, sessionID, agenda, userID, expectedVersion)
+}
+
+// SaveSharedNotes stores new shared notes if the caller edited the latest version
+func (r *SessionContentRepository) SaveSharedNotes(ctx context.Context, sessionID, userID int, notes string, expectedVersion int) (int, error) {
+	query := `
+        INSERT INTO session_contents (session_id, shared_notes, shared_notes_version, shared_notes_updated_by)
+        VALUES ($1, $2, 1, $3)
+        ON CONFLICT (session_id) DO UPDATE
+        SET shared_notes = EXCLUDED.shared_notes,
+            shared_notes_version = session_contents.shared_notes_version + 1,
+            shared_notes_updated_by = EXCLUDED.shared_notes_updated_by
+        WHERE session_contents.shared_notes_version = $4
+        RETURNING shared_notes_version`
+
+	return r.saveVersioned(ctx, query, sessionID, notes, userID, expectedVersion)
+}
+
+func (r *SessionContentRepository) saveVersioned(ctx context.Context, query string, sessionID int, text string, userID, expectedVersion int) (int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, query, sessionID, text, userID, expectedVersion).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, ErrContentConflict
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to save session content: %w", err)
+	}
+	return version, nil
+}
+
+// GetPrivateNotes retrieves the notes a participant keeps for themselves
+func (r *SessionContentRepository) GetPrivateNotes(ctx context.Context, sessionID, userID int) (string, error) {
+	var content string
+	err := r.db.QueryRowContext(ctx, `
+        SELECT content FROM session_private_notes WHERE session_id = $1 AND user_id = $2`,
+		sessionID, userID).Scan(&content)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get private notes: %w", err)
+	}
+	return content, nil
+}
+
+// SavePrivateNotes creates or replaces a participant's private notes
+func (r *SessionContentRepository) SavePrivateNotes(ctx context.Context, sessionID, userID int, content string) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO session_private_notes (session_id, user_id, content)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (session_id, user_id) DO UPDATE SET content = EXCLUDED.content`,
+		sessionID, userID, content)
+	if err != nil {
+		return fmt.Errorf("failed to save private notes: %w", err)
+	}
+	return nil
+}
+
+// CreateActionItem adds an action item to a session
+func (r *SessionContentRepository) CreateActionItem(ctx context.Context, item *models.ActionItem) error {
+	query := `
+        INSERT INTO session_action_items (request_id, session_id, title, description, owner_id, due_date, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, done, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		item.RequestID,
+		item.SessionID,
+		item.Title,
+		item.Description,
+		item.OwnerID,
+		item.DueDate,
+		item.CreatedBy,
+	).Scan(&item.ID, &item.Done, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create action item: %w", err)
+	}
+
+	return nil
+}
+
+// GetActionItem retrieves an action item by ID
+func (r *SessionContentRepository) GetActionItem(ctx context.Context, itemID int) (*models.ActionItem, error) {
+	query := `
+        SELECT id, request_id, session_id, title, description, owner_id, due_date,
+               done, completed_at, created_by, created_at, updated_at
+        FROM session_action_items
+        WHERE id = $1`
+
+	item := &models.ActionItem{}
+	err := r.db.QueryRowContext(ctx, query, itemID).Scan(actionItemScanFields(item)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrActionItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action item: %w", err)
+	}
+
+	return item, nil
+}
+
+// UpdateActionItem saves an action item's editable fields
+func (r *SessionContentRepository) UpdateActionItem(ctx context.Context, item *models.ActionItem) error {
+	query := `
+        UPDATE session_action_items
+        SET title = $1, description = $2, owner_id = $3, due_date = $4, done = $5, completed_at = $6
+        WHERE id = $7
+        RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		item.Title,
+		item.Description,
+		item.OwnerID,
+		item.DueDate,
+		item.Done,
+		item.CompletedAt,
+		item.ID,
+	).Scan(&item.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrActionItemNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update action item: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteActionItem removes an action item
+func (r *SessionContentRepository) DeleteActionItem(ctx context.Context, itemID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM session_action_items WHERE id = $1`, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to delete action item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrActionItemNotFound
+	}
+
+	return nil
+}
+
+// ListSessionActionItems retrieves the items raised in a session plus every
+// item still open from earlier sessions of the same mentorship
+func (r *SessionContentRepository) ListSessionActionItems(ctx context.Context, requestID, sessionID int, sessionStart time.Time) ([]*models.ActionItem, error) {
+	query := `
+        SELECT ai.id, ai.request_id, ai.session_id, ai.title, ai.description, ai.owner_id, ai.due_date,
+               ai.done, ai.completed_at, ai.created_by, ai.created_at, ai.updated_at
+        FROM session_action_items ai
+        JOIN mentorship_sessions ms ON ai.session_id = ms.id
+        WHERE ai.request_id = $1
+          AND (ai.session_id = $2 OR (ai.done = false AND ms.start_time < $3))
+        ORDER BY ai.done ASC, ai.due_date ASC NULLS LAST, ai.created_at ASC`
+
+	items, err := r.queryActionItems(ctx, query, requestID, sessionID, sessionStart)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		item.CarriedOver = item.SessionID != sessionID
+	}
+	return items, nil
+}
+
+// ListRequestActionItems retrieves all action items for a mentorship
+func (r *SessionContentRepository) ListRequestActionItems(ctx context.Context, requestID int, openOnly bool) ([]*models.ActionItem, error) {
+	query := `
+        SELECT id, request_id, session_id, title, description, owner_id, due_date,
+               done, completed_at, created_by, created_at, updated_at
+        FROM session_action_items
+        WHERE request_id = $1 AND ($2 = false OR done = false)
+        ORDER BY done ASC, due_date ASC NULLS LAST, created_at ASC`
+
+	return r.queryActionItems(ctx, query, requestID, openOnly)
+}
+
+func (r *SessionContentRepository) queryActionItems(ctx context.Context, query string, args ...interface{}) ([]*models.ActionItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list action items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.ActionItem{}
+	for rows.Next() {
+		item := &models.ActionItem{}
+		if err := rows.Scan(actionItemScanFields(item)...); err != nil {
+			return nil, fmt.Errorf("failed to scan action item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating action items: %w", err)
+	}
+
+	return items, nil
+}
+
+func actionItemScanFields(item *models.ActionItem) []interface{} {
+	return []interface{}{
+		&item.ID,
+		&item.RequestID,
+		&item.SessionID,
+		&item.Title,
+		&item.Description,
+		&item.OwnerID,
+		&item.DueDate,
+		&item.Done,
+		&item.CompletedAt,
+		&item.CreatedBy,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	}
+}
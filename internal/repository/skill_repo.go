@@ -0,0 +1,717 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"mentorApp/internal/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrSkillNotFound         = errors.New("skill not found")
+	ErrSkillExists           = errors.New("a skill or synonym with this name already exists")
+	ErrSkillCategoryNotFound = errors.New("skill category not found")
+	ErrSkillCategoryExists   = errors.New("a skill category with this name already exists")
+	ErrSynonymNotFound       = errors.New("synonym not found")
+	ErrProfileNotFound       = errors.New("profile not found")
+)
+
+const skillColumns = `
+        s.id, s.category_id, COALESCE(c.name, ''), s.name, s.slug, s.is_specialty, s.description,
+        ARRAY(SELECT sy.name FROM skill_synonyms sy WHERE sy.skill_id = s.id ORDER BY sy.name),
+        (SELECT COUNT(*) FROM profile_skills ps WHERE ps.skill_id = s.id),
+        s.created_at, s.updated_at`
+
+const skillJoins = `
+        FROM skills s
+        LEFT JOIN skill_categories c ON s.category_id = c.id`
+
+// profileSkillTextUpdate rewrites the comma-separated skills and specialties of
+// the profiles in $1 from their taxonomy links, so mentor search keeps matching them
+const profileSkillTextUpdate = `
+        UPDATE profiles p SET
+            skills = COALESCE((
+                SELECT string_agg(s.name, ', ' ORDER BY s.name)
+                FROM profile_skills ps JOIN skills s ON ps.skill_id = s.id
+                WHERE ps.profile_id = p.id AND NOT s.is_specialty), ''),
+            specialties = COALESCE((
+                SELECT string_agg(s.name, ', ' ORDER BY s.name)
+                FROM profile_skills ps JOIN skills s ON ps.skill_id = s.id
+                WHERE ps.profile_id = p.id AND s.is_specialty), '')
+        WHERE p.id = ANY($1)`
+
+type SkillRepository struct {
+	db *sql.DB
+}
+
+func NewSkillRepository(db *sql.DB) *SkillRepository {
+	return &SkillRepository{
+		db: db,
+	}
+}
+
+func scanSkill(row interface{ Scan(...interface{}) error }) (*models.Skill, error) {
+	skill := &models.Skill{}
+	err := row.Scan(
+		&skill.ID,
+		&skill.CategoryID,
+		&skill.CategoryName,
+		&skill.Name,
+		&skill.Slug,
+		&skill.IsSpecialty,
+		&skill.Description,
+		pq.Array(&skill.Synonyms),
+		&skill.MentorCount,
+		&skill.CreatedAt,
+		&skill.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if skill.Synonyms == nil {
+		skill.Synonyms = []string{}
+	}
+	return skill, nil
+}
+
+// CreateCategory stores a new skill category
+func (r *SkillRepository) CreateCategory(ctx context.Context, category *models.SkillCategory) error {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO skill_categories (name, slug, description, position)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (slug) DO NOTHING
+        RETURNING id, created_at, updated_at`,
+		category.Name,
+		category.Slug,
+		category.Description,
+		category.Position,
+	).Scan(&category.ID, &category.CreatedAt, &category.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSkillCategoryExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create skill category: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCategory saves a category's name, description and position
+func (r *SkillRepository) UpdateCategory(ctx context.Context, category *models.SkillCategory) error {
+	var taken bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM skill_categories WHERE slug = $1 AND id <> $2)`,
+		category.Slug, category.ID).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("failed to check skill category name: %w", err)
+	}
+	if taken {
+		return ErrSkillCategoryExists
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+        UPDATE skill_categories
+        SET name = $1, slug = $2, description = $3, position = $4
+        WHERE id = $5
+        RETURNING created_at, updated_at`,
+		category.Name,
+		category.Slug,
+		category.Description,
+		category.Position,
+		category.ID,
+	).Scan(&category.CreatedAt, &category.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSkillCategoryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update skill category: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCategory removes a category. Its skills become uncategorised.
+func (r *SkillRepository) DeleteCategory(ctx context.Context, categoryID int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM skill_categories WHERE id = $1", categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete skill category: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSkillCategoryNotFound
+	}
+
+	return nil
+}
+
+// GetCategory retrieves a skill category by ID
+func (r *SkillRepository) GetCategory(ctx context.Context, categoryID int) (*models.SkillCategory, error) {
+	category := &models.SkillCategory{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT c.id, c.name, c.slug, c.description, c.position,
+               (SELECT COUNT(*) FROM skills s WHERE s.category_id = c.id),
+               c.created_at, c.updated_at
+        FROM skill_categories c
+        WHERE c.id = $1`, categoryID).Scan(
+		&category.ID,
+		&category.Name,
+		&category.Slug,
+		&category.Description,
+		&category.Position,
+		&category.SkillCount,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrSkillCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill category: %w", err)
+	}
+
+	return category, nil
+}
+
+// ListCategories retrieves every category in display order with its skill count
+func (r *SkillRepository) ListCategories(ctx context.Context) ([]*models.SkillCategory, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT c.id, c.name, c.slug, c.description, c.position,
+               (SELECT COUNT(*) FROM skills s WHERE s.category_id = c.id),
+               c.created_at, c.updated_at
+        FROM skill_categories c
+        ORDER BY c.position, c.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skill categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*models.SkillCategory{}
+	for rows.Next() {
+		category := &models.SkillCategory{}
+		if err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.Slug,
+			&category.Description,
+			&category.Position,
+			&category.SkillCount,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan skill category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skill categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// CreateSkill adds a skill to the taxonomy. Its slug may not match another
+// skill or any synonym.
+func (r *SkillRepository) CreateSkill(ctx context.Context, skill *models.Skill) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSkillSlug(ctx, tx, skill.Slug, 0); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO skills (category_id, name, slug, is_specialty, description)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (slug) DO NOTHING
+        RETURNING id, created_at, updated_at`,
+		skill.CategoryID,
+		skill.Name,
+		skill.Slug,
+		skill.IsSpecialty,
+		skill.Description,
+	).Scan(&skill.ID, &skill.CreatedAt, &skill.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSkillExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create skill: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UpdateSkill saves a skill's name, category and description. Renaming
+// rewrites the skills text of every profile that lists it.
+func (r *SkillRepository) UpdateSkill(ctx context.Context, skill *models.Skill) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSkillSlug(ctx, tx, skill.Slug, skill.ID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE skills
+        SET category_id = $1, name = $2, slug = $3, is_specialty = $4, description = $5
+        WHERE id = $6`,
+		skill.CategoryID,
+		skill.Name,
+		skill.Slug,
+		skill.IsSpecialty,
+		skill.Description,
+		skill.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update skill: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSkillNotFound
+	}
+
+	profileIDs, err := profilesWithSkill(ctx, tx, skill.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, profileSkillTextUpdate, profileIDs); err != nil {
+		return fmt.Errorf("failed to update profile skills: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteSkill removes a skill, its synonyms and its profile links
+func (r *SkillRepository) DeleteSkill(ctx context.Context, skillID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	profileIDs, err := profilesWithSkill(ctx, tx, skillID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM skills WHERE id = $1", skillID)
+	if err != nil {
+		return fmt.Errorf("failed to delete skill: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSkillNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, profileSkillTextUpdate, profileIDs); err != nil {
+		return fmt.Errorf("failed to update profile skills: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetSkill retrieves a skill with its synonyms and the number of mentors listing it
+func (r *SkillRepository) GetSkill(ctx context.Context, skillID int) (*models.Skill, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT `+skillColumns+skillJoins+`
+        WHERE s.id = $1`, skillID)
+
+	skill, err := scanSkill(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrSkillNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill: %w", err)
+	}
+
+	return skill, nil
+}
+
+// ListSkills retrieves the taxonomy ordered by category and name, optionally
+// limited to one category or to specialties
+func (r *SkillRepository) ListSkills(ctx context.Context, categoryID *int, specialtiesOnly bool) ([]*models.Skill, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+skillColumns+skillJoins+`
+        WHERE ($1::int IS NULL OR s.category_id = $1)
+          AND (NOT $2 OR s.is_specialty)
+        ORDER BY c.position NULLS LAST, c.name NULLS LAST, s.name`, categoryID, specialtiesOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := []*models.Skill{}
+	for rows.Next() {
+		skill, err := scanSkill(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan skill: %w", err)
+		}
+		skills = append(skills, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+// AddSynonym adds an alternative name that resolves to the skill
+func (r *SkillRepository) AddSynonym(ctx context.Context, skillID int, name, slug string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockSkillSlug(ctx, tx, slug, 0); err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM skills WHERE id = $1)", skillID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to get skill: %w", err)
+	}
+	if !exists {
+		return ErrSkillNotFound
+	}
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO skill_synonyms (skill_id, name, slug)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (slug) DO NOTHING
+        RETURNING id`, skillID, name, slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		return ErrSkillExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add synonym: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// RemoveSynonym removes one of a skill's synonyms by slug
+func (r *SkillRepository) RemoveSynonym(ctx context.Context, skillID int, slug string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM skill_synonyms WHERE skill_id = $1 AND slug = $2", skillID, slug)
+	if err != nil {
+		return fmt.Errorf("failed to remove synonym: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSynonymNotFound
+	}
+
+	return nil
+}
+
+// MergeSkill folds a duplicate skill into another: profiles listing the source
+// list the target instead, keeping their level where they had both, and the
+// source's name and synonyms become synonyms of the target
+func (r *SkillRepository) MergeSkill(ctx context.Context, sourceID, targetID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sourceName, sourceSlug string
+	err = tx.QueryRowContext(ctx, "SELECT name, slug FROM skills WHERE id = $1 FOR UPDATE", sourceID).Scan(&sourceName, &sourceSlug)
+	if err == sql.ErrNoRows {
+		return ErrSkillNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock skill: %w", err)
+	}
+
+	var target int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM skills WHERE id = $1 FOR UPDATE", targetID).Scan(&target)
+	if err == sql.ErrNoRows {
+		return ErrSkillNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock skill: %w", err)
+	}
+
+	profileIDs, err := profilesWithSkill(ctx, tx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO profile_skills (profile_id, skill_id, level)
+        SELECT profile_id, $2, level FROM profile_skills WHERE skill_id = $1
+        ON CONFLICT (profile_id, skill_id) DO NOTHING`, sourceID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to move profile skills: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE skill_synonyms SET skill_id = $2 WHERE skill_id = $1", sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move synonyms: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM skills WHERE id = $1", sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged skill: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO skill_synonyms (skill_id, name, slug)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (slug) DO NOTHING`, targetID, sourceName, sourceSlug)
+	if err != nil {
+		return fmt.Errorf("failed to keep merged skill as synonym: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, profileSkillTextUpdate, profileIDs); err != nil {
+		return fmt.Errorf("failed to update profile skills: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SuggestSkills returns skills whose name or a synonym starts with the slug
+// prefix: exact matches first, then the skills most mentors list
+func (r *SkillRepository) SuggestSkills(ctx context.Context, prefix string, specialtiesOnly bool, limit int) ([]*models.SkillSuggestion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT s.id, s.name, s.slug, COALESCE(c.name, ''), s.is_specialty,
+               CASE WHEN s.slug LIKE $1 || '%' THEN '' ELSE COALESCE((
+                   SELECT sy.name FROM skill_synonyms sy
+                   WHERE sy.skill_id = s.id AND sy.slug LIKE $1 || '%'
+                   ORDER BY length(sy.slug), sy.slug
+                   LIMIT 1), '') END
+        FROM skills s
+        LEFT JOIN skill_categories c ON s.category_id = c.id
+        WHERE (s.slug LIKE $1 || '%'
+               OR EXISTS (SELECT 1 FROM skill_synonyms sy WHERE sy.skill_id = s.id AND sy.slug LIKE $1 || '%'))
+          AND (NOT $2 OR s.is_specialty)
+        ORDER BY s.slug = $1 DESC,
+                 (SELECT COUNT(*) FROM profile_skills ps WHERE ps.skill_id = s.id) DESC,
+                 s.name
+        LIMIT $3`, prefix, specialtiesOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest skills: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []*models.SkillSuggestion{}
+	for rows.Next() {
+		suggestion := &models.SkillSuggestion{}
+		if err := rows.Scan(
+			&suggestion.ID,
+			&suggestion.Name,
+			&suggestion.Slug,
+			&suggestion.CategoryName,
+			&suggestion.IsSpecialty,
+			&suggestion.MatchedSynonym,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan skill suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skill suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// ResolveSkill returns the skill a name or synonym refers to, adding it to
+// the taxonomy uncategorised when it is unknown
+func (r *SkillRepository) ResolveSkill(ctx context.Context, name, slug string, isSpecialty bool) (*models.Skill, error) {
+	skill := &models.Skill{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT s.id, s.name, s.slug, s.is_specialty
+        FROM skills s
+        WHERE s.slug = $1
+           OR s.id = (SELECT skill_id FROM skill_synonyms WHERE slug = $1)`, slug).Scan(
+		&skill.ID, &skill.Name, &skill.Slug, &skill.IsSpecialty)
+	if err == nil {
+		return skill, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to resolve skill: %w", err)
+	}
+
+	skill = &models.Skill{Name: name, Slug: slug, IsSpecialty: isSpecialty}
+	err = r.CreateSkill(ctx, skill)
+	if errors.Is(err, ErrSkillExists) {
+		// Created concurrently; look it up again
+		return r.ResolveSkill(ctx, name, slug, isSpecialty)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return skill, nil
+}
+
+// GetProfileSkills retrieves the skills on a user's profile with their levels
+func (r *SkillRepository) GetProfileSkills(ctx context.Context, userID int) ([]*models.ProfileSkill, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT s.id, s.name, s.slug, COALESCE(c.name, ''), s.is_specialty, ps.level
+        FROM profile_skills ps
+        JOIN profiles p ON ps.profile_id = p.id
+        JOIN skills s ON ps.skill_id = s.id
+        LEFT JOIN skill_categories c ON s.category_id = c.id
+        WHERE p.user_id = $1
+        ORDER BY s.is_specialty DESC, s.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := []*models.ProfileSkill{}
+	for rows.Next() {
+		skill := &models.ProfileSkill{}
+		if err := rows.Scan(
+			&skill.SkillID,
+			&skill.Name,
+			&skill.Slug,
+			&skill.CategoryName,
+			&skill.IsSpecialty,
+			&skill.Level,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan profile skill: %w", err)
+		}
+		skills = append(skills, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating profile skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+// SetProfileSkills replaces the skills on a user's profile and rewrites the
+// profile's skills and specialties text to match
+func (r *SkillRepository) SetProfileSkills(ctx context.Context, userID int, skills []*models.ProfileSkill) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var profileID int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM profiles WHERE user_id = $1 FOR UPDATE", userID).Scan(&profileID)
+	if err == sql.ErrNoRows {
+		return ErrProfileNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock profile: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM profile_skills WHERE profile_id = $1", profileID); err != nil {
+		return fmt.Errorf("failed to clear profile skills: %w", err)
+	}
+
+	for _, skill := range skills {
+		result, err := tx.ExecContext(ctx, `
+            INSERT INTO profile_skills (profile_id, skill_id, level)
+            SELECT $1, id, $3 FROM skills WHERE id = $2
+            ON CONFLICT (profile_id, skill_id) DO UPDATE SET level = EXCLUDED.level`,
+			profileID, skill.SkillID, skill.Level)
+		if err != nil {
+			return fmt.Errorf("failed to add profile skill: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("skill %d: %w", skill.SkillID, ErrSkillNotFound)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, profileSkillTextUpdate, pq.Array([]int{profileID})); err != nil {
+		return fmt.Errorf("failed to update profile skills: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// AddProfileSkills links skills to a user's profile without touching existing
+// links or levels
+func (r *SkillRepository) AddProfileSkills(ctx context.Context, userID int, skillIDs []int, level string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var profileID int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM profiles WHERE user_id = $1 FOR UPDATE", userID).Scan(&profileID)
+	if err == sql.ErrNoRows {
+		return ErrProfileNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock profile: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO profile_skills (profile_id, skill_id, level)
+        SELECT $1, id, $3 FROM skills WHERE id = ANY($2)
+        ON CONFLICT (profile_id, skill_id) DO NOTHING`, profileID, pq.Array(skillIDs), level)
+	if err != nil {
+		return fmt.Errorf("failed to add profile skills: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, profileSkillTextUpdate, pq.Array([]int{profileID})); err != nil {
+		return fmt.Errorf("failed to update profile skills: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// lockSkillSlug serialises changes to a slug and checks that no synonym and no
+// skill other than skillID uses it. Pass 0 for new skills and synonyms.
+func lockSkillSlug(ctx context.Context, tx *sql.Tx, slug string, skillID int) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('skill_slug:' || $1))", slug); err != nil {
+		return fmt.Errorf("failed to lock skill slug: %w", err)
+	}
+
+	var taken bool
+	err := tx.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM skills WHERE slug = $1 AND id <> $2)
+            OR EXISTS (SELECT 1 FROM skill_synonyms WHERE slug = $1)`, slug, skillID).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("failed to check skill slug: %w", err)
+	}
+	if taken {
+		return ErrSkillExists
+	}
+	return nil
+}
+
+// profilesWithSkill lists the profiles linked to a skill, for rewriting their skills text
+func profilesWithSkill(ctx context.Context, tx *sql.Tx, skillID int) (pq.Int64Array, error) {
+	var profileIDs pq.Int64Array
+	err := tx.QueryRowContext(ctx, `
+        SELECT COALESCE(array_agg(profile_id), '{}') FROM profile_skills WHERE skill_id = $1`, skillID).Scan(&profileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles with skill: %w", err)
+	}
+	return profileIDs, nil
+}
@@ -0,0 +1,468 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+var (
+	ErrSponsorNotFound     = errors.New("sponsor not found")
+	ErrSponsorCodeTaken    = errors.New("sponsorship code is already in use")
+	ErrSponsorshipNotFound = errors.New("mentorship is not sponsored")
+	ErrSponsorCreditsUsed  = errors.New("sponsor has no credits left")
+	ErrSponsorCodeInactive = errors.New("sponsorship code is no longer valid")
+)
+
+const sponsorColumns = `
+        s.id, s.name, s.code, s.contact_email, s.budget_credits,
+        (SELECT COALESCE(SUM(u.credits), 0) FROM sponsor_credit_usage u WHERE u.sponsor_id = s.id),
+        (SELECT COALESCE(SUM(sp.reserved_credits), 0) FROM sponsorships sp WHERE sp.sponsor_id = s.id),
+        s.active, s.expires_at, s.created_by, s.created_at, s.updated_at`
+
+type SponsorRepository struct {
+	db *sql.DB
+}
+
+func NewSponsorRepository(db *sql.DB) *SponsorRepository {
+	return &SponsorRepository{
+		db: db,
+	}
+}
+
+func scanSponsor(row interface{ Scan(...interface{}) error }) (*models.Sponsor, error) {
+	sponsor := &models.Sponsor{}
+	err := row.Scan(
+		&sponsor.ID,
+		&sponsor.Name,
+		&sponsor.Code,
+		&sponsor.ContactEmail,
+		&sponsor.BudgetCredits,
+		&sponsor.UsedCredits,
+		&sponsor.ReservedCredits,
+		&sponsor.Active,
+		&sponsor.ExpiresAt,
+		&sponsor.CreatedBy,
+		&sponsor.CreatedAt,
+		&sponsor.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return sponsor, nil
+}
+
+// CreateSponsor stores a new sponsor account. Codes are unique across sponsors.
+func (r *SponsorRepository) CreateSponsor(ctx context.Context, sponsor *models.Sponsor) error {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO sponsors (name, code, contact_email, budget_credits, active, expires_at, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (code) DO NOTHING
+        RETURNING id, created_at, updated_at`,
+		sponsor.Name,
+		sponsor.Code,
+		sponsor.ContactEmail,
+		sponsor.BudgetCredits,
+		sponsor.Active,
+		sponsor.ExpiresAt,
+		sponsor.CreatedBy,
+	).Scan(&sponsor.ID, &sponsor.CreatedAt, &sponsor.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSponsorCodeTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create sponsor: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSponsor saves a sponsor's details, budget and status. The code cannot change.
+func (r *SponsorRepository) UpdateSponsor(ctx context.Context, sponsor *models.Sponsor) error {
+	err := r.db.QueryRowContext(ctx, `
+        UPDATE sponsors
+        SET name = $1, contact_email = $2, budget_credits = $3, active = $4, expires_at = $5
+        WHERE id = $6
+        RETURNING updated_at`,
+		sponsor.Name,
+		sponsor.ContactEmail,
+		sponsor.BudgetCredits,
+		sponsor.Active,
+		sponsor.ExpiresAt,
+		sponsor.ID,
+	).Scan(&sponsor.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSponsorNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update sponsor: %w", err)
+	}
+
+	return nil
+}
+
+// GetSponsor retrieves a sponsor with the credits it has used so far
+func (r *SponsorRepository) GetSponsor(ctx context.Context, sponsorID int) (*models.Sponsor, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT `+sponsorColumns+`
+        FROM sponsors s
+        WHERE s.id = $1`, sponsorID)
+
+	sponsor, err := scanSponsor(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrSponsorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsor: %w", err)
+	}
+
+	return sponsor, nil
+}
+
+// GetSponsorByCode retrieves the sponsor owning a sponsorship code
+func (r *SponsorRepository) GetSponsorByCode(ctx context.Context, code string) (*models.Sponsor, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT `+sponsorColumns+`
+        FROM sponsors s
+        WHERE s.code = $1`, code)
+
+	sponsor, err := scanSponsor(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrSponsorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsor by code: %w", err)
+	}
+
+	return sponsor, nil
+}
+
+// ListSponsors retrieves every sponsor ordered by name
+func (r *SponsorRepository) ListSponsors(ctx context.Context) ([]*models.Sponsor, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+sponsorColumns+`
+        FROM sponsors s
+        ORDER BY s.name, s.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sponsors: %w", err)
+	}
+	defer rows.Close()
+
+	sponsors := []*models.Sponsor{}
+	for rows.Next() {
+		sponsor, err := scanSponsor(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sponsor: %w", err)
+		}
+		sponsors = append(sponsors, sponsor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sponsors: %w", err)
+	}
+
+	return sponsors, nil
+}
+
+// lockSponsor locks a sponsor's row for the rest of the transaction, so that
+// balances read and changed under it are exact, and returns its balance
+func lockSponsor(ctx context.Context, tx *sql.Tx, sponsorID int) (*models.Sponsor, error) {
+	var id int
+	err := tx.QueryRowContext(ctx, "SELECT id FROM sponsors WHERE id = $1 FOR UPDATE", sponsorID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, ErrSponsorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock sponsor: %w", err)
+	}
+
+	sponsor, err := scanSponsor(tx.QueryRowContext(ctx, `
+        SELECT `+sponsorColumns+`
+        FROM sponsors s
+        WHERE s.id = $1`, sponsorID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsor balance: %w", err)
+	}
+	return sponsor, nil
+}
+
+// CreateSponsoredRequest redeems a sponsorship code: in one transaction it
+// stores the mentorship request, records that the code's sponsor pays for it
+// and reserves credits for it. Unknown codes get ErrSponsorNotFound, inactive
+// or expired ones ErrSponsorCodeInactive and sponsors without enough free
+// credits ErrSponsorCreditsUsed; nothing is stored then.
+func (r *SponsorRepository) CreateSponsoredRequest(ctx context.Context, code string, request *models.MentorshipRequest, credits int, now time.Time) (*models.Sponsor, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var sponsorID int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM sponsors WHERE code = $1", code).Scan(&sponsorID)
+	if err == sql.ErrNoRows {
+		return nil, ErrSponsorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsor by code: %w", err)
+	}
+
+	sponsor, err := lockSponsor(ctx, tx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if !sponsor.AcceptsCodeAt(now) {
+		return nil, ErrSponsorCodeInactive
+	}
+	if sponsor.RemainingCredits() < credits {
+		return nil, ErrSponsorCreditsUsed
+	}
+
+	if err := createRequest(ctx, tx, request); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO sponsorships (request_id, sponsor_id, reserved_credits)
+        VALUES ($1, $2, $3)`, request.ID, sponsorID, credits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sponsorship: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return sponsor, nil
+}
+
+// CreateSponsoredSession stores a session of a sponsored mentorship and, in
+// the same transaction, makes sure the mentorship holds credits for all its
+// scheduled sessions, new one included, drawing any shortfall from the
+// sponsor's free credits. The sponsor row stays locked until the session is
+// stored, so concurrent bookings are counted one after the other. A sponsor
+// that cannot cover the shortfall gets ErrSponsorCreditsUsed and nothing is
+// stored; a mentorship without a sponsor gets ErrSponsorshipNotFound.
+func (r *SponsorRepository) CreateSponsoredSession(ctx context.Context, session *models.MentorshipSession, creditsPerSession int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sponsorID int
+	err = tx.QueryRowContext(ctx, "SELECT sponsor_id FROM sponsorships WHERE request_id = $1", session.RequestID).Scan(&sponsorID)
+	if err == sql.ErrNoRows {
+		return ErrSponsorshipNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get sponsorship: %w", err)
+	}
+
+	sponsor, err := lockSponsor(ctx, tx, sponsorID)
+	if err != nil {
+		return err
+	}
+
+	var reserved, scheduled int
+	err = tx.QueryRowContext(ctx, `
+        SELECT sp.reserved_credits,
+               (SELECT COUNT(*) FROM mentorship_sessions ms
+                WHERE ms.request_id = sp.request_id AND ms.status = 'scheduled')
+        FROM sponsorships sp
+        WHERE sp.request_id = $1`, session.RequestID).Scan(&reserved, &scheduled)
+	if err != nil {
+		return fmt.Errorf("failed to get reserved credits: %w", err)
+	}
+
+	if total := (scheduled + 1) * creditsPerSession; reserved < total {
+		if sponsor.RemainingCredits() < total-reserved {
+			return ErrSponsorCreditsUsed
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE sponsorships SET reserved_credits = $1 WHERE request_id = $2", total, session.RequestID)
+		if err != nil {
+			return fmt.Errorf("failed to reserve credits: %w", err)
+		}
+	}
+
+	if err := createSession(ctx, tx, session); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReleaseCredits gives the credits held for a mentorship back to its sponsor
+func (r *SponsorRepository) ReleaseCredits(ctx context.Context, requestID int) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE sponsorships SET reserved_credits = 0 WHERE request_id = $1", requestID)
+	if err != nil {
+		return fmt.Errorf("failed to release reserved credits: %w", err)
+	}
+	return nil
+}
+
+// GetSponsorship retrieves the sponsor of a mentorship and the credits it has used
+func (r *SponsorRepository) GetSponsorship(ctx context.Context, requestID int) (*models.Sponsorship, error) {
+	sponsorship := &models.Sponsorship{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT sp.request_id, sp.sponsor_id, s.name,
+               (SELECT COALESCE(SUM(u.credits), 0) FROM sponsor_credit_usage u WHERE u.request_id = sp.request_id),
+               sp.reserved_credits, sp.created_at
+        FROM sponsorships sp
+        JOIN sponsors s ON sp.sponsor_id = s.id
+        WHERE sp.request_id = $1`, requestID).Scan(
+		&sponsorship.RequestID,
+		&sponsorship.SponsorID,
+		&sponsorship.SponsorName,
+		&sponsorship.CreditsUsed,
+		&sponsorship.CreditsReserved,
+		&sponsorship.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrSponsorshipNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsorship: %w", err)
+	}
+
+	return sponsorship, nil
+}
+
+// ConsumeCredits draws a session's credits from the credits reserved for its
+// mentorship, or from the sponsor's free credits, and returns the sponsor's
+// updated balance. A session is only ever charged once; repeated calls leave
+// usage.ID at zero. The budget is never overdrawn: a session it cannot cover
+// gets ErrSponsorCreditsUsed and is not charged.
+func (r *SponsorRepository) ConsumeCredits(ctx context.Context, usage *models.CreditUsage) (*models.Sponsor, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockSponsor(ctx, tx, usage.SponsorID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO sponsor_credit_usage (sponsor_id, request_id, session_id, credits)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (session_id) DO NOTHING
+        RETURNING id, created_at`,
+		usage.SponsorID,
+		usage.RequestID,
+		usage.SessionID,
+		usage.Credits,
+	).Scan(&usage.ID, &usage.CreatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to record credit usage: %w", err)
+	}
+
+	if usage.ID != 0 {
+		_, err = tx.ExecContext(ctx, `
+            UPDATE sponsorships
+            SET reserved_credits = GREATEST(reserved_credits - $1, 0)
+            WHERE request_id = $2`, usage.Credits, usage.RequestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to use reserved credits: %w", err)
+		}
+	}
+
+	sponsor, err := scanSponsor(tx.QueryRowContext(ctx, `
+        SELECT `+sponsorColumns+`
+        FROM sponsors s
+        WHERE s.id = $1`, usage.SponsorID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sponsor balance: %w", err)
+	}
+	// Sessions covered by their reservation leave the committed credits as
+	// they were; any other session must fit in the free credits
+	committed := sponsor.UsedCredits + sponsor.ReservedCredits
+	if usage.ID != 0 && committed > before.UsedCredits+before.ReservedCredits && committed > sponsor.BudgetCredits {
+		usage.ID = 0
+		return nil, ErrSponsorCreditsUsed
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return sponsor, nil
+}
+
+// ListSponsoredMentorships retrieves the mentorships a sponsor pays for with the
+// credits each has used, most recent first
+func (r *SponsorRepository) ListSponsoredMentorships(ctx context.Context, sponsorID int) ([]*models.SponsoredMentorship, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT mr.id, mr.mentee_id, mentee.username, mentor.username, p.title, mr.status,
+               COALESCE(SUM(u.credits), 0), MAX(u.created_at), sp.created_at
+        FROM sponsorships sp
+        JOIN mentorship_requests mr ON sp.request_id = mr.id
+        JOIN users mentee ON mr.mentee_id = mentee.id
+        JOIN users mentor ON mr.mentor_id = mentor.id
+        JOIN mentorship_programs p ON mr.program_id = p.id
+        LEFT JOIN sponsor_credit_usage u ON u.request_id = mr.id AND u.sponsor_id = sp.sponsor_id
+        WHERE sp.sponsor_id = $1
+        GROUP BY mr.id, mentee.username, mentor.username, p.title, sp.created_at
+        ORDER BY sp.created_at DESC`, sponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sponsored mentorships: %w", err)
+	}
+	defer rows.Close()
+
+	mentorships := []*models.SponsoredMentorship{}
+	for rows.Next() {
+		mentorship := &models.SponsoredMentorship{}
+		if err := rows.Scan(
+			&mentorship.RequestID,
+			&mentorship.MenteeID,
+			&mentorship.MenteeName,
+			&mentorship.MentorName,
+			&mentorship.ProgramTitle,
+			&mentorship.Status,
+			&mentorship.CreditsUsed,
+			&mentorship.LastUsedAt,
+			&mentorship.SponsoredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan sponsored mentorship: %w", err)
+		}
+		mentorships = append(mentorships, mentorship)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sponsored mentorships: %w", err)
+	}
+
+	return mentorships, nil
+}
+
+// ListMonthlyUsage totals a sponsor's credit usage per calendar month, oldest first
+func (r *SponsorRepository) ListMonthlyUsage(ctx context.Context, sponsorID int) ([]*models.MonthlyCreditUsage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT to_char(date_trunc('month', created_at), 'YYYY-MM'), SUM(credits), COUNT(*)
+        FROM sponsor_credit_usage
+        WHERE sponsor_id = $1
+        GROUP BY date_trunc('month', created_at)
+        ORDER BY date_trunc('month', created_at)`, sponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monthly credit usage: %w", err)
+	}
+	defer rows.Close()
+
+	months := []*models.MonthlyCreditUsage{}
+	for rows.Next() {
+		month := &models.MonthlyCreditUsage{}
+		if err := rows.Scan(&month.Month, &month.Credits, &month.Sessions); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly credit usage: %w", err)
+		}
+		months = append(months, month)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating monthly credit usage: %w", err)
+	}
+
+	return months, nil
+}
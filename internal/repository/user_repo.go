@@ -259,15 +259,18 @@ func (r *UserRepository) CreateUserWithProfile(ctx context.Context, user *models
 
 	// Insert profile
 	profile.UserId = user.Id
-	query = `INSERT INTO profiles (user_id, first_name, last_name, bio, skills)
-             VALUES ($1, $2, $3, $4, $5)`
+	query = `INSERT INTO profiles (user_id, first_name, last_name, bio, skills, experience, specialties, rate)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 	_, err = tx.ExecContext(ctx, query,
 		profile.UserId,
 		profile.FirstName,
 		profile.LastName,
 		profile.Bio,
-		profile.Skills)
+		profile.Skills,
+		profile.Experience,
+		profile.Specialties,
+		profile.Rate)
 
 	if err != nil {
 		return err
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type AgreementService struct {
+	agreementRepo  repository.IAgreementRepository
+	mentorshipRepo repository.IMentorshipRepository
+	payments       IPaymentService
+	notifier       INotificationService
+}
+
+func NewAgreementService(
+	agreementRepo repository.IAgreementRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	payments IPaymentService,
+	notifier INotificationService,
+) IAgreementService {
+	return &AgreementService{
+		agreementRepo:  agreementRepo,
+		mentorshipRepo: mentorshipRepo,
+		payments:       payments,
+		notifier:       notifier,
+	}
+}
+
+// PublishTemplate stores a new version of the default agreement, or of a program's
+// override when programID is set. Mentorships already waiting keep their version.
+func (s *AgreementService) PublishTemplate(ctx context.Context, adminID int, programID *int, title, body string) (*models.AgreementTemplate, error) {
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+	if title == "" || body == "" {
+		return nil, errors.New("agreement title and body are required")
+	}
+
+	if programID != nil {
+		if _, err := s.mentorshipRepo.GetProgram(ctx, *programID); err != nil {
+			return nil, err
+		}
+	}
+
+	template := &models.AgreementTemplate{
+		ProgramID: programID,
+		Title:     title,
+		Body:      body,
+		CreatedBy: adminID,
+	}
+	if err := s.agreementRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// ListTemplates returns the version history of the default agreement or a program's override
+func (s *AgreementService) ListTemplates(ctx context.Context, programID *int) ([]*models.AgreementTemplate, error) {
+	return s.agreementRepo.ListTemplates(ctx, programID)
+}
+
+// GetAgreement returns the agreement a mentorship must accept to either participant
+func (s *AgreementService) GetAgreement(ctx context.Context, userID, requestID int) (*models.MentorshipAgreement, error) {
+	request, err := s.participantRequest(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadAgreement(ctx, request)
+}
+
+// GetAcceptanceRecord returns the agreement and acceptance evidence of any mentorship
+func (s *AgreementService) GetAcceptanceRecord(ctx context.Context, requestID int) (*models.MentorshipAgreement, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, repository.ErrAgreementNotFound
+	}
+	return s.loadAgreement(ctx, request)
+}
+
+// Accept records the participant's acceptance. When the second participant
+// accepts, the mentorship becomes active, both are notified and paid programs are invoiced.
+func (s *AgreementService) Accept(ctx context.Context, userID, requestID int, ipAddress, userAgent string) (*models.MentorshipAgreement, error) {
+	request, err := s.participantRequest(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	activated, err := s.agreementRepo.Accept(ctx, &models.AgreementAcceptance{
+		RequestID: requestID,
+		UserID:    userID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if activated {
+		request.Status = models.RequestStatus.Approved
+		for _, participant := range []int{request.MentorID, request.MenteeID} {
+			if err := s.notifier.Notify(ctx, participant, models.NotificationType.MentorshipActivated,
+				"Mentorship started", "Both participants accepted the agreement. Your mentorship is now active."); err != nil {
+				log.Printf("Failed to notify user %d of activated mentorship %d: %v", participant, requestID, err)
+			}
+		}
+		if _, err := s.payments.IssueInvoice(ctx, request); err != nil {
+			log.Printf("Failed to issue invoice for request %d: %v", requestID, err)
+		}
+	}
+
+	return s.loadAgreement(ctx, request)
+}
+
+func (s *AgreementService) participantRequest(ctx context.Context, userID, requestID int) (*models.MentorshipRequest, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	return request, nil
+}
+
+// loadAgreement fetches the mentorship's agreement and marks who has accepted it
+func (s *AgreementService) loadAgreement(ctx context.Context, request *models.MentorshipRequest) (*models.MentorshipAgreement, error) {
+	agreement, err := s.agreementRepo.GetMentorshipAgreement(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acceptance := range agreement.Acceptances {
+		switch acceptance.UserID {
+		case request.MentorID:
+			agreement.MentorAccepted = true
+		case request.MenteeID:
+			agreement.MenteeAccepted = true
+		}
+	}
+
+	return agreement, nil
+}
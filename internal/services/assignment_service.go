@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type AssignmentService struct {
+	assignmentRepo repository.IAssignmentRepository
+	mentorshipRepo repository.IMentorshipRepository
+	files          FileStore
+	notifier       INotificationService
+}
+
+func NewAssignmentService(
+	assignmentRepo repository.IAssignmentRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	files FileStore,
+	notifier INotificationService,
+) IAssignmentService {
+	return &AssignmentService{
+		assignmentRepo: assignmentRepo,
+		mentorshipRepo: mentorshipRepo,
+		files:          files,
+		notifier:       notifier,
+	}
+}
+
+// CreateAssignment sets work for every mentee of one of the mentor's programs
+// or for a single active mentorship
+func (s *AssignmentService) CreateAssignment(ctx context.Context, mentorID int, assignment *models.Assignment) error {
+	switch {
+	case assignment.ProgramID != nil && assignment.RequestID == nil:
+		program, err := s.mentorshipRepo.GetProgram(ctx, *assignment.ProgramID)
+		if err != nil {
+			return err
+		}
+		if program.MentorID != mentorID {
+			return errors.New("unauthorized: program belongs to another mentor")
+		}
+	case assignment.RequestID != nil && assignment.ProgramID == nil:
+		request, err := s.mentorshipRepo.GetRequest(ctx, *assignment.RequestID)
+		if err != nil {
+			return err
+		}
+		if request == nil || request.MentorID != mentorID {
+			return errors.New("unauthorized: only the mentor can set assignments")
+		}
+		if request.Status != "approved" {
+			return errors.New("assignments can only be set for an active mentorship")
+		}
+	default:
+		return errors.New("an assignment belongs to either a program or a mentorship")
+	}
+
+	if err := normalizeAssignment(assignment); err != nil {
+		return err
+	}
+	assignment.CreatedBy = mentorID
+
+	return s.assignmentRepo.CreateAssignment(ctx, assignment)
+}
+
+// UpdateAssignment changes an assignment's details; earlier reviews keep the rubric they were scored against
+func (s *AssignmentService) UpdateAssignment(ctx context.Context, mentorID, assignmentID int, update *models.Assignment) (*models.Assignment, error) {
+	assignment, err := s.mentorAssignment(ctx, mentorID, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeAssignment(update); err != nil {
+		return nil, err
+	}
+
+	assignment.Title = update.Title
+	assignment.Instructions = update.Instructions
+	assignment.DueAt = update.DueAt
+	assignment.Rubric = update.Rubric
+	assignment.MaxResubmissions = update.MaxResubmissions
+
+	if err := s.assignmentRepo.UpdateAssignment(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// DeleteAssignment removes an assignment and everything submitted for it
+func (s *AssignmentService) DeleteAssignment(ctx context.Context, mentorID, assignmentID int) error {
+	if _, err := s.mentorAssignment(ctx, mentorID, assignmentID); err != nil {
+		return err
+	}
+	return s.assignmentRepo.DeleteAssignment(ctx, assignmentID)
+}
+
+// ListProgramAssignments returns the program-wide assignments of one of the mentor's programs
+func (s *AssignmentService) ListProgramAssignments(ctx context.Context, mentorID, programID int) ([]*models.Assignment, error) {
+	program, err := s.mentorshipRepo.GetProgram(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	if program.MentorID != mentorID {
+		return nil, errors.New("unauthorized: program belongs to another mentor")
+	}
+	return s.assignmentRepo.ListForProgram(ctx, programID)
+}
+
+// ListMentorshipAssignments returns the assignments that apply to a mentorship
+// together with the latest submission for each
+func (s *AssignmentService) ListMentorshipAssignments(ctx context.Context, userID, requestID int) ([]*models.Assignment, error) {
+	request, err := s.participantRequest(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.ListForMentorship(ctx, requestID, request.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.assignmentRepo.ListLatestSubmissions(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	byAssignment := make(map[int]*models.Submission, len(latest))
+	for _, submission := range latest {
+		byAssignment[submission.AssignmentID] = submission
+	}
+	for _, assignment := range assignments {
+		assignment.LatestSubmission = byAssignment[assignment.ID]
+	}
+
+	return assignments, nil
+}
+
+// Submit hands in a round of work. A new round is only allowed after the mentor
+// requested changes and while resubmissions remain.
+func (s *AssignmentService) Submit(ctx context.Context, menteeID, assignmentID, requestID int, submission *models.Submission, fileName, contentType string, file io.Reader) error {
+	request, err := s.participantRequest(ctx, menteeID, requestID)
+	if err != nil {
+		return err
+	}
+	if request.MenteeID != menteeID {
+		return errors.New("unauthorized: only the mentee can submit work")
+	}
+	if request.Status != "approved" {
+		return errors.New("work can only be submitted for an active mentorship")
+	}
+
+	assignment, err := s.assignmentRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return err
+	}
+	if !appliesTo(assignment, request) {
+		return repository.ErrAssignmentNotFound
+	}
+
+	rounds, err := s.assignmentRepo.ListSubmissions(ctx, assignmentID, requestID)
+	if err != nil {
+		return err
+	}
+	if len(rounds) > 0 {
+		last := rounds[len(rounds)-1]
+		switch last.Status {
+		case models.SubmissionStatus.Submitted:
+			return errors.New("the previous submission is still awaiting review")
+		case models.SubmissionStatus.Approved:
+			return errors.New("this assignment has already been approved")
+		}
+		if len(rounds) > assignment.MaxResubmissions {
+			return errors.New("no resubmissions remain for this assignment")
+		}
+	}
+
+	submission.Body = strings.TrimSpace(submission.Body)
+	links := []string{}
+	for _, link := range submission.Links {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			continue
+		}
+		parsed, err := url.Parse(link)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid link %q: must be an http or https URL", link)
+		}
+		links = append(links, link)
+	}
+	submission.Links = links
+
+	if file != nil {
+		key, size, err := s.files.Save(ctx, fileName, file)
+		if err != nil {
+			return err
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		submission.FileKey = key
+		submission.FileName = fileName
+		submission.ContentType = contentType
+		submission.SizeBytes = size
+	}
+
+	if submission.Body == "" && len(submission.Links) == 0 && submission.FileKey == "" {
+		return errors.New("a submission needs text, a link or a file")
+	}
+
+	submission.AssignmentID = assignmentID
+	submission.RequestID = requestID
+	submission.MenteeID = menteeID
+	submission.Round = len(rounds) + 1
+	submission.Status = models.SubmissionStatus.Submitted
+	submission.Late = assignment.DueAt != nil && time.Now().After(*assignment.DueAt)
+
+	if err := s.assignmentRepo.CreateSubmission(ctx, submission); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Round %d of %q was submitted for review.", submission.Round, assignment.Title)
+	if err := s.notifier.Notify(ctx, request.MentorID, models.NotificationType.AssignmentSubmitted, "Assignment submitted", message); err != nil {
+		log.Printf("Failed to notify mentor %d of submission %d: %v", request.MentorID, submission.ID, err)
+	}
+
+	return nil
+}
+
+// ListSubmissions returns every round a mentorship submitted for an assignment
+func (s *AssignmentService) ListSubmissions(ctx context.Context, userID, assignmentID, requestID int) ([]*models.Submission, error) {
+	if _, err := s.participantRequest(ctx, userID, requestID); err != nil {
+		return nil, err
+	}
+	return s.assignmentRepo.ListSubmissions(ctx, assignmentID, requestID)
+}
+
+// OpenSubmissionFile returns the file attached to a submission to either participant
+func (s *AssignmentService) OpenSubmissionFile(ctx context.Context, userID, submissionID int) (*models.Submission, io.ReadCloser, error) {
+	submission, err := s.assignmentRepo.GetSubmission(ctx, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.participantRequest(ctx, userID, submission.RequestID); err != nil {
+		return nil, nil, err
+	}
+	if submission.FileKey == "" {
+		return nil, nil, errors.New("submission has no attached file")
+	}
+
+	content, err := s.files.Open(ctx, submission.FileKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return submission, content, nil
+}
+
+// Review scores a submission against the assignment's rubric and either
+// approves it or asks for another round
+func (s *AssignmentService) Review(ctx context.Context, mentorID, submissionID int, review *models.SubmissionReview) (*models.Submission, error) {
+	submission, err := s.assignmentRepo.GetSubmission(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, submission.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || request.MentorID != mentorID {
+		return nil, errors.New("unauthorized: only the mentor can review this submission")
+	}
+
+	assignment, err := s.assignmentRepo.GetAssignment(ctx, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch review.Decision {
+	case models.SubmissionStatus.Approved, models.SubmissionStatus.ChangesRequested:
+	default:
+		return nil, errors.New("decision must be approved or changes_requested")
+	}
+
+	if err := scoreReview(assignment, review); err != nil {
+		return nil, err
+	}
+
+	review.SubmissionID = submissionID
+	review.ReviewerID = mentorID
+	review.Comment = strings.TrimSpace(review.Comment)
+
+	if err := s.assignmentRepo.CreateReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	submission.Status = review.Decision
+	submission.Review = review
+
+	title := "Assignment approved"
+	message := fmt.Sprintf("Your mentor approved %q.", assignment.Title)
+	if review.Decision == models.SubmissionStatus.ChangesRequested {
+		title = "Changes requested"
+		message = fmt.Sprintf("Your mentor requested changes to %q.", assignment.Title)
+	}
+	if err := s.notifier.Notify(ctx, submission.MenteeID, models.NotificationType.AssignmentReviewed, title, message); err != nil {
+		log.Printf("Failed to notify mentee %d of review %d: %v", submission.MenteeID, review.ID, err)
+	}
+
+	return submission, nil
+}
+
+// ListPendingReviews returns the mentor's review queue
+func (s *AssignmentService) ListPendingReviews(ctx context.Context, mentorID int) ([]*models.PendingReview, error) {
+	return s.assignmentRepo.ListPendingReviews(ctx, mentorID)
+}
+
+func (s *AssignmentService) participantRequest(ctx context.Context, userID, requestID int) (*models.MentorshipRequest, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	return request, nil
+}
+
+// mentorAssignment loads an assignment the mentor created
+func (s *AssignmentService) mentorAssignment(ctx context.Context, mentorID, assignmentID int) (*models.Assignment, error) {
+	assignment, err := s.assignmentRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.CreatedBy != mentorID {
+		return nil, errors.New("unauthorized: assignment belongs to another mentor")
+	}
+	return assignment, nil
+}
+
+// appliesTo reports whether an assignment was set for the mentorship or its program
+func appliesTo(assignment *models.Assignment, request *models.MentorshipRequest) bool {
+	if assignment.RequestID != nil {
+		return *assignment.RequestID == request.ID
+	}
+	return assignment.ProgramID != nil && *assignment.ProgramID == request.ProgramID
+}
+
+func normalizeAssignment(assignment *models.Assignment) error {
+	assignment.Title = strings.TrimSpace(assignment.Title)
+	if assignment.Title == "" {
+		return errors.New("assignment title is required")
+	}
+	assignment.Instructions = strings.TrimSpace(assignment.Instructions)
+	if assignment.MaxResubmissions < 0 {
+		return errors.New("max resubmissions cannot be negative")
+	}
+
+	seen := make(map[string]bool, len(assignment.Rubric))
+	for i := range assignment.Rubric {
+		criterion := &assignment.Rubric[i]
+		criterion.Name = strings.TrimSpace(criterion.Name)
+		if criterion.Name == "" {
+			return errors.New("every rubric criterion needs a name")
+		}
+		if seen[criterion.Name] {
+			return fmt.Errorf("duplicate rubric criterion %q", criterion.Name)
+		}
+		seen[criterion.Name] = true
+		if criterion.MaxPoints < 1 {
+			return fmt.Errorf("rubric criterion %q needs at least one point", criterion.Name)
+		}
+	}
+	if assignment.Rubric == nil {
+		assignment.Rubric = []models.RubricCriterion{}
+	}
+	return nil
+}
+
+// scoreReview checks the scores against the rubric, one per criterion, and totals them
+func scoreReview(assignment *models.Assignment, review *models.SubmissionReview) error {
+	maxPoints := make(map[string]int, len(assignment.Rubric))
+	for _, criterion := range assignment.Rubric {
+		maxPoints[criterion.Name] = criterion.MaxPoints
+	}
+
+	scored := make(map[string]bool, len(review.Scores))
+	total := 0
+	for _, score := range review.Scores {
+		max, ok := maxPoints[score.Criterion]
+		if !ok {
+			return fmt.Errorf("unknown rubric criterion %q", score.Criterion)
+		}
+		if scored[score.Criterion] {
+			return fmt.Errorf("criterion %q scored more than once", score.Criterion)
+		}
+		if score.Points < 0 || score.Points > max {
+			return fmt.Errorf("points for %q must be between 0 and %d", score.Criterion, max)
+		}
+		scored[score.Criterion] = true
+		total += score.Points
+	}
+	if len(scored) != len(maxPoints) {
+		return errors.New("every rubric criterion must be scored")
+	}
+
+	if review.Scores == nil {
+		review.Scores = []models.RubricScore{}
+	}
+	review.TotalScore = total
+	review.MaxScore = assignment.MaxScore()
+	return nil
+}
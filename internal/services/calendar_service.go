@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/pkg/utils/ical"
+)
+
+const (
+	// calendarSyncWindow is how far ahead busy blocks are imported
+	calendarSyncWindow = 60 * 24 * time.Hour
+	// maxCalendarSize caps how much of a remote calendar we are willing to read
+	maxCalendarSize = 5 << 20
+)
+
+// errCalendarAddressBlocked is returned for calendar URLs that point at this
+// server or the network it runs in
+var errCalendarAddressBlocked = errors.New("calendar URL must point to a public address")
+
+type CalendarService struct {
+	calendarRepo          repository.ICalendarRepository
+	profileRepo           repository.IProfileRepository
+	userRepo              repository.IUserRepository
+	client                *http.Client
+	allowPrivateAddresses bool
+}
+
+// NewCalendarService creates the calendar service. Feeds on loopback, private
+// and link-local addresses are refused unless allowPrivateAddresses is set,
+// which is only meant for local testing. A nil client gets one that enforces
+// this on every connection, redirects included.
+func NewCalendarService(
+	calendarRepo repository.ICalendarRepository,
+	profileRepo repository.IProfileRepository,
+	userRepo repository.IUserRepository,
+	client *http.Client,
+	allowPrivateAddresses bool,
+) ICalendarService {
+	if client == nil {
+		client = newCalendarClient(allowPrivateAddresses)
+	}
+	return &CalendarService{
+		calendarRepo:          calendarRepo,
+		profileRepo:           profileRepo,
+		userRepo:              userRepo,
+		client:                client,
+		allowPrivateAddresses: allowPrivateAddresses,
+	}
+}
+
+// newCalendarClient returns an HTTP client whose dialer checks the resolved
+// address of every connection, so neither DNS tricks nor redirects can reach
+// internal services
+func newCalendarClient(allowPrivateAddresses bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivateAddresses {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isBlockedAddress(ip) {
+				return fmt.Errorf("%w: %s", errCalendarAddressBlocked, host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialled instead of the feed host and defeat the check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: 30 * time.Second, Transport: transport}
+}
+
+// isBlockedAddress reports whether ip is one calendar feeds may not point at
+func isBlockedAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// SetFeed configures the CalDAV or ICS calendar a mentor's busy times come from
+func (s *CalendarService) SetFeed(ctx context.Context, mentorID int, rawURL, feedType string) (*models.CalendarFeed, error) {
+	user, err := s.userRepo.GetUserByID(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsMentor {
+		return nil, errors.New("user is not a mentor")
+	}
+
+	if feedType == "" {
+		feedType = models.CalendarFeedType.ICS
+	}
+	if feedType != models.CalendarFeedType.ICS && feedType != models.CalendarFeedType.CalDAV {
+		return nil, fmt.Errorf("unsupported calendar feed type %q", feedType)
+	}
+
+	feedURL, err := normalizeCalendarURL(rawURL, s.allowPrivateAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &models.CalendarFeed{
+		MentorID: mentorID,
+		URL:      feedURL,
+		FeedType: feedType,
+		Enabled:  true,
+	}
+	if err := s.calendarRepo.UpsertFeed(ctx, feed); err != nil {
+		return nil, err
+	}
+
+	// Import right away so the mentor sees the effect without waiting for the next run
+	if err := s.syncFeed(ctx, feed); err != nil {
+		log.Printf("Initial calendar sync failed for mentor %d: %v", mentorID, err)
+		feed.LastError = err.Error()
+	}
+
+	return feed, nil
+}
+
+// GetFeed returns the calendar feed configured for a mentor
+func (s *CalendarService) GetFeed(ctx context.Context, mentorID int) (*models.CalendarFeed, error) {
+	return s.calendarRepo.GetFeedByMentor(ctx, mentorID)
+}
+
+// RemoveFeed disconnects a mentor's calendar and forgets its busy blocks
+func (s *CalendarService) RemoveFeed(ctx context.Context, mentorID int) error {
+	return s.calendarRepo.DeleteFeed(ctx, mentorID)
+}
+
+// SyncMentor re-imports busy blocks for a single mentor
+func (s *CalendarService) SyncMentor(ctx context.Context, mentorID int) error {
+	feed, err := s.calendarRepo.GetFeedByMentor(ctx, mentorID)
+	if err != nil {
+		return err
+	}
+	return s.syncFeed(ctx, feed)
+}
+
+// SyncAll re-imports busy blocks for every enabled feed. A failing feed is
+// recorded and skipped so one broken calendar does not block the rest.
+func (s *CalendarService) SyncAll(ctx context.Context) error {
+	feeds, err := s.calendarRepo.ListEnabledFeeds(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, feed := range feeds {
+		if err := s.syncFeed(ctx, feed); err != nil {
+			log.Printf("Calendar sync failed for mentor %d: %v", feed.MentorID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d calendar feeds failed to sync", failed, len(feeds))
+	}
+	return nil
+}
+
+// Run syncs all feeds immediately and then on every interval until ctx is cancelled
+func (s *CalendarService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SyncAll(ctx); err != nil {
+			log.Printf("Calendar sync: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *CalendarService) syncFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	from := time.Now().UTC().Truncate(time.Hour)
+	to := from.Add(calendarSyncWindow)
+
+	loc := time.UTC
+	profile, err := s.profileRepo.GetProfileByUserID(ctx, feed.MentorID)
+	if err == nil && profile != nil && profile.Timezone != "" {
+		if tz, err := time.LoadLocation(profile.Timezone); err == nil {
+			loc = tz
+		}
+	}
+
+	periods, err := s.fetchBusy(ctx, feed, from, to, loc)
+	var unexpanded *ical.RecurrenceError
+	if err != nil && !errors.As(err, &unexpanded) {
+		if recErr := s.calendarRepo.RecordSyncError(ctx, feed.ID, err.Error()); recErr != nil {
+			log.Printf("Failed to record calendar sync error: %v", recErr)
+		}
+		return err
+	}
+
+	blocks := make([]*models.BusyBlock, 0, len(periods))
+	for _, p := range periods {
+		blocks = append(blocks, &models.BusyBlock{
+			FeedID:    feed.ID,
+			MentorID:  feed.MentorID,
+			StartTime: p.Start,
+			EndTime:   p.End,
+		})
+	}
+
+	if err := s.calendarRepo.ReplaceBusyBlocks(ctx, feed, blocks); err != nil {
+		return err
+	}
+
+	// Everything else was imported; the mentor still needs to know which
+	// recurring events only block their first occurrence
+	if unexpanded != nil {
+		log.Printf("Calendar sync for mentor %d was partial: %v", feed.MentorID, unexpanded)
+		if recErr := s.calendarRepo.RecordSyncError(ctx, feed.ID, unexpanded.Error()); recErr != nil {
+			log.Printf("Failed to record calendar sync error: %v", recErr)
+		}
+		return unexpanded
+	}
+	return nil
+}
+
+// fetchBusy downloads a feed and extracts its busy periods. ICS feeds are
+// fetched with GET; CalDAV collections are asked for a free-busy report.
+func (s *CalendarService) fetchBusy(ctx context.Context, feed *models.CalendarFeed, from, to time.Time, loc *time.Location) ([]ical.Period, error) {
+	var req *http.Request
+	var err error
+
+	if feed.FeedType == models.CalendarFeedType.CalDAV {
+		body := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8" ?>
+<C:free-busy-query xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <C:time-range start="%s" end="%s"/>
+</C:free-busy-query>`, from.Format("20060102T150405Z"), to.Format("20060102T150405Z"))
+
+		req, err = http.NewRequestWithContext(ctx, "REPORT", feed.URL, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+		req.Header.Set("Depth", "1")
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("calendar server returned %s", resp.Status)
+	}
+
+	return ical.ParseBusy(io.LimitReader(resp.Body, maxCalendarSize), from, to, loc)
+}
+
+// normalizeCalendarURL validates a feed URL. Hosts that are obviously local
+// are refused up front; names that resolve to one are caught when dialling.
+func normalizeCalendarURL(rawURL string, allowPrivateAddresses bool) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid calendar URL: %w", err)
+	}
+
+	// webcal:// is the conventional scheme for subscribable ICS feeds
+	if u.Scheme == "webcal" {
+		u.Scheme = "https"
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.New("calendar URL must use http, https or webcal")
+	}
+	if u.Host == "" {
+		return "", errors.New("calendar URL must include a host")
+	}
+	if !allowPrivateAddresses {
+		host := strings.ToLower(u.Hostname())
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return "", errCalendarAddressBlocked
+		}
+		if ip := net.ParseIP(host); ip != nil && isBlockedAddress(ip) {
+			return "", errCalendarAddressBlocked
+		}
+	}
+
+	return u.String(), nil
+}
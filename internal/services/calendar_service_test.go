@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+func serveCalendar(t *testing.T, start time.Time) *httptest.Server {
+	t.Helper()
+	body := fmt.Sprintf("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:busy-1\r\nDTSTART:%s\r\nDTEND:%s\r\nSUMMARY:Busy\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		start.Format("20060102T150405Z"), start.Add(time.Hour).Format("20060102T150405Z"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchBusyParsesFeed(t *testing.T) {
+	from := time.Now().UTC().Truncate(time.Hour)
+	start := from.Add(24 * time.Hour)
+	server := serveCalendar(t, start)
+
+	s := &CalendarService{client: newCalendarClient(true), allowPrivateAddresses: true}
+	feed := &models.CalendarFeed{URL: server.URL, FeedType: models.CalendarFeedType.ICS}
+
+	periods, err := s.fetchBusy(context.Background(), feed, from, from.Add(calendarSyncWindow), time.UTC)
+	if err != nil {
+		t.Fatalf("fetchBusy: %v", err)
+	}
+	if len(periods) != 1 {
+		t.Fatalf("got %d busy periods, want 1", len(periods))
+	}
+	if !periods[0].Start.Equal(start) || !periods[0].End.Equal(start.Add(time.Hour)) {
+		t.Errorf("got busy period %v to %v, want %v to %v", periods[0].Start, periods[0].End, start, start.Add(time.Hour))
+	}
+}
+
+func TestFetchBusyRefusesPrivateAddresses(t *testing.T) {
+	from := time.Now().UTC().Truncate(time.Hour)
+	server := serveCalendar(t, from.Add(24*time.Hour))
+
+	s := &CalendarService{client: newCalendarClient(false)}
+	feed := &models.CalendarFeed{URL: server.URL, FeedType: models.CalendarFeedType.ICS}
+
+	_, err := s.fetchBusy(context.Background(), feed, from, from.Add(calendarSyncWindow), time.UTC)
+	if !errors.Is(err, errCalendarAddressBlocked) {
+		t.Fatalf("got error %v, want %v", err, errCalendarAddressBlocked)
+	}
+}
+
+func TestFetchBusyRefusesRedirectToPrivateAddress(t *testing.T) {
+	from := time.Now().UTC().Truncate(time.Hour)
+	target := serveCalendar(t, from.Add(24*time.Hour))
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	t.Cleanup(redirect.Close)
+
+	// The first hop skips the guard, so only the redirect target is checked
+	client := newCalendarClient(false)
+	client.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address == redirect.Listener.Addr().String() {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		}
+		return newCalendarClient(false).Transport.(*http.Transport).DialContext(ctx, network, address)
+	}
+
+	s := &CalendarService{client: client}
+	feed := &models.CalendarFeed{URL: redirect.URL, FeedType: models.CalendarFeedType.ICS}
+
+	_, err := s.fetchBusy(context.Background(), feed, from, from.Add(calendarSyncWindow), time.UTC)
+	if !errors.Is(err, errCalendarAddressBlocked) {
+		t.Fatalf("got error %v, want %v", err, errCalendarAddressBlocked)
+	}
+}
+
+func TestNormalizeCalendarURL(t *testing.T) {
+	tests := []struct {
+		raw          string
+		allowPrivate bool
+		want         string
+		wantErr      error
+	}{
+		{raw: "webcal://calendar.example.com/feed.ics", want: "https://calendar.example.com/feed.ics"},
+		{raw: "https://93.184.216.34/feed.ics", want: "https://93.184.216.34/feed.ics"},
+		{raw: "http://localhost:8080/feed.ics", wantErr: errCalendarAddressBlocked},
+		{raw: "http://127.0.0.1/feed.ics", wantErr: errCalendarAddressBlocked},
+		{raw: "http://10.0.0.5/feed.ics", wantErr: errCalendarAddressBlocked},
+		{raw: "http://169.254.169.254/latest/meta-data", wantErr: errCalendarAddressBlocked},
+		{raw: "http://[::1]/feed.ics", wantErr: errCalendarAddressBlocked},
+		{raw: "http://0.0.0.0/feed.ics", wantErr: errCalendarAddressBlocked},
+		{raw: "http://127.0.0.1/feed.ics", allowPrivate: true, want: "http://127.0.0.1/feed.ics"},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeCalendarURL(tt.raw, tt.allowPrivate)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("normalizeCalendarURL(%q) error = %v, want %v", tt.raw, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalizeCalendarURL(%q): %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeCalendarURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
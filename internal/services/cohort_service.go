@@ -0,0 +1,428 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type CohortService struct {
+	cohortRepo     repository.ICohortRepository
+	mentorshipRepo repository.IMentorshipRepository
+	userRepo       repository.IUserRepository
+	notifier       INotificationService
+}
+
+func NewCohortService(
+	cohortRepo repository.ICohortRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	userRepo repository.IUserRepository,
+	notifier INotificationService,
+) ICohortService {
+	return &CohortService{
+		cohortRepo:     cohortRepo,
+		mentorshipRepo: mentorshipRepo,
+		userRepo:       userRepo,
+		notifier:       notifier,
+	}
+}
+
+// CreateCohort schedules a new run of one of the mentor's programs. The
+// program's MaxMentees is used as the capacity unless one is given.
+func (s *CohortService) CreateCohort(ctx context.Context, mentorID, programID int, cohort *models.Cohort) error {
+	program, err := s.mentorshipRepo.GetProgram(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if program.MentorID != mentorID {
+		return errors.New("unauthorized: program belongs to another mentor")
+	}
+	if program.Status != models.ProgramStatus.Active {
+		return errors.New("cohorts can only be added to active programs")
+	}
+
+	cohort.Name = strings.TrimSpace(cohort.Name)
+	if cohort.Name == "" {
+		return errors.New("cohort name is required")
+	}
+	if !cohort.EnrollmentClosesAt.After(cohort.EnrollmentOpensAt) {
+		return errors.New("enrollment must close after it opens")
+	}
+	if cohort.EndsOn.Before(cohort.StartsOn) {
+		return errors.New("cohort cannot end before it starts")
+	}
+	if cohort.EnrollmentOpensAt.After(cohort.StartsOn) {
+		return errors.New("enrollment must open before the cohort starts")
+	}
+	if cohort.Capacity == 0 {
+		cohort.Capacity = program.MaxMentees
+	}
+	if cohort.Capacity < 1 {
+		return errors.New("cohort capacity must be at least 1")
+	}
+
+	cohort.ProgramID = programID
+	cohort.MentorID = mentorID
+	cohort.Status = models.CohortStatus.Active
+
+	if err := s.cohortRepo.CreateCohort(ctx, cohort); err != nil {
+		return err
+	}
+
+	cohort.Phase = cohort.CurrentPhase(time.Now())
+	return nil
+}
+
+// GetCohort returns a cohort with its enrollment count and phase
+func (s *CohortService) GetCohort(ctx context.Context, cohortID int) (*models.Cohort, error) {
+	cohort, err := s.cohortRepo.GetCohort(ctx, cohortID)
+	if err != nil {
+		return nil, err
+	}
+
+	cohort.Phase = cohort.CurrentPhase(time.Now())
+	return cohort, nil
+}
+
+// ListProgramCohorts returns every cohort of a program
+func (s *CohortService) ListProgramCohorts(ctx context.Context, programID int) ([]*models.Cohort, error) {
+	cohorts, err := s.cohortRepo.ListProgramCohorts(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	setPhases(cohorts)
+	return cohorts, nil
+}
+
+// CancelCohort calls off a cohort and lets everyone enrolled know
+func (s *CohortService) CancelCohort(ctx context.Context, mentorID, cohortID int) error {
+	cohort, err := s.mentorCohort(ctx, mentorID, cohortID)
+	if err != nil {
+		return err
+	}
+	if cohort.Status == models.CohortStatus.Cancelled {
+		return errors.New("cohort is already cancelled")
+	}
+
+	if err := s.cohortRepo.UpdateCohortStatus(ctx, cohortID, models.CohortStatus.Cancelled); err != nil {
+		return err
+	}
+
+	s.notifyMembers(ctx, cohortID, models.NotificationType.CohortCancelled,
+		"Cohort cancelled",
+		fmt.Sprintf("The cohort %q has been cancelled by the mentor.", cohort.Name))
+	return nil
+}
+
+// Enroll takes a seat in a cohort while its enrollment window is open
+func (s *CohortService) Enroll(ctx context.Context, menteeID, cohortID int) error {
+	mentee, err := s.userRepo.GetUserByID(ctx, menteeID)
+	if err != nil {
+		return err
+	}
+	if mentee.IsMentor {
+		return errors.New("mentors cannot enroll in cohorts")
+	}
+
+	cohort, err := s.cohortRepo.GetCohort(ctx, cohortID)
+	if err != nil {
+		return err
+	}
+	if !cohort.EnrollmentOpen(time.Now()) {
+		return errors.New("enrollment for this cohort is not open")
+	}
+
+	return s.cohortRepo.Enroll(ctx, cohortID, menteeID)
+}
+
+// Withdraw gives up a mentee's seat before the cohort has finished
+func (s *CohortService) Withdraw(ctx context.Context, menteeID, cohortID int) error {
+	cohort, err := s.cohortRepo.GetCohort(ctx, cohortID)
+	if err != nil {
+		return err
+	}
+	if cohort.CurrentPhase(time.Now()) == models.CohortPhase.Finished {
+		return errors.New("cannot withdraw from a finished cohort")
+	}
+
+	return s.cohortRepo.Withdraw(ctx, cohortID, menteeID)
+}
+
+// ListMenteeCohorts returns the cohorts a mentee is enrolled in
+func (s *CohortService) ListMenteeCohorts(ctx context.Context, menteeID int) ([]*models.Cohort, error) {
+	cohorts, err := s.cohortRepo.ListMenteeCohorts(ctx, menteeID)
+	if err != nil {
+		return nil, err
+	}
+
+	setPhases(cohorts)
+	return cohorts, nil
+}
+
+// GetRoster returns a cohort's members with their attendance. Members see
+// each other's names; contact details are only shown to the mentor.
+func (s *CohortService) GetRoster(ctx context.Context, userID, cohortID int) ([]*models.CohortMember, error) {
+	isMentor, err := s.cohortAccess(ctx, userID, cohortID)
+	if err != nil {
+		return nil, err
+	}
+
+	roster, err := s.cohortRepo.ListRoster(ctx, cohortID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMentor {
+		enrolled := roster[:0]
+		for _, member := range roster {
+			if member.Status != models.CohortMemberStatus.Enrolled {
+				continue
+			}
+			member.Email = ""
+			enrolled = append(enrolled, member)
+		}
+		roster = enrolled
+	}
+
+	return roster, nil
+}
+
+// ScheduleSession adds a group session to a cohort and invites its members
+func (s *CohortService) ScheduleSession(ctx context.Context, mentorID, cohortID int, session *models.CohortSession) error {
+	cohort, err := s.mentorCohort(ctx, mentorID, cohortID)
+	if err != nil {
+		return err
+	}
+	if cohort.Status == models.CohortStatus.Cancelled {
+		return errors.New("cannot schedule sessions for a cancelled cohort")
+	}
+
+	session.Title = strings.TrimSpace(session.Title)
+	if session.Title == "" {
+		return errors.New("session title is required")
+	}
+	if !session.EndTime.After(session.StartTime) {
+		return errors.New("session must end after it starts")
+	}
+	if session.StartTime.Before(time.Now()) {
+		return errors.New("cannot schedule a session in the past")
+	}
+
+	session.CohortID = cohortID
+	session.Status = models.SessionStatus.Scheduled
+
+	if err := s.cohortRepo.CreateSession(ctx, session); err != nil {
+		return err
+	}
+
+	s.notifyMembers(ctx, cohortID, models.NotificationType.CohortSession,
+		"New group session",
+		fmt.Sprintf("%q for %s starts %s.", session.Title, cohort.Name, session.StartTime.Format("Mon Jan 2 15:04 MST")))
+	return nil
+}
+
+// CancelSession calls off an upcoming group session
+func (s *CohortService) CancelSession(ctx context.Context, mentorID, sessionID int) error {
+	session, err := s.cohortRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	cohort, err := s.mentorCohort(ctx, mentorID, session.CohortID)
+	if err != nil {
+		return err
+	}
+	if session.Status != models.SessionStatus.Scheduled {
+		return errors.New("only scheduled sessions can be cancelled")
+	}
+
+	if err := s.cohortRepo.UpdateSessionStatus(ctx, sessionID, models.SessionStatus.Cancelled); err != nil {
+		return err
+	}
+
+	s.notifyMembers(ctx, cohort.ID, models.NotificationType.CohortSession,
+		"Group session cancelled",
+		fmt.Sprintf("%q for %s has been cancelled.", session.Title, cohort.Name))
+	return nil
+}
+
+// ListSessions returns a cohort's group sessions to its mentor or members
+func (s *CohortService) ListSessions(ctx context.Context, userID, cohortID int) ([]*models.CohortSession, error) {
+	if _, err := s.cohortAccess(ctx, userID, cohortID); err != nil {
+		return nil, err
+	}
+	return s.cohortRepo.ListSessions(ctx, cohortID)
+}
+
+// GetSession returns a group session; mentees only see their own attendance
+func (s *CohortService) GetSession(ctx context.Context, userID, sessionID int) (*models.CohortSession, error) {
+	session, err := s.cohortRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	isMentor, err := s.cohortAccess(ctx, userID, session.CohortID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMentor {
+		own := []*models.AttendanceRecord{}
+		for _, record := range session.Attendance {
+			if record.MenteeID == userID {
+				own = append(own, record)
+			}
+		}
+		session.Attendance = own
+	}
+
+	return session, nil
+}
+
+// RecordAttendance saves who attended a group session once it has started and
+// marks the session completed
+func (s *CohortService) RecordAttendance(ctx context.Context, mentorID, sessionID int, records []*models.AttendanceRecord) (*models.CohortSession, error) {
+	session, err := s.cohortRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.mentorCohort(ctx, mentorID, session.CohortID); err != nil {
+		return nil, err
+	}
+	if session.Status == models.SessionStatus.Cancelled {
+		return nil, errors.New("cannot record attendance for a cancelled session")
+	}
+	if session.StartTime.After(time.Now()) {
+		return nil, errors.New("attendance can only be recorded once the session has started")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no attendance records given")
+	}
+
+	members, err := s.cohortRepo.ListEnrolledMenteeIDs(ctx, session.CohortID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled := make(map[int]bool, len(members))
+	for _, id := range members {
+		enrolled[id] = true
+	}
+
+	for _, record := range records {
+		if !enrolled[record.MenteeID] {
+			return nil, fmt.Errorf("mentee %d is not enrolled in this cohort", record.MenteeID)
+		}
+		switch record.Status {
+		case models.AttendanceStatus.Present, models.AttendanceStatus.Absent, models.AttendanceStatus.Excused:
+		default:
+			return nil, errors.New("attendance status must be present, absent or excused")
+		}
+		record.SessionID = sessionID
+	}
+
+	if err := s.cohortRepo.RecordAttendance(ctx, records); err != nil {
+		return nil, err
+	}
+
+	if session.Status == models.SessionStatus.Scheduled {
+		if err := s.cohortRepo.UpdateSessionStatus(ctx, sessionID, models.SessionStatus.Completed); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.cohortRepo.GetSession(ctx, sessionID)
+}
+
+// PostAnnouncement sends a message from the mentor to the whole cohort
+func (s *CohortService) PostAnnouncement(ctx context.Context, mentorID, cohortID int, announcement *models.CohortAnnouncement) error {
+	cohort, err := s.mentorCohort(ctx, mentorID, cohortID)
+	if err != nil {
+		return err
+	}
+
+	announcement.Title = strings.TrimSpace(announcement.Title)
+	announcement.Body = strings.TrimSpace(announcement.Body)
+	if announcement.Title == "" || announcement.Body == "" {
+		return errors.New("announcement title and body are required")
+	}
+
+	announcement.CohortID = cohortID
+	announcement.AuthorID = mentorID
+
+	if err := s.cohortRepo.CreateAnnouncement(ctx, announcement); err != nil {
+		return err
+	}
+
+	s.notifyMembers(ctx, cohortID, models.NotificationType.CohortAnnouncement,
+		fmt.Sprintf("%s: %s", cohort.Name, announcement.Title),
+		announcement.Body)
+	return nil
+}
+
+// ListAnnouncements returns a cohort's announcements to its mentor or members
+func (s *CohortService) ListAnnouncements(ctx context.Context, userID, cohortID int) ([]*models.CohortAnnouncement, error) {
+	if _, err := s.cohortAccess(ctx, userID, cohortID); err != nil {
+		return nil, err
+	}
+	return s.cohortRepo.ListAnnouncements(ctx, cohortID)
+}
+
+// mentorCohort loads a cohort and checks that it is run by the given mentor
+func (s *CohortService) mentorCohort(ctx context.Context, mentorID, cohortID int) (*models.Cohort, error) {
+	cohort, err := s.cohortRepo.GetCohort(ctx, cohortID)
+	if err != nil {
+		return nil, err
+	}
+	if cohort.MentorID != mentorID {
+		return nil, errors.New("unauthorized: cohort belongs to another mentor")
+	}
+	return cohort, nil
+}
+
+// cohortAccess checks that the user runs or is enrolled in the cohort and
+// reports whether they are its mentor
+func (s *CohortService) cohortAccess(ctx context.Context, userID, cohortID int) (bool, error) {
+	cohort, err := s.cohortRepo.GetCohort(ctx, cohortID)
+	if err != nil {
+		return false, err
+	}
+	if cohort.MentorID == userID {
+		return true, nil
+	}
+
+	enrolled, err := s.cohortRepo.IsEnrolled(ctx, cohortID, userID)
+	if err != nil {
+		return false, err
+	}
+	if !enrolled {
+		return false, errors.New("unauthorized: user not part of this cohort")
+	}
+	return false, nil
+}
+
+func (s *CohortService) notifyMembers(ctx context.Context, cohortID int, notificationType, title, message string) {
+	members, err := s.cohortRepo.ListEnrolledMenteeIDs(ctx, cohortID)
+	if err != nil {
+		log.Printf("Failed to load members of cohort %d for notification: %v", cohortID, err)
+		return
+	}
+
+	for _, menteeID := range members {
+		if err := s.notifier.Notify(ctx, menteeID, notificationType, title, message); err != nil {
+			log.Printf("Failed to notify mentee %d of cohort %d: %v", menteeID, cohortID, err)
+		}
+	}
+}
+
+func setPhases(cohorts []*models.Cohort) {
+	now := time.Now()
+	for _, cohort := range cohorts {
+		cohort.Phase = cohort.CurrentPhase(now)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ContentFilter inspects user-written text that may be shown publicly.
+// Implementations are run over review comments and mentor replies.
+type ContentFilter interface {
+	// Check returns a short reason for each problem found, or nothing if the text is clean
+	Check(text string) []string
+}
+
+// defaultProfanity is a deliberately small starter list; deployments can pass their own
+var defaultProfanity = []string{
+	"asshole", "bastard", "bitch", "bullshit", "crap", "damn", "dick", "fuck", "fucking", "shit", "slut", "whore",
+}
+
+// ProfanityFilter flags text containing words from a block list
+type ProfanityFilter struct {
+	words map[string]bool
+}
+
+// NewProfanityFilter builds a filter from the given words, or the default list when none are given
+func NewProfanityFilter(words []string) *ProfanityFilter {
+	if len(words) == 0 {
+		words = defaultProfanity
+	}
+
+	f := &ProfanityFilter{words: make(map[string]bool, len(words))}
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			f.words[w] = true
+		}
+	}
+	return f
+}
+
+func (f *ProfanityFilter) Check(text string) []string {
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, token := range tokens {
+		if f.words[token] {
+			return []string{"profanity"}
+		}
+	}
+	return nil
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(?:\+?\d[\s.\-()]*){9,}\d`)
+	urlPattern   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+)
+
+// PIIFilter flags contact details that should not be published in a review
+type PIIFilter struct{}
+
+func NewPIIFilter() *PIIFilter {
+	return &PIIFilter{}
+}
+
+func (f *PIIFilter) Check(text string) []string {
+	var reasons []string
+	if emailPattern.MatchString(text) {
+		reasons = append(reasons, "email_address")
+	}
+	if phonePattern.MatchString(text) {
+		reasons = append(reasons, "phone_number")
+	}
+	if urlPattern.MatchString(text) {
+		reasons = append(reasons, "link")
+	}
+	return reasons
+}
+
+// screenText runs every filter over text and returns the combined reasons
+func screenText(filters []ContentFilter, text string) []string {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	var reasons []string
+	for _, filter := range filters {
+		reasons = append(reasons, filter.Check(text)...)
+	}
+	return reasons
+}
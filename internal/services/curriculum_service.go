@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type CurriculumService struct {
+	curriculumRepo repository.ICurriculumRepository
+	mentorshipRepo repository.IMentorshipRepository
+	files          FileStore
+}
+
+func NewCurriculumService(
+	curriculumRepo repository.ICurriculumRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	files FileStore,
+) ICurriculumService {
+	return &CurriculumService{
+		curriculumRepo: curriculumRepo,
+		mentorshipRepo: mentorshipRepo,
+		files:          files,
+	}
+}
+
+// GetDraft returns the program's editable draft, starting one from the latest
+// published version if there is no draft yet
+func (s *CurriculumService) GetDraft(ctx context.Context, mentorID, programID int) (*models.CurriculumVersion, error) {
+	if err := s.checkProgramOwner(ctx, mentorID, programID); err != nil {
+		return nil, err
+	}
+
+	draft, err := s.curriculumRepo.GetDraft(ctx, programID)
+	if errors.Is(err, repository.ErrCurriculumNotFound) {
+		return s.curriculumRepo.CreateDraft(ctx, programID, mentorID)
+	}
+	return draft, err
+}
+
+// ListVersions returns the history of a program's curriculum
+func (s *CurriculumService) ListVersions(ctx context.Context, mentorID, programID int) ([]*models.CurriculumVersion, error) {
+	if err := s.checkProgramOwner(ctx, mentorID, programID); err != nil {
+		return nil, err
+	}
+	return s.curriculumRepo.ListVersions(ctx, programID)
+}
+
+// GetVersion returns any version of one of the mentor's curricula
+func (s *CurriculumService) GetVersion(ctx context.Context, mentorID, versionID int) (*models.CurriculumVersion, error) {
+	return s.mentorVersion(ctx, mentorID, versionID, false)
+}
+
+// Publish freezes a draft; mentorships that start afterwards follow it while
+// existing ones keep the version they were pinned to
+func (s *CurriculumService) Publish(ctx context.Context, mentorID, versionID int) (*models.CurriculumVersion, error) {
+	version, err := s.mentorVersion(ctx, mentorID, versionID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(version.Modules) == 0 {
+		return nil, errors.New("a curriculum needs at least one module before it can be published")
+	}
+
+	if err := s.curriculumRepo.PublishVersion(ctx, versionID); err != nil {
+		return nil, err
+	}
+
+	return s.curriculumRepo.GetVersion(ctx, versionID)
+}
+
+// AddModule appends a module to a draft
+func (s *CurriculumService) AddModule(ctx context.Context, mentorID, versionID int, module *models.CurriculumModule) error {
+	version, err := s.mentorVersion(ctx, mentorID, versionID, true)
+	if err != nil {
+		return err
+	}
+	if err := normalizeModule(module); err != nil {
+		return err
+	}
+
+	module.VersionID = versionID
+	if module.Position == 0 {
+		module.Position = len(version.Modules) + 1
+	}
+
+	if err := s.curriculumRepo.CreateModule(ctx, module); err != nil {
+		return err
+	}
+
+	module.Resources = []*models.ModuleResource{}
+	return nil
+}
+
+// UpdateModule replaces a draft module's content
+func (s *CurriculumService) UpdateModule(ctx context.Context, mentorID, moduleID int, update *models.CurriculumModule) (*models.CurriculumModule, error) {
+	module, err := s.draftModule(ctx, mentorID, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeModule(update); err != nil {
+		return nil, err
+	}
+
+	module.Title = update.Title
+	module.Summary = update.Summary
+	module.Objectives = update.Objectives
+	module.Assignment = update.Assignment
+	if update.Position != 0 {
+		module.Position = update.Position
+	}
+
+	if err := s.curriculumRepo.UpdateModule(ctx, module); err != nil {
+		return nil, err
+	}
+
+	return module, nil
+}
+
+// DeleteModule removes a module from a draft
+func (s *CurriculumService) DeleteModule(ctx context.Context, mentorID, moduleID int) error {
+	if _, err := s.draftModule(ctx, mentorID, moduleID); err != nil {
+		return err
+	}
+	return s.curriculumRepo.DeleteModule(ctx, moduleID)
+}
+
+// ReorderModules sets the order of a draft's modules; every module must be listed once
+func (s *CurriculumService) ReorderModules(ctx context.Context, mentorID, versionID int, moduleIDs []int) error {
+	version, err := s.mentorVersion(ctx, mentorID, versionID, true)
+	if err != nil {
+		return err
+	}
+
+	if len(moduleIDs) != len(version.Modules) {
+		return errors.New("every module must be listed exactly once")
+	}
+	seen := make(map[int]bool, len(moduleIDs))
+	for _, id := range moduleIDs {
+		if seen[id] {
+			return errors.New("every module must be listed exactly once")
+		}
+		seen[id] = true
+	}
+
+	return s.curriculumRepo.ReorderModules(ctx, versionID, moduleIDs)
+}
+
+// AddLink attaches an external link to a draft module
+func (s *CurriculumService) AddLink(ctx context.Context, mentorID, moduleID int, resource *models.ModuleResource) error {
+	module, err := s.draftModule(ctx, mentorID, moduleID)
+	if err != nil {
+		return err
+	}
+
+	resource.URL = strings.TrimSpace(resource.URL)
+	parsed, err := url.Parse(resource.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("resource URL must be an http or https link")
+	}
+
+	resource.Title = strings.TrimSpace(resource.Title)
+	if resource.Title == "" {
+		resource.Title = resource.URL
+	}
+
+	resource.ModuleID = moduleID
+	resource.Kind = models.ResourceKind.Link
+	resource.Position = len(module.Resources) + 1
+
+	return s.curriculumRepo.CreateResource(ctx, resource)
+}
+
+// UploadFile stores a file and attaches it to a draft module
+func (s *CurriculumService) UploadFile(ctx context.Context, mentorID, moduleID int, title, fileName, contentType string, content io.Reader) (*models.ModuleResource, error) {
+	module, err := s.draftModule(ctx, mentorID, moduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fileName == "" {
+		return nil, errors.New("file name is required")
+	}
+
+	key, size, err := s.files.Save(ctx, fileName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = fileName
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	resource := &models.ModuleResource{
+		ModuleID:    moduleID,
+		Kind:        models.ResourceKind.File,
+		Title:       title,
+		FileKey:     key,
+		FileName:    fileName,
+		ContentType: contentType,
+		SizeBytes:   size,
+		Position:    len(module.Resources) + 1,
+	}
+	if err := s.curriculumRepo.CreateResource(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// DeleteResource detaches a resource from a draft module. The stored file is
+// kept because published versions may still refer to it.
+func (s *CurriculumService) DeleteResource(ctx context.Context, mentorID, resourceID int) error {
+	resource, err := s.curriculumRepo.GetResource(ctx, resourceID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.draftModule(ctx, mentorID, resource.ModuleID); err != nil {
+		return err
+	}
+
+	return s.curriculumRepo.DeleteResource(ctx, resourceID)
+}
+
+// OpenResource returns an uploaded file to the program's mentor or to a mentee
+// whose mentorship follows the version it belongs to
+func (s *CurriculumService) OpenResource(ctx context.Context, userID, resourceID int) (*models.ModuleResource, io.ReadCloser, error) {
+	resource, err := s.curriculumRepo.GetResource(ctx, resourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resource.Kind != models.ResourceKind.File {
+		return nil, nil, errors.New("resource is a link, not a file")
+	}
+
+	module, err := s.curriculumRepo.GetModule(ctx, resource.ModuleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := s.curriculumRepo.GetVersion(ctx, module.VersionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkProgramOwner(ctx, userID, version.ProgramID); err != nil {
+		pinned, pinErr := s.curriculumRepo.IsVersionPinnedForMentee(ctx, userID, version.ID)
+		if pinErr != nil {
+			return nil, nil, pinErr
+		}
+		if !pinned {
+			return nil, nil, errors.New("unauthorized: resource is not part of your curriculum")
+		}
+	}
+
+	content, err := s.files.Open(ctx, resource.FileKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource, content, nil
+}
+
+// GetProgress returns the curriculum a mentorship follows with the mentee's
+// completed modules. The first call pins the mentorship to the latest
+// published version so later edits don't change it.
+func (s *CurriculumService) GetProgress(ctx context.Context, userID, requestID int) (*models.CurriculumProgress, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	if request.Status != "approved" {
+		return nil, errors.New("curriculum is available once the mentorship is approved")
+	}
+
+	versionID, err := s.curriculumRepo.GetPinnedVersionID(ctx, requestID)
+	if errors.Is(err, repository.ErrCurriculumNotFound) {
+		latest, latestErr := s.curriculumRepo.GetLatestPublished(ctx, request.ProgramID)
+		if latestErr != nil {
+			return nil, latestErr
+		}
+		versionID, err = s.curriculumRepo.PinVersion(ctx, requestID, latest.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.curriculumRepo.GetVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := s.curriculumRepo.ListCompletions(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &models.CurriculumProgress{
+		RequestID:   requestID,
+		Curriculum:  version,
+		Completions: completions,
+	}
+	progress.CalculateProgress()
+	return progress, nil
+}
+
+// SetModuleCompletion lets the mentee tick off, or un-tick, a module of their curriculum
+func (s *CurriculumService) SetModuleCompletion(ctx context.Context, menteeID, requestID, moduleID int, done bool) (*models.CurriculumProgress, error) {
+	progress, err := s.GetProgress(ctx, menteeID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.MenteeID != menteeID {
+		return nil, errors.New("unauthorized: only the mentee can record module completion")
+	}
+
+	inCurriculum := false
+	for _, module := range progress.Curriculum.Modules {
+		if module.ID == moduleID {
+			inCurriculum = true
+			break
+		}
+	}
+	if !inCurriculum {
+		return nil, repository.ErrModuleNotFound
+	}
+
+	if err := s.curriculumRepo.SetCompletion(ctx, requestID, moduleID, done); err != nil {
+		return nil, err
+	}
+
+	return s.GetProgress(ctx, menteeID, requestID)
+}
+
+func (s *CurriculumService) checkProgramOwner(ctx context.Context, mentorID, programID int) error {
+	program, err := s.mentorshipRepo.GetProgram(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if program.MentorID != mentorID {
+		return errors.New("unauthorized: program belongs to another mentor")
+	}
+	return nil
+}
+
+// mentorVersion loads a version of one of the mentor's curricula, optionally
+// requiring it to still be an editable draft
+func (s *CurriculumService) mentorVersion(ctx context.Context, mentorID, versionID int, requireDraft bool) (*models.CurriculumVersion, error) {
+	version, err := s.curriculumRepo.GetVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProgramOwner(ctx, mentorID, version.ProgramID); err != nil {
+		return nil, err
+	}
+	if requireDraft && version.Status != models.CurriculumStatus.Draft {
+		return nil, errors.New("published curriculum versions cannot be changed; edit the draft instead")
+	}
+	return version, nil
+}
+
+func (s *CurriculumService) draftModule(ctx context.Context, mentorID, moduleID int) (*models.CurriculumModule, error) {
+	module, err := s.curriculumRepo.GetModule(ctx, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.mentorVersion(ctx, mentorID, module.VersionID, true); err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+func normalizeModule(module *models.CurriculumModule) error {
+	module.Title = strings.TrimSpace(module.Title)
+	if module.Title == "" {
+		return errors.New("module title is required")
+	}
+	module.Summary = strings.TrimSpace(module.Summary)
+	module.Assignment = strings.TrimSpace(module.Assignment)
+
+	objectives := []string{}
+	for _, objective := range module.Objectives {
+		if objective = strings.TrimSpace(objective); objective != "" {
+			objectives = append(objectives, objective)
+		}
+	}
+	module.Objectives = objectives
+	return nil
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+const (
+	// featuredMentorLimit is how many mentors the home page shows
+	featuredMentorLimit = 6
+	// rotationPoolSize is how many top-rated mentors the automatic mode rotates through
+	rotationPoolSize = 24
+	// minRotationReviews is how many ratings a mentor needs to join the rotation
+	minRotationReviews = 3
+	// rotationPeriod is how long each automatic selection stays on the home page
+	rotationPeriod = 24 * time.Hour
+	// maxHeadlineLength matches the headline column of featured_mentors
+	maxHeadlineLength = 255
+)
+
+// FeaturedService curates the home page's featured mentors. The list shown on
+// the home page is cached for cacheTTL and refreshed whenever admins change it.
+type FeaturedService struct {
+	featuredRepo repository.IFeaturedRepository
+	userRepo     repository.IUserRepository
+	cacheTTL     time.Duration
+
+	mu       sync.Mutex
+	cached   []*models.FeaturedMentor
+	cachedAt time.Time
+}
+
+func NewFeaturedService(
+	featuredRepo repository.IFeaturedRepository,
+	userRepo repository.IUserRepository,
+	cacheTTL time.Duration,
+) IFeaturedService {
+	return &FeaturedService{
+		featuredRepo: featuredRepo,
+		userRepo:     userRepo,
+		cacheTTL:     cacheTTL,
+	}
+}
+
+// GetFeaturedMentors returns the mentors to show on the home page: curated
+// entries in their display window first and, in automatic mode, a daily
+// rotation of top-rated, available mentors in the remaining places
+func (s *FeaturedService) GetFeaturedMentors(ctx context.Context) ([]*models.FeaturedMentor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.cached != nil && now.Sub(s.cachedAt) < s.cacheTTL {
+		return s.cached, nil
+	}
+
+	mentors, err := s.selectFeatured(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cached = mentors
+	s.cachedAt = now
+	return mentors, nil
+}
+
+func (s *FeaturedService) selectFeatured(ctx context.Context, now time.Time) ([]*models.FeaturedMentor, error) {
+	curated, err := s.featuredRepo.ListActiveFeatures(ctx, now, featuredMentorLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// A mentor curated more than once is shown at their first position
+	mentors := make([]*models.FeaturedMentor, 0, featuredMentorLimit)
+	shown := make(map[int]bool, featuredMentorLimit)
+	for _, feature := range curated {
+		if !shown[feature.MentorID] {
+			shown[feature.MentorID] = true
+			mentors = append(mentors, feature)
+		}
+	}
+
+	mode, err := s.featuredRepo.GetMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if mode != models.FeaturedMentorsMode.Automatic || len(mentors) >= featuredMentorLimit {
+		return mentors, nil
+	}
+
+	pool, err := s.featuredRepo.ListTopRatedAvailable(ctx, minRotationReviews, rotationPoolSize)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*models.FeaturedMentor, 0, len(pool))
+	for _, mentor := range pool {
+		if !shown[mentor.MentorID] {
+			candidates = append(candidates, mentor)
+		}
+	}
+	if len(candidates) == 0 {
+		return mentors, nil
+	}
+
+	// Each period starts where the previous one stopped, wrapping around the pool
+	need := featuredMentorLimit - len(mentors)
+	if need > len(candidates) {
+		need = len(candidates)
+	}
+	period := int(now.Unix() / int64(rotationPeriod/time.Second))
+	start := (period * need) % len(candidates)
+	for i := 0; i < need; i++ {
+		mentor := candidates[(start+i)%len(candidates)]
+		mentor.Position = len(mentors)
+		mentors = append(mentors, mentor)
+	}
+
+	return mentors, nil
+}
+
+// ListFeatures returns every curated entry for admins
+func (s *FeaturedService) ListFeatures(ctx context.Context) ([]*models.FeaturedMentor, error) {
+	return s.featuredRepo.ListFeatures(ctx)
+}
+
+// CreateFeature curates an approved mentor onto the home page. The entry
+// starts now unless a start is given and runs indefinitely unless it has an end.
+func (s *FeaturedService) CreateFeature(ctx context.Context, adminID int, feature *models.FeaturedMentor) (*models.FeaturedMentor, error) {
+	mentor, err := s.userRepo.GetUserByID(ctx, feature.MentorID)
+	if err != nil {
+		return nil, err
+	}
+	if mentor == nil || !mentor.IsMentor {
+		return nil, errors.New("mentor not found")
+	}
+	if !mentor.IsApproved {
+		return nil, errors.New("only approved mentors can be featured")
+	}
+
+	if feature.StartsAt.IsZero() {
+		feature.StartsAt = time.Now()
+	}
+	if err := validateFeature(feature); err != nil {
+		return nil, err
+	}
+
+	feature.CreatedBy = adminID
+	if err := s.featuredRepo.CreateFeature(ctx, feature); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return s.featuredRepo.GetFeature(ctx, feature.ID)
+}
+
+// UpdateFeature changes a curated entry's headline, position or display window
+func (s *FeaturedService) UpdateFeature(ctx context.Context, featureID int, update *models.FeaturedMentor) (*models.FeaturedMentor, error) {
+	feature, err := s.featuredRepo.GetFeature(ctx, featureID)
+	if err != nil {
+		return nil, err
+	}
+
+	feature.Headline = update.Headline
+	feature.Position = update.Position
+	if !update.StartsAt.IsZero() {
+		feature.StartsAt = update.StartsAt
+	}
+	feature.EndsAt = update.EndsAt
+	if err := validateFeature(feature); err != nil {
+		return nil, err
+	}
+
+	if err := s.featuredRepo.UpdateFeature(ctx, feature); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return s.featuredRepo.GetFeature(ctx, featureID)
+}
+
+// DeleteFeature removes a curated entry
+func (s *FeaturedService) DeleteFeature(ctx context.Context, featureID int) error {
+	if err := s.featuredRepo.DeleteFeature(ctx, featureID); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// GetMode returns whether the home page shows only curated mentors or also the rotation
+func (s *FeaturedService) GetMode(ctx context.Context) (string, error) {
+	return s.featuredRepo.GetMode(ctx)
+}
+
+// SetMode switches between curated-only and automatic rotation
+func (s *FeaturedService) SetMode(ctx context.Context, mode string) error {
+	switch mode {
+	case models.FeaturedMentorsMode.Manual, models.FeaturedMentorsMode.Automatic:
+	default:
+		return fmt.Errorf("unknown featured mentors mode %q", mode)
+	}
+
+	if err := s.featuredRepo.SetMode(ctx, mode); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// invalidate drops the cached home page list so the next request rebuilds it
+func (s *FeaturedService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+}
+
+func validateFeature(feature *models.FeaturedMentor) error {
+	feature.Headline = strings.TrimSpace(feature.Headline)
+	if len(feature.Headline) > maxHeadlineLength {
+		return fmt.Errorf("headline must be at most %d characters", maxHeadlineLength)
+	}
+	if feature.Position < 0 {
+		return errors.New("position cannot be negative")
+	}
+	if feature.EndsAt != nil && !feature.EndsAt.After(feature.StartsAt) {
+		return errors.New("featuring must end after it starts")
+	}
+	return nil
+}
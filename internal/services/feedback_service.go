@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+const (
+	// feedbackPageSize is the number of entries returned per page of mentor feedback
+	feedbackPageSize = 20
+	// testimonialLimit caps the testimonials shown on a mentor's profile
+	testimonialLimit = 10
+)
+
+type FeedbackService struct {
+	feedbackRepo   repository.IFeedbackRepository
+	mentorshipRepo repository.IMentorshipRepository
+	profileRepo    repository.IProfileRepository
+	notifier       INotificationService
+	filters        []ContentFilter
+}
+
+func NewFeedbackService(
+	feedbackRepo repository.IFeedbackRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	profileRepo repository.IProfileRepository,
+	notifier INotificationService,
+	filters []ContentFilter,
+) IFeedbackService {
+	return &FeedbackService{
+		feedbackRepo:   feedbackRepo,
+		mentorshipRepo: mentorshipRepo,
+		profileRepo:    profileRepo,
+		notifier:       notifier,
+		filters:        filters,
+	}
+}
+
+// SubmitFeedback records a participant's feedback on a completed session.
+// Each participant may leave feedback once per session; mentees may opt in
+// to the comment being shown publicly once a moderator approves it.
+func (s *FeedbackService) SubmitFeedback(ctx context.Context, userID, sessionID, rating int, comment string, public bool) (*models.SessionFeedback, error) {
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+
+	session, err := s.mentorshipRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errors.New("session not found")
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	if session.Status != models.SessionStatus.Completed {
+		return nil, errors.New("feedback can only be left for completed sessions")
+	}
+
+	feedback := &models.SessionFeedback{
+		SessionID: sessionID,
+		UserID:    userID,
+		MentorID:  request.MentorID,
+		Rating:    rating,
+		Comment:   strings.TrimSpace(comment),
+		IsPublic:  public,
+	}
+	if feedback.IsPublic && !feedback.CountsTowardRating() {
+		return nil, errors.New("only mentee reviews can be made public")
+	}
+	s.screen(feedback)
+
+	if err := s.feedbackRepo.CreateFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+
+	if feedback.CountsTowardRating() {
+		message := fmt.Sprintf("Your mentee rated the session %q %d out of 5.", session.Title, rating)
+		if err := s.notifier.Notify(ctx, request.MentorID, models.NotificationType.FeedbackReceived, "New session feedback", message); err != nil {
+			log.Printf("Failed to notify mentor %d of feedback %d: %v", request.MentorID, feedback.ID, err)
+		}
+	}
+
+	return feedback, nil
+}
+
+// UpdateFeedback changes the author's own feedback while the edit window is open
+func (s *FeedbackService) UpdateFeedback(ctx context.Context, userID, feedbackID, rating int, comment string) (*models.SessionFeedback, error) {
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+
+	feedback, err := s.feedbackRepo.GetFeedback(ctx, feedbackID)
+	if err != nil {
+		return nil, err
+	}
+	if feedback.UserID != userID {
+		return nil, errors.New("unauthorized: feedback belongs to another user")
+	}
+	if !feedback.Editable(time.Now()) {
+		return nil, errors.New("feedback can no longer be edited")
+	}
+
+	feedback.Rating = rating
+	if comment = strings.TrimSpace(comment); comment != feedback.Comment {
+		// A changed comment has to go through moderation again
+		feedback.Comment = comment
+		s.screen(feedback)
+	}
+
+	if err := s.feedbackRepo.UpdateFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+// GetSessionFeedback returns the feedback left on a session to either participant
+func (s *FeedbackService) GetSessionFeedback(ctx context.Context, userID, sessionID int) ([]*models.SessionFeedback, error) {
+	session, err := s.mentorshipRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errors.New("session not found")
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+
+	return s.feedbackRepo.ListSessionFeedback(ctx, sessionID)
+}
+
+// ListMentorFeedback returns one page of the feedback mentees left for a mentor
+func (s *FeedbackService) ListMentorFeedback(ctx context.Context, mentorID, page int) ([]*models.FeedbackResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	return s.feedbackRepo.ListMentorFeedback(ctx, mentorID, feedbackPageSize, (page-1)*feedbackPageSize)
+}
+
+// GetRatingSummary returns a mentor's average rating, count and star distribution
+func (s *FeedbackService) GetRatingSummary(ctx context.Context, mentorID int) (*models.RatingSummary, error) {
+	summary, err := s.profileRepo.GetRatingSummary(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary.Distribution, err = s.feedbackRepo.GetRatingDistribution(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// SetVisibility lets a mentee choose whether their review may be shown publicly
+func (s *FeedbackService) SetVisibility(ctx context.Context, userID, feedbackID int, public bool) (*models.SessionFeedback, error) {
+	feedback, err := s.feedbackRepo.GetFeedback(ctx, feedbackID)
+	if err != nil {
+		return nil, err
+	}
+	if feedback.UserID != userID {
+		return nil, errors.New("unauthorized: feedback belongs to another user")
+	}
+	if public && !feedback.CountsTowardRating() {
+		return nil, errors.New("only mentee reviews can be made public")
+	}
+
+	if err := s.feedbackRepo.SetVisibility(ctx, feedbackID, public); err != nil {
+		return nil, err
+	}
+
+	feedback.IsPublic = public
+	return feedback, nil
+}
+
+// ReplyToFeedback stores the mentor's public reply to a review of them.
+// Replies are shown without moderation, so any filter hit rejects them.
+func (s *FeedbackService) ReplyToFeedback(ctx context.Context, mentorID, feedbackID int, reply string) (*models.SessionFeedback, error) {
+	feedback, err := s.feedbackRepo.GetFeedback(ctx, feedbackID)
+	if err != nil {
+		return nil, err
+	}
+	if feedback.MentorID != mentorID || !feedback.CountsTowardRating() {
+		return nil, errors.New("unauthorized: mentors can only reply to reviews of themselves")
+	}
+
+	reply = strings.TrimSpace(reply)
+	if reasons := screenText(s.filters, reply); len(reasons) > 0 {
+		return nil, fmt.Errorf("reply rejected: contains %s", strings.Join(reasons, ", "))
+	}
+
+	if err := s.feedbackRepo.SaveMentorReply(ctx, feedbackID, reply); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	feedback.MentorReply = reply
+	feedback.MentorRepliedAt = &now
+	return feedback, nil
+}
+
+// ListModerationQueue returns public reviews awaiting a decision. With no
+// status, reviews flagged by the content filters come before pending ones.
+func (s *FeedbackService) ListModerationQueue(ctx context.Context, status string) ([]*models.SessionFeedback, error) {
+	if status != "" {
+		if !isReviewStatus(status) {
+			return nil, errors.New("invalid moderation status")
+		}
+		return s.feedbackRepo.ListModerationQueue(ctx, status)
+	}
+
+	flagged, err := s.feedbackRepo.ListModerationQueue(ctx, models.ReviewStatus.Flagged)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.feedbackRepo.ListModerationQueue(ctx, models.ReviewStatus.Pending)
+	if err != nil {
+		return nil, err
+	}
+	return append(flagged, pending...), nil
+}
+
+// ModerateReview records an admin's approve, hide or flag decision on a review
+func (s *FeedbackService) ModerateReview(ctx context.Context, adminID, feedbackID int, action, note string) error {
+	var status string
+	switch action {
+	case "approve":
+		status = models.ReviewStatus.Approved
+	case "hide":
+		status = models.ReviewStatus.Hidden
+	case "flag":
+		status = models.ReviewStatus.Flagged
+	default:
+		return errors.New("action must be one of approve, hide or flag")
+	}
+
+	return s.feedbackRepo.SetModeration(ctx, feedbackID, adminID, status, strings.TrimSpace(note))
+}
+
+// ListTestimonials returns the approved public reviews shown on a mentor's profile
+func (s *FeedbackService) ListTestimonials(ctx context.Context, mentorID int) ([]*models.Testimonial, error) {
+	return s.feedbackRepo.ListTestimonials(ctx, mentorID, testimonialLimit)
+}
+
+// screen runs the content filters over the comment and resets its moderation state
+func (s *FeedbackService) screen(feedback *models.SessionFeedback) {
+	feedback.ModerationFlags = screenText(s.filters, feedback.Comment)
+	feedback.ModerationStatus = models.ReviewStatus.Pending
+	if len(feedback.ModerationFlags) > 0 {
+		feedback.ModerationStatus = models.ReviewStatus.Flagged
+	}
+}
+
+func isReviewStatus(status string) bool {
+	switch status {
+	case models.ReviewStatus.Pending, models.ReviewStatus.Approved, models.ReviewStatus.Hidden, models.ReviewStatus.Flagged:
+		return true
+	}
+	return false
+}
+
+func validateRating(rating int) error {
+	if rating < 1 || rating > 5 {
+		return errors.New("rating must be between 1 and 5")
+	}
+	return nil
+}
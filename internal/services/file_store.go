@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore keeps uploaded files. Callers store the returned key and use it
+// to read the file back; access control is left to the caller.
+type FileStore interface {
+	// Save writes the content under a new key derived from name's extension
+	Save(ctx context.Context, name string, r io.Reader) (key string, size int64, err error)
+	// Open returns the content stored under key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key
+	Delete(ctx context.Context, key string) error
+}
+
+var ErrInvalidFileKey = errors.New("invalid file key")
+
+// LocalFileStore keeps uploads in a directory on local disk
+type LocalFileStore struct {
+	dir string
+}
+
+func NewLocalFileStore(dir string) *LocalFileStore {
+	return &LocalFileStore{
+		dir: dir,
+	}
+}
+
+// Save writes the file under a random name so uploads never collide or
+// overwrite each other
+func (s *LocalFileStore) Save(ctx context.Context, name string, r io.Reader) (string, int64, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return "", 0, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", 0, fmt.Errorf("failed to generate file key: %w", err)
+	}
+	key := hex.EncodeToString(random) + strings.ToLower(filepath.Ext(filepath.Base(name)))
+
+	f, err := os.OpenFile(filepath.Join(s.dir, key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return key, size, nil
+}
+
+// Open reads a previously saved file; keys that could escape the upload
+// directory are rejected
+func (s *LocalFileStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !validFileKey(key) {
+		return nil, ErrInvalidFileKey
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return f, nil
+}
+
+// Delete removes a previously saved file; a file that is already gone is not an error
+func (s *LocalFileStore) Delete(ctx context.Context, key string) error {
+	if !validFileKey(key) {
+		return ErrInvalidFileKey
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// validFileKey reports whether key names a file directly inside the upload directory
+func validFileKey(key string) bool {
+	return key != "" && key == filepath.Base(key) && !strings.HasPrefix(key, ".")
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+const (
+	// requestTimelineLimit caps the events shown for a single mentorship
+	requestTimelineLimit = 200
+	// dashboardTimelineLimit caps the events shown on a dashboard
+	dashboardTimelineLimit = 20
+)
+
+type GoalService struct {
+	goalRepo       repository.IGoalRepository
+	mentorshipRepo repository.IMentorshipRepository
+	notifier       INotificationService
+}
+
+func NewGoalService(
+	goalRepo repository.IGoalRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	notifier INotificationService,
+) IGoalService {
+	return &GoalService{
+		goalRepo:       goalRepo,
+		mentorshipRepo: mentorshipRepo,
+		notifier:       notifier,
+	}
+}
+
+// CreateGoal attaches a goal to an active mentorship
+func (s *GoalService) CreateGoal(ctx context.Context, userID, requestID int, goal *models.Goal) error {
+	request, err := s.participantRequest(ctx, userID, requestID)
+	if err != nil {
+		return err
+	}
+	if request.Status != models.RequestStatus.Approved {
+		return errors.New("goals can only be added to an active mentorship")
+	}
+
+	goal.Title = strings.TrimSpace(goal.Title)
+	if goal.Title == "" {
+		return errors.New("goal title is required")
+	}
+
+	goal.RequestID = requestID
+	goal.Status = models.GoalStatus.Active
+	goal.CreatedBy = userID
+	goal.Milestones = []*models.Milestone{}
+
+	return s.goalRepo.CreateGoal(ctx, goal)
+}
+
+// UpdateGoal replaces a goal's SMART fields, target date and status
+func (s *GoalService) UpdateGoal(ctx context.Context, userID, goalID int, update *models.Goal) (*models.Goal, error) {
+	goal, err := s.participantGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(update.Title)
+	if title == "" {
+		return nil, errors.New("goal title is required")
+	}
+
+	switch update.Status {
+	case "":
+		update.Status = goal.Status
+	case models.GoalStatus.Active, models.GoalStatus.Achieved, models.GoalStatus.Abandoned:
+	default:
+		return nil, errors.New("invalid goal status")
+	}
+
+	if update.Status == models.GoalStatus.Achieved && goal.AchievedAt == nil {
+		now := time.Now().UTC()
+		goal.AchievedAt = &now
+	} else if update.Status != models.GoalStatus.Achieved {
+		goal.AchievedAt = nil
+	}
+
+	goal.Title = title
+	goal.Specific = update.Specific
+	goal.Measurable = update.Measurable
+	goal.Achievable = update.Achievable
+	goal.Relevant = update.Relevant
+	goal.TimeBound = update.TimeBound
+	goal.TargetDate = update.TargetDate
+	goal.Status = update.Status
+
+	if err := s.goalRepo.UpdateGoal(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	goal.CalculateProgress()
+	return goal, nil
+}
+
+// ListGoals returns a mentorship's goals with milestones and progress
+func (s *GoalService) ListGoals(ctx context.Context, userID, requestID int) ([]*models.Goal, error) {
+	if _, err := s.participantRequest(ctx, userID, requestID); err != nil {
+		return nil, err
+	}
+
+	goals, err := s.goalRepo.ListGoalsByRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, goal := range goals {
+		goal.CalculateProgress()
+	}
+	return goals, nil
+}
+
+// AddMilestone adds a step towards a goal
+func (s *GoalService) AddMilestone(ctx context.Context, userID, goalID int, milestone *models.Milestone) error {
+	goal, err := s.participantGoal(ctx, userID, goalID)
+	if err != nil {
+		return err
+	}
+
+	milestone.Title = strings.TrimSpace(milestone.Title)
+	if milestone.Title == "" {
+		return errors.New("milestone title is required")
+	}
+	if err := validateProgress(milestone.Progress); err != nil {
+		return err
+	}
+
+	milestone.GoalID = goalID
+	if milestone.Position == 0 {
+		milestone.Position = len(goal.Milestones) + 1
+	}
+	if milestone.Progress == 100 {
+		now := time.Now().UTC()
+		milestone.CompletedAt = &now
+	}
+
+	return s.goalRepo.CreateMilestone(ctx, milestone)
+}
+
+// UpdateMilestone applies a partial update; reaching 100% marks the milestone completed
+func (s *GoalService) UpdateMilestone(ctx context.Context, userID, milestoneID int, update *models.MilestoneUpdate) (*models.Milestone, error) {
+	milestone, err := s.goalRepo.GetMilestone(ctx, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.participantGoal(ctx, userID, milestone.GoalID); err != nil {
+		return nil, err
+	}
+
+	if update.Title != nil {
+		title := strings.TrimSpace(*update.Title)
+		if title == "" {
+			return nil, errors.New("milestone title is required")
+		}
+		milestone.Title = title
+	}
+	if update.Description != nil {
+		milestone.Description = *update.Description
+	}
+	if update.ClearDue {
+		milestone.DueDate = nil
+	} else if update.DueDate != nil {
+		milestone.DueDate = update.DueDate
+	}
+	if update.Position != nil {
+		milestone.Position = *update.Position
+	}
+	if update.Progress != nil {
+		if err := validateProgress(*update.Progress); err != nil {
+			return nil, err
+		}
+		milestone.Progress = *update.Progress
+		if milestone.Progress == 100 && milestone.CompletedAt == nil {
+			now := time.Now().UTC()
+			milestone.CompletedAt = &now
+		} else if milestone.Progress < 100 {
+			milestone.CompletedAt = nil
+		}
+	}
+
+	if err := s.goalRepo.UpdateMilestone(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+// DeleteMilestone removes a milestone from a goal
+func (s *GoalService) DeleteMilestone(ctx context.Context, userID, milestoneID int) error {
+	milestone, err := s.goalRepo.GetMilestone(ctx, milestoneID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.participantGoal(ctx, userID, milestone.GoalID); err != nil {
+		return err
+	}
+
+	return s.goalRepo.DeleteMilestone(ctx, milestoneID)
+}
+
+// CheckIn records progress on a goal, optionally during one of the mentorship's
+// sessions, and lets the other participant know
+func (s *GoalService) CheckIn(ctx context.Context, userID, goalID int, checkIn *models.CheckIn) error {
+	goal, err := s.participantGoal(ctx, userID, goalID)
+	if err != nil {
+		return err
+	}
+	if err := validateProgress(checkIn.Progress); err != nil {
+		return err
+	}
+
+	if checkIn.SessionID != nil {
+		session, err := s.mentorshipRepo.GetSession(ctx, *checkIn.SessionID)
+		if err != nil {
+			return err
+		}
+		if session == nil || session.RequestID != goal.RequestID {
+			return errors.New("session does not belong to this mentorship")
+		}
+	}
+
+	checkIn.GoalID = goalID
+	checkIn.UserID = userID
+	checkIn.Note = strings.TrimSpace(checkIn.Note)
+
+	if err := s.goalRepo.CreateCheckIn(ctx, checkIn); err != nil {
+		return err
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, goal.RequestID)
+	if err != nil {
+		return err
+	}
+	otherID := request.MentorID
+	if otherID == userID {
+		otherID = request.MenteeID
+	}
+	message := fmt.Sprintf("Progress on %q is now at %d%%.", goal.Title, checkIn.Progress)
+	if err := s.notifier.Notify(ctx, otherID, models.NotificationType.GoalCheckIn, "Goal check-in", message); err != nil {
+		log.Printf("Failed to notify user %d of check-in %d: %v", otherID, checkIn.ID, err)
+	}
+
+	return nil
+}
+
+// GetTimeline returns the progress events of one mentorship, newest first
+func (s *GoalService) GetTimeline(ctx context.Context, userID, requestID int) ([]*models.TimelineEvent, error) {
+	if _, err := s.participantRequest(ctx, userID, requestID); err != nil {
+		return nil, err
+	}
+	return s.goalRepo.ListRequestTimeline(ctx, requestID, requestTimelineLimit)
+}
+
+// GetDashboardTimeline returns the latest progress events across all of the user's mentorships
+func (s *GoalService) GetDashboardTimeline(ctx context.Context, userID int) ([]*models.TimelineEvent, error) {
+	return s.goalRepo.ListUserTimeline(ctx, userID, dashboardTimelineLimit)
+}
+
+func (s *GoalService) participantRequest(ctx context.Context, userID, requestID int) (*models.MentorshipRequest, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	return request, nil
+}
+
+func (s *GoalService) participantGoal(ctx context.Context, userID, goalID int) (*models.Goal, error) {
+	goal, err := s.goalRepo.GetGoal(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.participantRequest(ctx, userID, goal.RequestID); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+func validateProgress(progress int) error {
+	if progress < 0 || progress > 100 {
+		return errors.New("progress must be between 0 and 100")
+	}
+	return nil
+}
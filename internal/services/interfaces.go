@@ -4,7 +4,9 @@ package services
 
 import (
 	"context"
+	"io"
 	"mentorApp/internal/models"
+	"time"
 )
 
 // IMentorshipService defines the interface for mentorship-related operations
@@ -12,25 +14,31 @@ type IMentorshipService interface {
 	// Program Management
 	CreateMentorshipProgram(ctx context.Context, mentorID int, program *models.MentorshipProgram) error
 	GetMentorPrograms(ctx context.Context, mentorID int) ([]*models.MentorshipProgram, error)
-	GetProgramDetails(ctx context.Context, programID int) (*models.MentorshipProgram, error)
+	GetProgramDetails(ctx context.Context, userID, programID int) (*models.MentorshipProgram, error)
 	ListMentorPrograms(ctx context.Context, mentorID int) ([]*models.MentorshipProgram, error)
 	ListAvailablePrograms(ctx context.Context) ([]*models.MentorshipProgram, error)
+	UpdateProgram(ctx context.Context, mentorID, programID int, update *models.MentorshipProgram) (*models.MentorshipProgram, error)
+	SetProgramStatus(ctx context.Context, mentorID, programID int, status string) (*models.MentorshipProgram, error)
+	DuplicateProgram(ctx context.Context, mentorID, programID int) (*models.MentorshipProgram, error)
+	DeleteProgram(ctx context.Context, mentorID, programID int) error
 
 	// Request Management
-	RequestMentorship(ctx context.Context, menteeID, programID int, message string) error
+	RequestMentorship(ctx context.Context, menteeID, programID int, message, sponsorshipCode string) error
 	RespondToRequest(ctx context.Context, mentorID, requestID int, approve bool) error
+	ApproveRequest(ctx context.Context, request *models.MentorshipRequest) error
+	CancelMentorship(ctx context.Context, userID, requestID int) (*models.MentorshipRequest, error)
 	ListMenteeRequests(ctx context.Context, menteeID int) ([]*models.MentorshipRequest, error)
 	GetPendingRequests(ctx context.Context, mentorID int) ([]*models.MentorshipRequest, error)
 
 	// Session Management
 	ScheduleSession(ctx context.Context, userID int, session *models.MentorshipSession) error
+	RescheduleSession(ctx context.Context, userID, sessionID int, startTime, endTime time.Time) (*models.MentorshipSession, error)
+	GetSession(ctx context.Context, userID, sessionID int) (*models.MentorshipSession, error)
+	CompleteSession(ctx context.Context, mentorID, sessionID int) (*models.MentorshipSession, error)
 	GetUpcomingSessions(ctx context.Context, userID int) ([]*models.MentorshipSession, error)
-	SubmitSessionFeedback(ctx context.Context, feedback *models.SessionFeedback) error
 
 	// Search and Discovery
-	SearchMentors(ctx context.Context, filters map[string]interface{}) ([]*models.PublicProfile, error)
-	GetFeaturedMentors(ctx context.Context) ([]*models.Profile, error)
-	GetRecommendedMentors(ctx context.Context, userID int) ([]*models.Profile, error)
+	SearchMentors(ctx context.Context, query *models.MentorSearchQuery) (*models.MentorSearchResult, error)
 
 	// Analytics and Stats
 	GetMentorshipStats(ctx context.Context, mentorID int) (map[string]interface{}, error)
@@ -39,13 +47,271 @@ type IMentorshipService interface {
 	// Availability Management
 	UpdateAvailability(ctx context.Context, mentorID int, availability []models.Availability) error
 	GetAvailability(ctx context.Context, mentorID int) ([]models.Availability, error)
+	GetBookableSlots(ctx context.Context, mentorID int, from, to time.Time, length time.Duration) ([]models.BookableSlot, error)
 
-	// Job Board
-	GetAvailableJobs(ctx context.Context) ([]*models.Job, error)
 	GetActiveMentorships(ctx context.Context, userID int) ([]*models.MentorshipRequest, error)
 	GetAvailableSpecialties(ctx context.Context) []string
 }
 
+// ICalendarService defines the interface for external calendar synchronisation
+type ICalendarService interface {
+	SetFeed(ctx context.Context, mentorID int, rawURL, feedType string) (*models.CalendarFeed, error)
+	GetFeed(ctx context.Context, mentorID int) (*models.CalendarFeed, error)
+	RemoveFeed(ctx context.Context, mentorID int) error
+	SyncMentor(ctx context.Context, mentorID int) error
+	SyncAll(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// INotificationService defines the interface for delivering user notifications
+type INotificationService interface {
+	Notify(ctx context.Context, userID int, notificationType, title, message string) error
+	SendCalendarInvite(ctx context.Context, userID int, notificationType, title, message, invite string) error
+	ListNotifications(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error)
+	MarkRead(ctx context.Context, userID, notificationID int) error
+}
+
+// IReminderService defines the interface for session reminders
+type IReminderService interface {
+	SendDueReminders(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// ISessionContentService defines the interface for session agendas, notes and action items
+type ISessionContentService interface {
+	GetContent(ctx context.Context, userID, sessionID int) (*models.SessionContent, error)
+	UpdateAgenda(ctx context.Context, userID, sessionID int, agenda string, version int) (int, error)
+	UpdateSharedNotes(ctx context.Context, userID, sessionID int, notes string, version int) (int, error)
+	UpdatePrivateNotes(ctx context.Context, userID, sessionID int, notes string) error
+	AddActionItem(ctx context.Context, userID, sessionID int, item *models.ActionItem) error
+	UpdateActionItem(ctx context.Context, userID, itemID int, update *models.ActionItemUpdate) (*models.ActionItem, error)
+	DeleteActionItem(ctx context.Context, userID, itemID int) error
+	ListActionItems(ctx context.Context, userID, requestID int, openOnly bool) ([]*models.ActionItem, error)
+}
+
+// IFeedbackService defines the interface for session feedback and mentor ratings
+type IFeedbackService interface {
+	SubmitFeedback(ctx context.Context, userID, sessionID, rating int, comment string, public bool) (*models.SessionFeedback, error)
+	UpdateFeedback(ctx context.Context, userID, feedbackID, rating int, comment string) (*models.SessionFeedback, error)
+	GetSessionFeedback(ctx context.Context, userID, sessionID int) ([]*models.SessionFeedback, error)
+	ListMentorFeedback(ctx context.Context, mentorID, page int) ([]*models.FeedbackResponse, error)
+	GetRatingSummary(ctx context.Context, mentorID int) (*models.RatingSummary, error)
+
+	// Moderation and testimonials
+	SetVisibility(ctx context.Context, userID, feedbackID int, public bool) (*models.SessionFeedback, error)
+	ReplyToFeedback(ctx context.Context, mentorID, feedbackID int, reply string) (*models.SessionFeedback, error)
+	ListModerationQueue(ctx context.Context, status string) ([]*models.SessionFeedback, error)
+	ModerateReview(ctx context.Context, adminID, feedbackID int, action, note string) error
+	ListTestimonials(ctx context.Context, mentorID int) ([]*models.Testimonial, error)
+}
+
+// IGoalService defines the interface for mentorship goals, milestones and check-ins
+type IGoalService interface {
+	CreateGoal(ctx context.Context, userID, requestID int, goal *models.Goal) error
+	UpdateGoal(ctx context.Context, userID, goalID int, update *models.Goal) (*models.Goal, error)
+	ListGoals(ctx context.Context, userID, requestID int) ([]*models.Goal, error)
+	AddMilestone(ctx context.Context, userID, goalID int, milestone *models.Milestone) error
+	UpdateMilestone(ctx context.Context, userID, milestoneID int, update *models.MilestoneUpdate) (*models.Milestone, error)
+	DeleteMilestone(ctx context.Context, userID, milestoneID int) error
+	CheckIn(ctx context.Context, userID, goalID int, checkIn *models.CheckIn) error
+	GetTimeline(ctx context.Context, userID, requestID int) ([]*models.TimelineEvent, error)
+	GetDashboardTimeline(ctx context.Context, userID int) ([]*models.TimelineEvent, error)
+}
+
+// ICohortService defines the interface for cohort-based group programs
+type ICohortService interface {
+	CreateCohort(ctx context.Context, mentorID, programID int, cohort *models.Cohort) error
+	GetCohort(ctx context.Context, cohortID int) (*models.Cohort, error)
+	ListProgramCohorts(ctx context.Context, programID int) ([]*models.Cohort, error)
+	CancelCohort(ctx context.Context, mentorID, cohortID int) error
+	Enroll(ctx context.Context, menteeID, cohortID int) error
+	Withdraw(ctx context.Context, menteeID, cohortID int) error
+	ListMenteeCohorts(ctx context.Context, menteeID int) ([]*models.Cohort, error)
+	GetRoster(ctx context.Context, userID, cohortID int) ([]*models.CohortMember, error)
+	ScheduleSession(ctx context.Context, mentorID, cohortID int, session *models.CohortSession) error
+	CancelSession(ctx context.Context, mentorID, sessionID int) error
+	ListSessions(ctx context.Context, userID, cohortID int) ([]*models.CohortSession, error)
+	GetSession(ctx context.Context, userID, sessionID int) (*models.CohortSession, error)
+	RecordAttendance(ctx context.Context, mentorID, sessionID int, records []*models.AttendanceRecord) (*models.CohortSession, error)
+	PostAnnouncement(ctx context.Context, mentorID, cohortID int, announcement *models.CohortAnnouncement) error
+	ListAnnouncements(ctx context.Context, userID, cohortID int) ([]*models.CohortAnnouncement, error)
+}
+
+// ICurriculumService defines the interface for versioned program curricula
+type ICurriculumService interface {
+	GetDraft(ctx context.Context, mentorID, programID int) (*models.CurriculumVersion, error)
+	ListVersions(ctx context.Context, mentorID, programID int) ([]*models.CurriculumVersion, error)
+	GetVersion(ctx context.Context, mentorID, versionID int) (*models.CurriculumVersion, error)
+	Publish(ctx context.Context, mentorID, versionID int) (*models.CurriculumVersion, error)
+	AddModule(ctx context.Context, mentorID, versionID int, module *models.CurriculumModule) error
+	UpdateModule(ctx context.Context, mentorID, moduleID int, update *models.CurriculumModule) (*models.CurriculumModule, error)
+	DeleteModule(ctx context.Context, mentorID, moduleID int) error
+	ReorderModules(ctx context.Context, mentorID, versionID int, moduleIDs []int) error
+	AddLink(ctx context.Context, mentorID, moduleID int, resource *models.ModuleResource) error
+	UploadFile(ctx context.Context, mentorID, moduleID int, title, fileName, contentType string, content io.Reader) (*models.ModuleResource, error)
+	DeleteResource(ctx context.Context, mentorID, resourceID int) error
+	OpenResource(ctx context.Context, userID, resourceID int) (*models.ModuleResource, io.ReadCloser, error)
+	GetProgress(ctx context.Context, userID, requestID int) (*models.CurriculumProgress, error)
+	SetModuleCompletion(ctx context.Context, menteeID, requestID, moduleID int, done bool) (*models.CurriculumProgress, error)
+}
+
+// IAssignmentService defines the interface for assignments, submissions and mentor review
+type IAssignmentService interface {
+	CreateAssignment(ctx context.Context, mentorID int, assignment *models.Assignment) error
+	UpdateAssignment(ctx context.Context, mentorID, assignmentID int, update *models.Assignment) (*models.Assignment, error)
+	DeleteAssignment(ctx context.Context, mentorID, assignmentID int) error
+	ListProgramAssignments(ctx context.Context, mentorID, programID int) ([]*models.Assignment, error)
+	ListMentorshipAssignments(ctx context.Context, userID, requestID int) ([]*models.Assignment, error)
+	Submit(ctx context.Context, menteeID, assignmentID, requestID int, submission *models.Submission, fileName, contentType string, file io.Reader) error
+	ListSubmissions(ctx context.Context, userID, assignmentID, requestID int) ([]*models.Submission, error)
+	OpenSubmissionFile(ctx context.Context, userID, submissionID int) (*models.Submission, io.ReadCloser, error)
+	Review(ctx context.Context, mentorID, submissionID int, review *models.SubmissionReview) (*models.Submission, error)
+	ListPendingReviews(ctx context.Context, mentorID int) ([]*models.PendingReview, error)
+}
+
+// IAgreementService defines the interface for mentorship agreements and their acceptance
+type IAgreementService interface {
+	PublishTemplate(ctx context.Context, adminID int, programID *int, title, body string) (*models.AgreementTemplate, error)
+	ListTemplates(ctx context.Context, programID *int) ([]*models.AgreementTemplate, error)
+	GetAgreement(ctx context.Context, userID, requestID int) (*models.MentorshipAgreement, error)
+	GetAcceptanceRecord(ctx context.Context, requestID int) (*models.MentorshipAgreement, error)
+	Accept(ctx context.Context, userID, requestID int, ipAddress, userAgent string) (*models.MentorshipAgreement, error)
+}
+
+// IPaymentService defines the interface for invoices, checkout, refunds and mentor payouts
+type IPaymentService interface {
+	IssueInvoice(ctx context.Context, request *models.MentorshipRequest) (*models.Invoice, error)
+	RequirePaid(ctx context.Context, request *models.MentorshipRequest) error
+	Checkout(ctx context.Context, menteeID, requestID int, paymentToken string) (*models.Invoice, error)
+	SettleCancellation(ctx context.Context, request *models.MentorshipRequest, cancelledBy int) (*models.Invoice, error)
+	RefundInvoice(ctx context.Context, invoiceID int, amountCents int64, reason string) (*models.Invoice, error)
+	GetInvoice(ctx context.Context, userID, invoiceID int) (*models.Invoice, error)
+	GetMentorshipInvoice(ctx context.Context, userID, requestID int) (*models.Invoice, error)
+	ListInvoices(ctx context.Context, userID int) ([]*models.Invoice, error)
+	GetInvoiceLedger(ctx context.Context, invoiceID int) ([]*models.LedgerTransaction, error)
+	GetPayoutSummary(ctx context.Context, mentorID int) (*models.PayoutSummary, error)
+}
+
+// ISponsorService defines the interface for sponsor accounts, sponsorship codes and credit usage
+type ISponsorService interface {
+	CreateSponsor(ctx context.Context, adminID int, sponsor *models.Sponsor) (*models.Sponsor, error)
+	UpdateSponsor(ctx context.Context, sponsorID int, update *models.Sponsor) (*models.Sponsor, error)
+	GetSponsor(ctx context.Context, sponsorID int) (*models.Sponsor, error)
+	ListSponsors(ctx context.Context) ([]*models.Sponsor, error)
+	GetSponsorReport(ctx context.Context, sponsorID int) (*models.SponsorReport, error)
+	RedeemCode(ctx context.Context, code string, request *models.MentorshipRequest) (*models.Sponsor, error)
+	CreateSession(ctx context.Context, session *models.MentorshipSession) error
+	ReleaseCredits(ctx context.Context, requestID int) error
+	GetMentorshipSponsorship(ctx context.Context, userID, requestID int) (*models.Sponsorship, error)
+	RecordSessionUsage(ctx context.Context, request *models.MentorshipRequest, session *models.MentorshipSession) error
+}
+
+// ISkillService defines the interface for the skills taxonomy and profile skills
+type ISkillService interface {
+	CreateCategory(ctx context.Context, category *models.SkillCategory) (*models.SkillCategory, error)
+	UpdateCategory(ctx context.Context, categoryID int, update *models.SkillCategory) (*models.SkillCategory, error)
+	DeleteCategory(ctx context.Context, categoryID int) error
+	ListCategories(ctx context.Context) ([]*models.SkillCategory, error)
+	CreateSkill(ctx context.Context, skill *models.Skill) (*models.Skill, error)
+	UpdateSkill(ctx context.Context, skillID int, update *models.Skill) (*models.Skill, error)
+	DeleteSkill(ctx context.Context, skillID int) error
+	GetSkill(ctx context.Context, skillID int) (*models.Skill, error)
+	ListSkills(ctx context.Context, categoryID *int, specialtiesOnly bool) ([]*models.Skill, error)
+	AddSynonym(ctx context.Context, skillID int, name string) (*models.Skill, error)
+	RemoveSynonym(ctx context.Context, skillID int, name string) (*models.Skill, error)
+	MergeSkill(ctx context.Context, sourceID, targetID int) (*models.Skill, error)
+	Autocomplete(ctx context.Context, prefix string, specialtiesOnly bool, limit int) ([]*models.SkillSuggestion, error)
+	ListSpecialtyNames(ctx context.Context) ([]string, error)
+	GetProfileSkills(ctx context.Context, userID int) ([]*models.ProfileSkill, error)
+	SetProfileSkills(ctx context.Context, userID int, skills []*models.ProfileSkill) ([]*models.ProfileSkill, error)
+	AddProfileSkillNames(ctx context.Context, userID int, skills, specialties string) error
+}
+
+// IRecommendationService defines the interface for mentor recommendations
+type IRecommendationService interface {
+	GetRecommendations(ctx context.Context, menteeID, limit int) ([]*models.MentorRecommendation, error)
+	Dismiss(ctx context.Context, menteeID, mentorID int) error
+	MarkNotInterested(ctx context.Context, menteeID, mentorID int) error
+	ClearFeedback(ctx context.Context, menteeID, mentorID int) error
+}
+
+// IMatchingService defines the interface for bulk mentor-mentee matching rounds
+type IMatchingService interface {
+	CreateRound(ctx context.Context, adminID int, round *models.MatchingRound) (*models.MatchingRound, error)
+	ListRounds(ctx context.Context, status string) ([]*models.MatchingRound, error)
+	GetRoundDetail(ctx context.Context, roundID int) (*models.MatchingRoundDetail, error)
+	ListRoundMentors(ctx context.Context, roundID int) ([]*models.MatchingMentor, error)
+	JoinAsMentor(ctx context.Context, mentorID, roundID int, entry *models.MatchingMentor) (*models.MatchingMentor, error)
+	SubmitPreferences(ctx context.Context, menteeID, roundID int, entry *models.MatchingMentee) (*models.MatchingMentee, error)
+	RunMatching(ctx context.Context, roundID int) (*models.MatchingRoundDetail, error)
+	AssignPair(ctx context.Context, roundID, menteeID, mentorID int) (*models.MatchingRoundDetail, error)
+	UnassignPair(ctx context.Context, roundID, menteeID int) (*models.MatchingRoundDetail, error)
+	PublishRound(ctx context.Context, roundID int) (*models.MatchingRoundDetail, error)
+}
+
+// IFeaturedService defines the interface for the home page's featured mentors
+type IFeaturedService interface {
+	GetFeaturedMentors(ctx context.Context) ([]*models.FeaturedMentor, error)
+	ListFeatures(ctx context.Context) ([]*models.FeaturedMentor, error)
+	CreateFeature(ctx context.Context, adminID int, feature *models.FeaturedMentor) (*models.FeaturedMentor, error)
+	UpdateFeature(ctx context.Context, featureID int, update *models.FeaturedMentor) (*models.FeaturedMentor, error)
+	DeleteFeature(ctx context.Context, featureID int) error
+	GetMode(ctx context.Context) (string, error)
+	SetMode(ctx context.Context, mode string) error
+}
+
+// IJobService defines the interface for the job board and job applications
+type IJobService interface {
+	SearchJobs(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error)
+	GetJob(ctx context.Context, jobID int) (*models.Job, error)
+
+	// Postings and moderation
+	CreatePosting(ctx context.Context, userID int, job *models.Job) (*models.Job, error)
+	UpdatePosting(ctx context.Context, userID, jobID int, update *models.Job) (*models.Job, error)
+	ListPostings(ctx context.Context, userID int) ([]*models.Job, error)
+	ListJobs(ctx context.Context, status string) ([]*models.Job, error)
+	ListRevisions(ctx context.Context, userID, jobID int) ([]*models.JobRevision, error)
+	ApproveJob(ctx context.Context, adminID, jobID int) (*models.Job, error)
+	RejectJob(ctx context.Context, adminID, jobID int, reason string) (*models.Job, error)
+	RenewJob(ctx context.Context, userID, jobID int) (*models.Job, error)
+	CloseJob(ctx context.Context, userID, jobID int) error
+	SetFeatured(ctx context.Context, jobID int, featured bool) (*models.Job, error)
+	ImportJobs(ctx context.Context, adminID int, format string, data io.Reader, mapping map[string]string, dryRun bool) (*models.JobImportResult, error)
+	ExpireJobs(ctx context.Context) error
+	RunExpiry(ctx context.Context, interval time.Duration)
+
+	// Applications
+	Apply(ctx context.Context, userID, jobID int, app *models.JobApplication, fileName, contentType string, file io.Reader) (*models.JobApplication, error)
+	ListMyApplications(ctx context.Context, userID int) ([]*models.JobApplication, error)
+	ListJobApplications(ctx context.Context, reviewerID, jobID int, status string) ([]*models.JobApplication, error)
+	GetApplication(ctx context.Context, userID, applicationID int) (*models.JobApplication, error)
+	UpdateApplicationStatus(ctx context.Context, reviewerID, applicationID int, status, note string) (*models.JobApplication, error)
+	AddApplicationNote(ctx context.Context, reviewerID, applicationID int, note string) (*models.JobApplication, error)
+	WithdrawApplication(ctx context.Context, userID, applicationID int) (*models.JobApplication, error)
+	OpenResume(ctx context.Context, userID, applicationID int) (*models.JobApplication, io.ReadCloser, error)
+
+	// Referrals
+	ReferMentee(ctx context.Context, mentorID, jobID, menteeID int, note string) (*models.JobReferral, error)
+	ListMyReferrals(ctx context.Context, mentorID int) (*models.JobReferralHistory, error)
+	ListReceivedReferrals(ctx context.Context, menteeID int) ([]*models.JobReferral, error)
+}
+
+// IJobAlertService defines the interface for saved job searches, job alerts,
+// bookmarks and job suggestions
+type IJobAlertService interface {
+	CreateSavedSearch(ctx context.Context, userID int, search *models.SavedJobSearch) (*models.SavedJobSearch, error)
+	UpdateSavedSearch(ctx context.Context, userID, searchID int, update *models.SavedJobSearch) (*models.SavedJobSearch, error)
+	DeleteSavedSearch(ctx context.Context, userID, searchID int) error
+	ListSavedSearches(ctx context.Context, userID int) ([]*models.SavedJobSearch, error)
+	SaveJob(ctx context.Context, userID, jobID int) error
+	UnsaveJob(ctx context.Context, userID, jobID int) error
+	ListSavedJobs(ctx context.Context, userID int) ([]*models.SavedJob, error)
+	SimilarJobs(ctx context.Context, userID int) ([]*models.Job, error)
+	NotifyNewJob(ctx context.Context, job *models.Job)
+	SendDailyDigests(ctx context.Context) error
+	RunDigests(ctx context.Context, interval time.Duration)
+}
+
 // IProfileService defines the interface for profile-related operations
 type IProfileService interface {
 	CreateProfile(ctx context.Context, userID int, profile *models.Profile) error
@@ -63,6 +329,7 @@ type IUserService interface {
 	// Existing methods
 	RegisterUser(ctx context.Context, input RegisterUserInput) (*models.User, error)
 	RegisterMentor(ctx context.Context, input RegisterMentorInput) (*models.User, error)
+	RegisterEmployer(ctx context.Context, input RegisterEmployerInput) (*models.User, error)
 	AuthenticateUser(ctx context.Context, email, password string) (*models.User, error)
 	VerifyEmail(ctx context.Context, token string) error
 	RequestPasswordReset(ctx context.Context, email string) error
@@ -104,3 +371,13 @@ type RegisterMentorInput struct {
 	Experience  string
 	Specialties string
 }
+
+// RegisterEmployerInput represents the input for employer registration
+type RegisterEmployerInput struct {
+	Username  string
+	Email     string
+	Password  string
+	FirstName string
+	LastName  string
+	Bio       string
+}
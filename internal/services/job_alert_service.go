@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+const (
+	// maxSavedJobSearches is how many searches each user may save
+	maxSavedJobSearches = 20
+	// maxSavedSearchNameLength matches the name column of saved_job_searches
+	maxSavedSearchNameLength = 100
+	// jobDigestPeriod is how often daily saved searches are alerted
+	jobDigestPeriod = 24 * time.Hour
+	// jobAlertListLimit is how many job titles an alert names
+	jobAlertListLimit = 5
+	// similarJobsLimit is how many suggestions a user gets
+	similarJobsLimit = 10
+	// maxSimilarJobTerms caps the skills a suggestion query is built from
+	maxSimilarJobTerms = 20
+)
+
+// JobAlertService keeps users informed about the job board: saved searches
+// alerted instantly or in a daily digest, bookmarked jobs and suggestions
+// based on their profile skills
+type JobAlertService struct {
+	alertRepo   repository.IJobAlertRepository
+	jobRepo     repository.IJobRepository
+	profileRepo repository.IProfileRepository
+	skillRepo   repository.ISkillRepository
+	notifier    INotificationService
+}
+
+func NewJobAlertService(
+	alertRepo repository.IJobAlertRepository,
+	jobRepo repository.IJobRepository,
+	profileRepo repository.IProfileRepository,
+	skillRepo repository.ISkillRepository,
+	notifier INotificationService,
+) IJobAlertService {
+	return &JobAlertService{
+		alertRepo:   alertRepo,
+		jobRepo:     jobRepo,
+		profileRepo: profileRepo,
+		skillRepo:   skillRepo,
+		notifier:    notifier,
+	}
+}
+
+// CreateSavedSearch saves a user's job board filters to be alerted about
+func (s *JobAlertService) CreateSavedSearch(ctx context.Context, userID int, search *models.SavedJobSearch) (*models.SavedJobSearch, error) {
+	if err := validateSavedSearch(search); err != nil {
+		return nil, err
+	}
+
+	count, err := s.alertRepo.CountSavedSearches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSavedJobSearches {
+		return nil, fmt.Errorf("you can save at most %d job searches", maxSavedJobSearches)
+	}
+
+	search.UserID = userID
+	if err := s.alertRepo.CreateSavedSearch(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// UpdateSavedSearch changes the name, filters or alert frequency of a saved search
+func (s *JobAlertService) UpdateSavedSearch(ctx context.Context, userID, searchID int, update *models.SavedJobSearch) (*models.SavedJobSearch, error) {
+	if err := validateSavedSearch(update); err != nil {
+		return nil, err
+	}
+
+	update.ID = searchID
+	update.UserID = userID
+	if err := s.alertRepo.UpdateSavedSearch(ctx, update); err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+// DeleteSavedSearch removes a saved search and stops its alerts
+func (s *JobAlertService) DeleteSavedSearch(ctx context.Context, userID, searchID int) error {
+	return s.alertRepo.DeleteSavedSearch(ctx, userID, searchID)
+}
+
+// ListSavedSearches returns the user's saved searches
+func (s *JobAlertService) ListSavedSearches(ctx context.Context, userID int) ([]*models.SavedJobSearch, error) {
+	return s.alertRepo.ListSavedSearches(ctx, userID)
+}
+
+// SaveJob bookmarks an active job
+func (s *JobAlertService) SaveJob(ctx context.Context, userID, jobID int) error {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status != models.JobStatus.Active || (job.ExpiresAt != nil && !job.ExpiresAt.After(time.Now())) {
+		return repository.ErrJobNotFound
+	}
+	return s.alertRepo.SaveJob(ctx, userID, jobID)
+}
+
+// UnsaveJob removes a bookmark
+func (s *JobAlertService) UnsaveJob(ctx context.Context, userID, jobID int) error {
+	return s.alertRepo.UnsaveJob(ctx, userID, jobID)
+}
+
+// ListSavedJobs returns the user's bookmarked jobs, including ones that have
+// since expired or closed so the user can see what happened to them
+func (s *JobAlertService) ListSavedJobs(ctx context.Context, userID int) ([]*models.SavedJob, error) {
+	return s.alertRepo.ListSavedJobs(ctx, userID)
+}
+
+// SimilarJobs suggests active jobs matching any of the user's profile skills,
+// leaving out jobs they posted or already applied for
+func (s *JobAlertService) SimilarJobs(ctx context.Context, userID int) ([]*models.Job, error) {
+	terms, err := s.profileTerms(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return []*models.Job{}, nil
+	}
+
+	result, err := s.jobRepo.SearchJobs(ctx, &models.JobSearchQuery{
+		Text:             strings.Join(terms, " or "),
+		ExcludeAppliedBy: userID,
+		Page:             1,
+		PageSize:         similarJobsLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Jobs, nil
+}
+
+// profileTerms returns the user's skills as quoted search terms: the skills
+// picked from the taxonomy or, for profiles without any, the free-text skills
+func (s *JobAlertService) profileTerms(ctx context.Context, userID int) ([]string, error) {
+	skills, err := s.skillRepo.GetProfileSkills(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, skill := range skills {
+		names = append(names, skill.Name)
+	}
+	if len(names) == 0 {
+		profile, err := s.profileRepo.GetProfileByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil {
+			names = strings.Split(profile.Skills, ",")
+		}
+	}
+
+	terms := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(name, `"`, "")))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		terms = append(terms, `"`+name+`"`)
+		if len(terms) == maxSimilarJobTerms {
+			break
+		}
+	}
+	return terms, nil
+}
+
+// NotifyNewJob alerts the owners of instant saved searches matching a job
+// that was just published. A user is alerted once however many of their
+// searches match.
+func (s *JobAlertService) NotifyNewJob(ctx context.Context, job *models.Job) {
+	searches, err := s.alertRepo.ListSavedSearchesMatchingJob(ctx, job.ID, models.AlertFrequency.Instant)
+	if err != nil {
+		log.Printf("Failed to match saved job searches for job %d: %v", job.ID, err)
+		return
+	}
+
+	now := time.Now()
+	alerted := make(map[int]bool, len(searches))
+	for _, search := range searches {
+		if !alerted[search.UserID] {
+			alerted[search.UserID] = true
+			message := fmt.Sprintf("%s at %s matches your saved search %s.", job.Title, job.Company, search.Name)
+			if err := s.notifier.Notify(ctx, search.UserID, models.NotificationType.JobAlert, "New job match", message); err != nil {
+				log.Printf("Failed to send job alert %d to user %d: %v", search.ID, search.UserID, err)
+			}
+		}
+		if err := s.alertRepo.MarkSavedSearchAlerted(ctx, search.ID, now); err != nil {
+			log.Printf("Failed to mark job alert %d sent: %v", search.ID, err)
+		}
+	}
+}
+
+// SendDailyDigests sends each daily saved search that is due a digest of the
+// jobs published since its last one
+func (s *JobAlertService) SendDailyDigests(ctx context.Context) error {
+	now := time.Now()
+	searches, err := s.alertRepo.ListDueSavedSearches(ctx, models.AlertFrequency.Daily, now.Add(-jobDigestPeriod))
+	if err != nil {
+		return err
+	}
+
+	for _, search := range searches {
+		query := search.SearchQuery()
+		query.PublishedAfter = &search.LastAlertedAt
+		query.ExcludeAppliedBy = search.UserID
+		query.Page = 1
+		query.PageSize = jobAlertListLimit
+
+		result, err := s.jobRepo.SearchJobs(ctx, query)
+		if err != nil {
+			log.Printf("Failed to run saved job search %d: %v", search.ID, err)
+			continue
+		}
+
+		if result.Total > 0 {
+			title := fmt.Sprintf("%d new jobs for %s", result.Total, search.Name)
+			if result.Total == 1 {
+				title = fmt.Sprintf("1 new job for %s", search.Name)
+			}
+			if err := s.notifier.Notify(ctx, search.UserID, models.NotificationType.JobAlert, title, digestMessage(result)); err != nil {
+				log.Printf("Failed to send job digest %d to user %d: %v", search.ID, search.UserID, err)
+			}
+		}
+		if err := s.alertRepo.MarkSavedSearchAlerted(ctx, search.ID, now); err != nil {
+			log.Printf("Failed to mark job digest %d sent: %v", search.ID, err)
+		}
+	}
+	return nil
+}
+
+// RunDigests sends due digests immediately and then on every interval until ctx is cancelled
+func (s *JobAlertService) RunDigests(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDailyDigests(ctx); err != nil {
+			log.Printf("Job alert digests: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// digestMessage names the first jobs of a digest and counts the rest
+func digestMessage(result *models.JobSearchResult) string {
+	titles := make([]string, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		titles = append(titles, fmt.Sprintf("%s at %s", job.Title, job.Company))
+	}
+	message := strings.Join(titles, "; ")
+	if more := result.Total - len(result.Jobs); more > 0 {
+		message += fmt.Sprintf(" and %d more", more)
+	}
+	return message + "."
+}
+
+// validateSavedSearch trims a saved search and checks its name, filters and frequency
+func validateSavedSearch(search *models.SavedJobSearch) error {
+	search.Name = strings.TrimSpace(search.Name)
+	if search.Name == "" {
+		return errors.New("a saved search needs a name")
+	}
+	if len(search.Name) > maxSavedSearchNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxSavedSearchNameLength)
+	}
+
+	query := search.SearchQuery()
+	if err := normalizeJobSearch(query); err != nil {
+		return err
+	}
+	search.Text = query.Text
+	search.Location = query.Location
+
+	if search.Frequency == "" {
+		search.Frequency = models.AlertFrequency.Daily
+	}
+	return checkJobEnum("frequency", search.Frequency, models.AlertFrequency.Instant, models.AlertFrequency.Daily)
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+)
+
+// maxJobImportRows caps the number of postings in a single import
+const maxJobImportRows = 1000
+
+// Formats a bulk job import can be read from
+const (
+	JobImportCSV  = "csv"
+	JobImportJSON = "json"
+)
+
+// jobImportFields are the models.Job fields an import can set, by JSON name
+var jobImportFields = map[string]bool{
+	"external_id":      true,
+	"title":            true,
+	"company":          true,
+	"location":         true,
+	"description":      true,
+	"requirements":     true,
+	"salary_range":     true,
+	"job_type":         true,
+	"experience_level": true,
+	"remote_policy":    true,
+	"contact_email":    true,
+}
+
+// jobImportRecord is one posting read from an import, keyed by job field
+type jobImportRecord struct {
+	row    int
+	fields map[string]string
+}
+
+// ImportJobs reads postings from a CSV file with a header row or a JSON array
+// of objects and creates or updates them by external ID. Columns are matched
+// to job fields by name unless mapping maps them explicitly; a column mapped
+// to "" is left out. Invalid rows are skipped and reported with their errors;
+// the valid rows are written in one transaction. On a dry run nothing is
+// written and the result only reports what would happen. Imported postings
+// are published straight away like an admin's own postings.
+func (s *JobService) ImportJobs(ctx context.Context, adminID int, format string, data io.Reader, mapping map[string]string, dryRun bool) (*models.JobImportResult, error) {
+	for column, field := range mapping {
+		if field != "" && !jobImportFields[field] {
+			return nil, fmt.Errorf("column %q is mapped to unknown job field %q", column, field)
+		}
+	}
+
+	var records []*jobImportRecord
+	var err error
+	switch format {
+	case JobImportCSV:
+		records, err = readJobImportCSV(data, mapping)
+	case JobImportJSON:
+		records, err = readJobImportJSON(data, mapping)
+	default:
+		return nil, fmt.Errorf("import format must be one of %s, %s", JobImportCSV, JobImportJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the import has no postings")
+	}
+	if len(records) > maxJobImportRows {
+		return nil, fmt.Errorf("an import can have at most %d postings", maxJobImportRows)
+	}
+
+	result := &models.JobImportResult{DryRun: dryRun, Rows: make([]*models.JobImportRow, 0, len(records))}
+	jobs := make(map[*models.JobImportRow]*models.Job, len(records))
+	firstRow := make(map[string]int, len(records))
+	var externalIDs []string
+	for _, record := range records {
+		job := importedJob(record.fields)
+		row := &models.JobImportRow{Row: record.row, ExternalID: job.ExternalID, Title: job.Title}
+		result.Rows = append(result.Rows, row)
+
+		switch first, seen := firstRow[job.ExternalID]; {
+		case job.ExternalID == "":
+			row.Errors = append(row.Errors, "external_id is required")
+		case len(job.ExternalID) > 255:
+			row.Errors = append(row.Errors, "external_id must be at most 255 characters")
+		case seen:
+			row.Errors = append(row.Errors, fmt.Sprintf("external_id %s is also used by row %d", job.ExternalID, first))
+		default:
+			firstRow[job.ExternalID] = record.row
+			externalIDs = append(externalIDs, job.ExternalID)
+		}
+		if err := validateJob(job); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		jobs[row] = job
+	}
+
+	existing, err := s.jobRepo.GetJobsByExternalIDs(ctx, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	byExternalID := make(map[string]*models.Job, len(existing))
+	for _, job := range existing {
+		byExternalID[job.ExternalID] = job
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.postingTTL)
+	var creates, updates []*models.Job
+	var revisions []*models.JobRevision
+	rowOf := make(map[*models.Job]*models.JobImportRow)
+	for _, row := range result.Rows {
+		job := jobs[row]
+		current := byExternalID[job.ExternalID]
+		if current != nil && current.Status == models.JobStatus.Closed {
+			row.Errors = append(row.Errors, fmt.Sprintf("job %d is closed and cannot be updated", current.ID))
+		}
+		if len(row.Errors) > 0 {
+			row.Action = models.JobImportAction.Error
+			result.Failed++
+			continue
+		}
+
+		if current == nil {
+			job.Status = models.JobStatus.Active
+			job.CreatedBy = adminID
+			job.ExpiresAt = &expiresAt
+			job.ModeratedBy = adminID
+			job.ModeratedAt = &now
+			row.Action = models.JobImportAction.Create
+			result.Created++
+			creates = append(creates, job)
+			rowOf[job] = row
+			continue
+		}
+
+		row.JobID = current.ID
+		row.Changes = jobChanges(current, job)
+		if len(row.Changes) == 0 {
+			row.Action = models.JobImportAction.Unchanged
+			result.Unchanged++
+			continue
+		}
+		current.Title = job.Title
+		current.Company = job.Company
+		current.Location = job.Location
+		current.Description = job.Description
+		current.Requirements = job.Requirements
+		current.SalaryRange = job.SalaryRange
+		current.JobType = job.JobType
+		current.ExperienceLevel = job.ExperienceLevel
+		current.RemotePolicy = job.RemotePolicy
+		current.ContactEmail = job.ContactEmail
+		row.Action = models.JobImportAction.Update
+		result.Updated++
+		updates = append(updates, current)
+		rowOf[current] = row
+		revisions = append(revisions, &models.JobRevision{EditorID: adminID, Changes: row.Changes})
+	}
+
+	if dryRun || len(creates)+len(updates) == 0 {
+		return result, nil
+	}
+
+	// Rows another import or edit got to first are reported like invalid rows
+	skipped, err := s.jobRepo.ImportJobs(ctx, creates, updates, revisions)
+	if err != nil {
+		return nil, err
+	}
+	for job, reason := range skipped {
+		row := rowOf[job]
+		if row.Action == models.JobImportAction.Create {
+			result.Created--
+		} else {
+			result.Updated--
+		}
+		row.Action = models.JobImportAction.Error
+		row.Changes = nil
+		row.Errors = append(row.Errors, reason.Error())
+		result.Failed++
+	}
+	result.Applied = len(skipped) < len(creates)+len(updates)
+
+	for _, job := range creates {
+		if skipped[job] != nil {
+			continue
+		}
+		rowOf[job].JobID = job.ID
+		s.alerts.NotifyNewJob(ctx, job)
+	}
+	return result, nil
+}
+
+// importedJob builds a posting from an import row, normalizing the spellings
+// spreadsheets tend to use for the enums ("Full Time", "FULL_TIME")
+func importedJob(fields map[string]string) *models.Job {
+	return &models.Job{
+		ExternalID:      strings.TrimSpace(fields["external_id"]),
+		Title:           fields["title"],
+		Company:         fields["company"],
+		Location:        fields["location"],
+		Description:     fields["description"],
+		Requirements:    fields["requirements"],
+		SalaryRange:     fields["salary_range"],
+		JobType:         normalizeJobEnum(fields["job_type"]),
+		ExperienceLevel: normalizeJobEnum(fields["experience_level"]),
+		RemotePolicy:    normalizeJobEnum(fields["remote_policy"]),
+		ContactEmail:    fields["contact_email"],
+	}
+}
+
+func normalizeJobEnum(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.NewReplacer("_", "-", " ", "-").Replace(value)
+}
+
+// importField returns the job field a source column fills, or "" for
+// columns that are not imported
+func importField(column string, mapping map[string]string) string {
+	if field, ok := mapping[column]; ok {
+		return field
+	}
+	field := strings.ToLower(strings.TrimSpace(column))
+	field = strings.NewReplacer(" ", "_", "-", "_").Replace(field)
+	if jobImportFields[field] {
+		return field
+	}
+	return ""
+}
+
+// readJobImportCSV reads a CSV file whose first row names the columns. Rows
+// are numbered as a spreadsheet shows them, the header being row 1.
+func readJobImportCSV(data io.Reader, mapping map[string]string) ([]*jobImportRecord, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	columns := make([]string, len(header))
+	for i, column := range header {
+		columns[i] = importField(column, mapping)
+	}
+
+	var records []*jobImportRecord
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		record := &jobImportRecord{row: row, fields: make(map[string]string)}
+		empty := true
+		for i, value := range values {
+			if i < len(columns) && columns[i] != "" {
+				record.fields[columns[i]] = value
+			}
+			if strings.TrimSpace(value) != "" {
+				empty = false
+			}
+		}
+		// Spreadsheets often export trailing blank rows
+		if !empty {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// readJobImportJSON reads a JSON array of objects. Rows are numbered from 1.
+func readJobImportJSON(data io.Reader, mapping map[string]string) ([]*jobImportRecord, error) {
+	var objects []map[string]interface{}
+	if err := json.NewDecoder(data).Decode(&objects); err != nil {
+		return nil, fmt.Errorf("invalid JSON: expected an array of postings: %w", err)
+	}
+
+	records := make([]*jobImportRecord, 0, len(objects))
+	for i, object := range objects {
+		record := &jobImportRecord{row: i + 1, fields: make(map[string]string)}
+		for key, value := range object {
+			field := importField(key, mapping)
+			if field == "" {
+				continue
+			}
+			switch v := value.(type) {
+			case nil:
+			case string:
+				record.fields[field] = v
+			case float64:
+				record.fields[field] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				record.fields[field] = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("row %d: %s must be a string", i+1, key)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
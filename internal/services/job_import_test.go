@@ -0,0 +1,78 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadJobImportCSV(t *testing.T) {
+	data := "\uFEFFExternal ID,Job Title,Company,Job-Type,Notes\n" +
+		"ext-1,Backend Engineer,Acme,Full Time,ignored\n" +
+		",,,,\n" +
+		"ext-2,\"Data Analyst, Junior\",Globex,PART_TIME\n"
+
+	records, err := readJobImportCSV(strings.NewReader(data), map[string]string{"Job Title": "title"})
+	if err != nil {
+		t.Fatalf("readJobImportCSV: %v", err)
+	}
+
+	want := []*jobImportRecord{
+		{row: 2, fields: map[string]string{"external_id": "ext-1", "title": "Backend Engineer", "company": "Acme", "job_type": "Full Time"}},
+		{row: 4, fields: map[string]string{"external_id": "ext-2", "title": "Data Analyst, Junior", "company": "Globex", "job_type": "PART_TIME"}},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %+v, want %+v", records, want)
+	}
+
+	if job := importedJob(records[1].fields); job.JobType != "part-time" {
+		t.Errorf("job type normalized to %q, want %q", job.JobType, "part-time")
+	}
+}
+
+func TestReadJobImportCSVMappingLeavesColumnsOut(t *testing.T) {
+	data := "external_id,title,company\next-1,Engineer,Acme\n"
+
+	records, err := readJobImportCSV(strings.NewReader(data), map[string]string{"company": ""})
+	if err != nil {
+		t.Fatalf("readJobImportCSV: %v", err)
+	}
+	if _, ok := records[0].fields["company"]; ok {
+		t.Errorf("column mapped to \"\" was imported: %+v", records[0].fields)
+	}
+}
+
+func TestReadJobImportJSON(t *testing.T) {
+	data := `[
+		{"external_id": 1042, "title": "Designer", "remote_policy": "Hybrid", "salary": null, "unknown": "x"},
+		{"id": "ext-2", "title": "Writer"}
+	]`
+
+	records, err := readJobImportJSON(strings.NewReader(data), map[string]string{"id": "external_id"})
+	if err != nil {
+		t.Fatalf("readJobImportJSON: %v", err)
+	}
+
+	want := []*jobImportRecord{
+		{row: 1, fields: map[string]string{"external_id": "1042", "title": "Designer", "remote_policy": "Hybrid"}},
+		{row: 2, fields: map[string]string{"external_id": "ext-2", "title": "Writer"}},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %+v, want %+v", records, want)
+	}
+}
+
+func TestReadJobImportRejectsMalformedInput(t *testing.T) {
+	if _, err := readJobImportCSV(strings.NewReader(""), nil); err == nil {
+		t.Error("empty CSV was accepted")
+	}
+	if _, err := readJobImportCSV(strings.NewReader("title\n\"unterminated\n"), nil); err == nil {
+		t.Error("malformed CSV was accepted")
+	}
+	if _, err := readJobImportJSON(strings.NewReader(`{"title": "not an array"}`), nil); err == nil {
+		t.Error("JSON object was accepted in place of an array")
+	}
+	if _, err := readJobImportJSON(strings.NewReader(`[{"title": ["nested"]}]`), nil); err == nil {
+		t.Error("nested JSON value was accepted")
+	}
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"mentorApp/internal/models"
+)
+
+// maxReferralNoteLength caps the note a mentor sends with a referral
+const maxReferralNoteLength = 2000
+
+// ReferMentee lets an approved mentor recommend one of their current or past
+// mentees for an active job. The mentee is told and, when they apply, the
+// application is credited to the mentor.
+func (s *JobService) ReferMentee(ctx context.Context, mentorID, jobID, menteeID int, note string) (*models.JobReferral, error) {
+	mentor, err := s.userRepo.GetUserByID(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+	if mentor == nil || !mentor.IsMentor || !mentor.IsApproved {
+		return nil, errors.New("only approved mentors can refer mentees to jobs")
+	}
+
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentored, err := s.hasMentored(ctx, mentorID, menteeID)
+	if err != nil {
+		return nil, err
+	}
+	if !mentored {
+		return nil, errors.New("you can only refer mentees you mentor or have mentored")
+	}
+
+	applications, err := s.jobRepo.ListUserApplications(ctx, menteeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range applications {
+		if app.JobID == jobID && app.Status != models.ApplicationStatus.Withdrawn {
+			return nil, errors.New("this mentee has already applied for this job")
+		}
+	}
+
+	note = strings.TrimSpace(note)
+	if len(note) > maxReferralNoteLength {
+		return nil, fmt.Errorf("note must be at most %d characters", maxReferralNoteLength)
+	}
+
+	referral := &models.JobReferral{
+		JobID:    jobID,
+		MentorID: mentorID,
+		MenteeID: menteeID,
+		Note:     note,
+	}
+	if err := s.jobRepo.CreateReferral(ctx, referral); err != nil {
+		return nil, err
+	}
+
+	created, err := s.jobRepo.GetReferral(ctx, referral.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentorName := created.MentorName
+	if mentorName == "" {
+		mentorName = "Your mentor"
+	}
+	message := fmt.Sprintf("%s referred you to %s at %s.", mentorName, job.Title, job.Company)
+	if err := s.notifier.Notify(ctx, menteeID, models.NotificationType.JobReferral, "You were referred to a job", message); err != nil {
+		log.Printf("Failed to notify mentee %d of referral %d: %v", menteeID, created.ID, err)
+	}
+
+	return created, nil
+}
+
+// ListMyReferrals returns the referrals a mentor made with a count of their outcomes
+func (s *JobService) ListMyReferrals(ctx context.Context, mentorID int) (*models.JobReferralHistory, error) {
+	referrals, err := s.jobRepo.ListMentorReferrals(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.JobReferralHistory{
+		Summary:   summarizeReferrals(referrals),
+		Referrals: referrals,
+	}, nil
+}
+
+// ListReceivedReferrals returns the referrals a mentee received
+func (s *JobService) ListReceivedReferrals(ctx context.Context, menteeID int) ([]*models.JobReferral, error) {
+	return s.jobRepo.ListMenteeReferrals(ctx, menteeID)
+}
+
+// hasMentored reports whether the mentor has an active or completed
+// mentorship with the mentee
+func (s *JobService) hasMentored(ctx context.Context, mentorID, menteeID int) (bool, error) {
+	requests, err := s.mentorshipRepo.ListMenteeRequests(ctx, menteeID)
+	if err != nil {
+		return false, err
+	}
+	for _, request := range requests {
+		if request.MentorID != mentorID {
+			continue
+		}
+		if request.Status == models.RequestStatus.Approved || request.Status == models.RequestStatus.Completed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// notifyReferrer tells the mentor who referred an applicant how their
+// application is going
+func (s *JobService) notifyReferrer(ctx context.Context, app *models.JobApplication, message string) {
+	if app.ReferrerID == 0 {
+		return
+	}
+	if err := s.notifier.Notify(ctx, app.ReferrerID, models.NotificationType.ReferralUpdated, "Referral update", message); err != nil {
+		log.Printf("Failed to notify referrer %d of application %d: %v", app.ReferrerID, app.ID, err)
+	}
+}
+
+// summarizeReferrals counts referrals by where they are in the pipeline
+func summarizeReferrals(referrals []*models.JobReferral) models.JobReferralSummary {
+	summary := models.JobReferralSummary{Total: len(referrals)}
+	for _, referral := range referrals {
+		switch referral.Status {
+		case models.ReferralStatus.Referred:
+			summary.Referred++
+		case models.ApplicationStatus.Pending, models.ApplicationStatus.Reviewed:
+			summary.InReview++
+		case models.ApplicationStatus.Interview:
+			summary.Interview++
+		case models.ApplicationStatus.Accepted:
+			summary.Hired++
+		case models.ApplicationStatus.Rejected:
+			summary.Rejected++
+		case models.ApplicationStatus.Withdrawn:
+			summary.Withdrawn++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,822 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+const (
+	defaultJobPageSize = 20
+	maxJobPageSize     = 100
+	// maxCoverLetterLength caps cover letters at a few pages of text
+	maxCoverLetterLength = 10000
+	// jobRenewalWindow is how long before expiry an active posting may be renewed
+	jobRenewalWindow = 7 * 24 * time.Hour
+)
+
+// resumeExtensions are the file types accepted as resumes
+var resumeExtensions = map[string]bool{
+	".pdf":  true,
+	".doc":  true,
+	".docx": true,
+	".odt":  true,
+	".rtf":  true,
+	".txt":  true,
+}
+
+// applicationTransitions lists the statuses a reviewer may move an application
+// to from each status. Accepted, rejected and withdrawn applications are final.
+var applicationTransitions = map[string][]string{
+	models.ApplicationStatus.Pending:   {models.ApplicationStatus.Reviewed, models.ApplicationStatus.Rejected},
+	models.ApplicationStatus.Reviewed:  {models.ApplicationStatus.Interview, models.ApplicationStatus.Rejected},
+	models.ApplicationStatus.Interview: {models.ApplicationStatus.Accepted, models.ApplicationStatus.Rejected},
+}
+
+// JobService runs the job board, its postings and the applications to them.
+// Approved postings stay on the board for postingTTL unless renewed, and
+// alerts is told about every posting that goes live. Mentors may refer
+// their mentees to postings and follow how the referrals turn out.
+type JobService struct {
+	jobRepo        repository.IJobRepository
+	userRepo       repository.IUserRepository
+	mentorshipRepo repository.IMentorshipRepository
+	files          FileStore
+	notifier       INotificationService
+	alerts         IJobAlertService
+	postingTTL     time.Duration
+}
+
+func NewJobService(
+	jobRepo repository.IJobRepository,
+	userRepo repository.IUserRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	files FileStore,
+	notifier INotificationService,
+	alerts IJobAlertService,
+	postingTTL time.Duration,
+) IJobService {
+	return &JobService{
+		jobRepo:        jobRepo,
+		userRepo:       userRepo,
+		mentorshipRepo: mentorshipRepo,
+		files:          files,
+		notifier:       notifier,
+		alerts:         alerts,
+		postingTTL:     postingTTL,
+	}
+}
+
+// SearchJobs returns one page of active jobs matching the query
+func (s *JobService) SearchJobs(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error) {
+	if err := normalizeJobSearch(query); err != nil {
+		return nil, err
+	}
+
+	result, err := s.jobRepo.SearchJobs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result.TotalPages = (result.Total + query.PageSize - 1) / query.PageSize
+	return result, nil
+}
+
+// GetJob returns an active job posting. Expired and closed postings are not
+// shown on the board.
+func (s *JobService) GetJob(ctx context.Context, jobID int) (*models.Job, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.JobStatus.Active || (job.ExpiresAt != nil && !job.ExpiresAt.After(time.Now())) {
+		return nil, repository.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// CreatePosting submits a job posting. Postings by approved mentors and
+// employers wait for moderation; postings by admins go live straight away.
+func (s *JobService) CreatePosting(ctx context.Context, userID int, job *models.Job) (*models.Job, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !(user.IsAdmin || (user.IsApproved && (user.IsMentor || user.IsEmployer()))) {
+		return nil, errors.New("only approved mentors, approved employers and admins can post jobs")
+	}
+
+	if err := validateJob(job); err != nil {
+		return nil, err
+	}
+
+	job.CreatedBy = userID
+	job.RenewalCount = 0
+	job.ModerationNote = ""
+	if user.IsAdmin {
+		now := time.Now()
+		expiresAt := now.Add(s.postingTTL)
+		job.Status = models.JobStatus.Active
+		job.ExpiresAt = &expiresAt
+		job.ModeratedBy = userID
+		job.ModeratedAt = &now
+	} else {
+		job.Status = models.JobStatus.Pending
+		job.IsFeatured = false
+		job.ExpiresAt = nil
+		job.ModeratedBy = 0
+		job.ModeratedAt = nil
+	}
+
+	if err := s.jobRepo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	if job.Status == models.JobStatus.Active {
+		s.alerts.NotifyNewJob(ctx, job)
+	}
+	return s.jobRepo.GetJob(ctx, job.ID)
+}
+
+// UpdatePosting edits a posting's details and records the changed fields in
+// its history. An edit by anyone but an admin sends the posting back to moderation.
+func (s *JobService) UpdatePosting(ctx context.Context, userID, jobID int, update *models.Job) (*models.Job, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.requirePoster(ctx, userID, job)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == models.JobStatus.Closed {
+		return nil, errors.New("a closed posting cannot be edited")
+	}
+
+	if err := validateJob(update); err != nil {
+		return nil, err
+	}
+
+	changes := jobChanges(job, update)
+	if len(changes) == 0 {
+		return job, nil
+	}
+
+	job.Title = update.Title
+	job.Company = update.Company
+	job.Location = update.Location
+	job.Description = update.Description
+	job.Requirements = update.Requirements
+	job.SalaryRange = update.SalaryRange
+	job.JobType = update.JobType
+	job.ExperienceLevel = update.ExperienceLevel
+	job.RemotePolicy = update.RemotePolicy
+	job.ContactEmail = update.ContactEmail
+	if !admin {
+		job.Status = models.JobStatus.Pending
+		job.ModerationNote = ""
+	}
+
+	revision := &models.JobRevision{EditorID: userID, Changes: changes}
+	if err := s.jobRepo.UpdateJob(ctx, job, revision); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListPostings returns the postings a user has made, in every status
+func (s *JobService) ListPostings(ctx context.Context, userID int) ([]*models.Job, error) {
+	return s.jobRepo.ListJobs(ctx, "", userID)
+}
+
+// ListJobs returns every posting for admins, optionally in one status;
+// pending postings make up the moderation queue
+func (s *JobService) ListJobs(ctx context.Context, status string) ([]*models.Job, error) {
+	if status != "" && !validJobStatus(status) {
+		return nil, fmt.Errorf("unknown job status %q", status)
+	}
+	return s.jobRepo.ListJobs(ctx, status, 0)
+}
+
+// ListRevisions returns a posting's edit history to its poster or an admin
+func (s *JobService) ListRevisions(ctx context.Context, userID, jobID int) ([]*models.JobRevision, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.requirePoster(ctx, userID, job); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.ListJobRevisions(ctx, jobID)
+}
+
+// ApproveJob publishes a pending posting for postingTTL
+func (s *JobService) ApproveJob(ctx context.Context, adminID, jobID int) (*models.Job, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.JobStatus.Pending {
+		return nil, fmt.Errorf("only pending postings can be approved, this one is %s", job.Status)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.postingTTL)
+	job.Status = models.JobStatus.Active
+	job.ExpiresAt = &expiresAt
+	job.ModeratedBy = adminID
+	job.ModeratedAt = &now
+	job.ModerationNote = ""
+	if err := s.jobRepo.UpdateJob(ctx, job, nil); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your posting %s is now live until %s.", job.Title, expiresAt.Format("Jan 2, 2006"))
+	s.notifyPoster(ctx, job, models.NotificationType.JobModerated, "Job posting approved", message)
+	s.alerts.NotifyNewJob(ctx, job)
+	return job, nil
+}
+
+// RejectJob turns down a pending posting with a reason shown to its poster
+func (s *JobService) RejectJob(ctx context.Context, adminID, jobID int, reason string) (*models.Job, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("a reason is required to reject a posting")
+	}
+
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.JobStatus.Pending {
+		return nil, fmt.Errorf("only pending postings can be rejected, this one is %s", job.Status)
+	}
+
+	now := time.Now()
+	job.Status = models.JobStatus.Rejected
+	job.ModeratedBy = adminID
+	job.ModeratedAt = &now
+	job.ModerationNote = reason
+	if err := s.jobRepo.UpdateJob(ctx, job, nil); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your posting %s was not approved: %s", job.Title, reason)
+	s.notifyPoster(ctx, job, models.NotificationType.JobModerated, "Job posting rejected", message)
+	return job, nil
+}
+
+// RenewJob keeps a posting on the board for another postingTTL. Active
+// postings can be renewed in their last week, expired ones at any time.
+func (s *JobService) RenewJob(ctx context.Context, userID, jobID int) (*models.Job, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.requirePoster(ctx, userID, job); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := now
+	switch job.Status {
+	case models.JobStatus.Active:
+		if job.ExpiresAt == nil {
+			return nil, errors.New("this posting does not expire")
+		}
+		if job.ExpiresAt.Sub(now) > jobRenewalWindow {
+			return nil, fmt.Errorf("postings can be renewed from %s", job.ExpiresAt.Add(-jobRenewalWindow).Format("Jan 2, 2006"))
+		}
+		if job.ExpiresAt.After(now) {
+			start = *job.ExpiresAt
+		}
+	case models.JobStatus.Expired:
+	default:
+		return nil, fmt.Errorf("a %s posting cannot be renewed", job.Status)
+	}
+
+	expiresAt := start.Add(s.postingTTL)
+	job.Status = models.JobStatus.Active
+	job.ExpiresAt = &expiresAt
+	job.RenewalCount++
+	if err := s.jobRepo.UpdateJob(ctx, job, nil); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// CloseJob takes a posting off the board for good
+func (s *JobService) CloseJob(ctx context.Context, userID, jobID int) error {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.requirePoster(ctx, userID, job); err != nil {
+		return err
+	}
+	return s.jobRepo.DeleteJob(ctx, jobID)
+}
+
+// SetFeatured pins a posting to the top of the board or unpins it
+func (s *JobService) SetFeatured(ctx context.Context, jobID int, featured bool) (*models.Job, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	job.IsFeatured = featured
+	if err := s.jobRepo.UpdateJob(ctx, job, nil); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ExpireJobs moves postings past their expiry to expired and tells their posters
+func (s *JobService) ExpireJobs(ctx context.Context) error {
+	jobs, err := s.jobRepo.ExpireJobs(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		message := fmt.Sprintf("Your posting %s has expired. Renew it to put it back on the job board.", job.Title)
+		s.notifyPoster(ctx, job, models.NotificationType.JobExpired, "Job posting expired", message)
+	}
+	return nil
+}
+
+// RunExpiry expires postings immediately and then on every interval until ctx is cancelled
+func (s *JobService) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ExpireJobs(ctx); err != nil {
+			log.Printf("Job expiry: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Apply submits a mentee's application to an active job with a cover letter
+// and a resume, given as an uploaded file or a link. A mentee referred to the
+// job applies on the referral and their mentor is told.
+func (s *JobService) Apply(ctx context.Context, userID, jobID int, app *models.JobApplication, fileName, contentType string, file io.Reader) (*models.JobApplication, error) {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsMentor || user.IsAdmin || user.IsEmployer() {
+		return nil, errors.New("only mentees can apply for jobs")
+	}
+
+	app.CoverLetter = strings.TrimSpace(app.CoverLetter)
+	if len(app.CoverLetter) > maxCoverLetterLength {
+		return nil, fmt.Errorf("cover letter must be at most %d characters", maxCoverLetterLength)
+	}
+	app.ResumeURL = strings.TrimSpace(app.ResumeURL)
+	if app.ResumeURL != "" {
+		parsed, err := url.Parse(app.ResumeURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, errors.New("resume link must be an http or https URL")
+		}
+	}
+
+	if file != nil && !resumeExtensions[strings.ToLower(filepath.Ext(fileName))] {
+		return nil, errors.New("resume must be a PDF, Word, OpenDocument, RTF or text file")
+	}
+	if app.CoverLetter == "" && file == nil && app.ResumeURL == "" {
+		return nil, errors.New("an application needs a cover letter or a resume")
+	}
+
+	// The resume is only stored once the application is known to be valid,
+	// and removed again if the application cannot be saved
+	if file != nil {
+		key, size, err := s.files.Save(ctx, fileName, file)
+		if err != nil {
+			return nil, err
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		app.ResumeKey = key
+		app.ResumeFileName = filepath.Base(fileName)
+		app.ResumeContentType = contentType
+		app.ResumeSizeBytes = size
+	}
+
+	app.JobID = jobID
+	app.UserID = userID
+	app.Status = models.ApplicationStatus.Pending
+	if err := s.jobRepo.CreateApplication(ctx, app); err != nil {
+		if app.ResumeKey != "" {
+			if delErr := s.files.Delete(ctx, app.ResumeKey); delErr != nil {
+				log.Printf("Failed to delete resume %s of a rejected application: %v", app.ResumeKey, delErr)
+			}
+		}
+		return nil, err
+	}
+
+	if job.CreatedBy != 0 {
+		message := fmt.Sprintf("A new application was submitted for %s.", job.Title)
+		if err := s.notifier.Notify(ctx, job.CreatedBy, models.NotificationType.JobApplication, "New job application", message); err != nil {
+			log.Printf("Failed to notify poster %d of application %d: %v", job.CreatedBy, app.ID, err)
+		}
+	}
+
+	created, err := s.jobRepo.GetApplication(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyReferrer(ctx, created, fmt.Sprintf("%s applied for %s at %s on your referral.", applicantName(created), created.JobTitle, created.Company))
+	return created, nil
+}
+
+// ListMyApplications returns the applications a user has made
+func (s *JobService) ListMyApplications(ctx context.Context, userID int) ([]*models.JobApplication, error) {
+	return s.jobRepo.ListUserApplications(ctx, userID)
+}
+
+// ListJobApplications returns a job's applications, optionally in one status,
+// to its poster or an admin
+func (s *JobService) ListJobApplications(ctx context.Context, reviewerID, jobID int, status string) ([]*models.JobApplication, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireReviewer(ctx, reviewerID, job); err != nil {
+		return nil, err
+	}
+	if status != "" && !validApplicationStatus(status) {
+		return nil, fmt.Errorf("unknown application status %q", status)
+	}
+
+	return s.jobRepo.ListJobApplications(ctx, jobID, status)
+}
+
+// GetApplication returns an application with its history. Applicants see
+// their status changes; reviewer notes are only shown to reviewers.
+func (s *JobService) GetApplication(ctx context.Context, userID, applicationID int) (*models.JobApplication, error) {
+	app, reviewer, err := s.accessApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.jobRepo.ListApplicationEvents(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer {
+		changes := events[:0]
+		for _, event := range events {
+			if event.FromStatus != event.ToStatus {
+				event.Note = ""
+				changes = append(changes, event)
+			}
+		}
+		events = changes
+	}
+	app.Events = events
+
+	return app, nil
+}
+
+// UpdateApplicationStatus moves an application to the next pipeline stage,
+// with an optional note, and tells the applicant and the mentor who referred them
+func (s *JobService) UpdateApplicationStatus(ctx context.Context, reviewerID, applicationID int, status, note string) (*models.JobApplication, error) {
+	app, err := s.jobRepo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepo.GetJob(ctx, app.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireReviewer(ctx, reviewerID, job); err != nil {
+		return nil, err
+	}
+
+	if !canMoveApplication(app.Status, status) {
+		return nil, fmt.Errorf("cannot move an application from %s to %s", app.Status, status)
+	}
+
+	event := &models.JobApplicationEvent{
+		ApplicationID: applicationID,
+		AuthorID:      reviewerID,
+		FromStatus:    app.Status,
+		ToStatus:      status,
+		Note:          strings.TrimSpace(note),
+	}
+	if err := s.jobRepo.UpdateApplicationStatus(ctx, event); err != nil {
+		return nil, err
+	}
+
+	title := "Application update"
+	message := fmt.Sprintf("Your application for %s at %s is now %s.", app.JobTitle, app.Company, status)
+	if err := s.notifier.Notify(ctx, app.UserID, models.NotificationType.ApplicationUpdated, title, message); err != nil {
+		log.Printf("Failed to notify applicant %d of application %d: %v", app.UserID, applicationID, err)
+	}
+	s.notifyReferrer(ctx, app, fmt.Sprintf("%s's application for %s at %s is now %s.", applicantName(app), app.JobTitle, app.Company, status))
+
+	return s.GetApplication(ctx, reviewerID, applicationID)
+}
+
+// AddApplicationNote records a reviewer note without changing the status
+func (s *JobService) AddApplicationNote(ctx context.Context, reviewerID, applicationID int, note string) (*models.JobApplication, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, errors.New("note cannot be empty")
+	}
+
+	app, err := s.jobRepo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepo.GetJob(ctx, app.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireReviewer(ctx, reviewerID, job); err != nil {
+		return nil, err
+	}
+
+	event := &models.JobApplicationEvent{
+		ApplicationID: applicationID,
+		AuthorID:      reviewerID,
+		Note:          note,
+	}
+	if err := s.jobRepo.AddApplicationEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return s.GetApplication(ctx, reviewerID, applicationID)
+}
+
+// WithdrawApplication lets an applicant pull an application that is still
+// under consideration
+func (s *JobService) WithdrawApplication(ctx context.Context, userID, applicationID int) (*models.JobApplication, error) {
+	app, err := s.jobRepo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.UserID != userID {
+		return nil, repository.ErrJobApplicationNotFound
+	}
+	if _, open := applicationTransitions[app.Status]; !open {
+		return nil, fmt.Errorf("a %s application cannot be withdrawn", app.Status)
+	}
+
+	event := &models.JobApplicationEvent{
+		ApplicationID: applicationID,
+		AuthorID:      userID,
+		FromStatus:    app.Status,
+		ToStatus:      models.ApplicationStatus.Withdrawn,
+	}
+	if err := s.jobRepo.UpdateApplicationStatus(ctx, event); err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobRepo.GetJob(ctx, app.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.CreatedBy != 0 {
+		message := fmt.Sprintf("%s withdrew their application for %s.", applicantName(app), job.Title)
+		if err := s.notifier.Notify(ctx, job.CreatedBy, models.NotificationType.ApplicationUpdated, "Application withdrawn", message); err != nil {
+			log.Printf("Failed to notify poster %d of withdrawal %d: %v", job.CreatedBy, applicationID, err)
+		}
+	}
+	s.notifyReferrer(ctx, app, fmt.Sprintf("%s withdrew their application for %s at %s.", applicantName(app), app.JobTitle, app.Company))
+
+	return s.GetApplication(ctx, userID, applicationID)
+}
+
+// OpenResume returns the resume file attached to an application, for the
+// applicant and the application's reviewers
+func (s *JobService) OpenResume(ctx context.Context, userID, applicationID int) (*models.JobApplication, io.ReadCloser, error) {
+	app, _, err := s.accessApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if app.ResumeKey == "" {
+		return nil, nil, errors.New("application has no resume file")
+	}
+
+	content, err := s.files.Open(ctx, app.ResumeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return app, content, nil
+}
+
+// accessApplication loads an application the user may see and reports
+// whether they see it as a reviewer
+func (s *JobService) accessApplication(ctx context.Context, userID, applicationID int) (*models.JobApplication, bool, error) {
+	app, err := s.jobRepo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	job, err := s.jobRepo.GetJob(ctx, app.JobID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.requireReviewer(ctx, userID, job); err == nil {
+		return app, true, nil
+	}
+	if app.UserID != userID {
+		return nil, false, repository.ErrJobApplicationNotFound
+	}
+	return app, false, nil
+}
+
+// requireReviewer checks that the user posted the job or is an admin
+func (s *JobService) requireReviewer(ctx context.Context, userID int, job *models.Job) error {
+	if _, err := s.requirePoster(ctx, userID, job); err != nil {
+		return errors.New("unauthorized: only the job's poster or an admin can review applications")
+	}
+	return nil
+}
+
+// requirePoster checks that the user posted the job or is an admin and
+// reports whether they are an admin
+func (s *JobService) requirePoster(ctx context.Context, userID int, job *models.Job) (bool, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if user != nil && user.IsAdmin {
+		return true, nil
+	}
+	if job.CreatedBy == 0 || job.CreatedBy != userID {
+		return false, errors.New("unauthorized: only the job's poster or an admin can manage it")
+	}
+	return false, nil
+}
+
+func (s *JobService) notifyPoster(ctx context.Context, job *models.Job, notificationType, title, message string) {
+	if job.CreatedBy == 0 {
+		return
+	}
+	if err := s.notifier.Notify(ctx, job.CreatedBy, notificationType, title, message); err != nil {
+		log.Printf("Failed to notify poster %d of job %d: %v", job.CreatedBy, job.ID, err)
+	}
+}
+
+// validateJob trims a posting and checks its required fields and enums
+func validateJob(job *models.Job) error {
+	for _, field := range []*string{&job.Title, &job.Company, &job.Location, &job.Description,
+		&job.Requirements, &job.SalaryRange, &job.ContactEmail} {
+		*field = strings.TrimSpace(*field)
+	}
+
+	switch {
+	case job.Title == "" || len(job.Title) > 255:
+		return errors.New("title is required and must be at most 255 characters")
+	case job.Company == "" || len(job.Company) > 255:
+		return errors.New("company is required and must be at most 255 characters")
+	case job.Description == "":
+		return errors.New("description is required")
+	case len(job.Location) > 255:
+		return errors.New("location must be at most 255 characters")
+	case len(job.SalaryRange) > 100:
+		return errors.New("salary range must be at most 100 characters")
+	case len(job.ContactEmail) > 255 || (job.ContactEmail != "" && !strings.Contains(job.ContactEmail, "@")):
+		return errors.New("contact email must be a valid email address")
+	}
+
+	if err := checkJobEnum("job type", job.JobType,
+		models.JobType.FullTime, models.JobType.PartTime, models.JobType.Contract); err != nil {
+		return err
+	}
+	if err := checkJobEnum("experience level", job.ExperienceLevel,
+		models.ExperienceLevel.Entry, models.ExperienceLevel.Mid, models.ExperienceLevel.Senior); err != nil {
+		return err
+	}
+	return checkJobEnum("remote policy", job.RemotePolicy,
+		models.RemotePolicy.Remote, models.RemotePolicy.Hybrid, models.RemotePolicy.OnSite)
+}
+
+// jobChanges lists the posting fields that update changes, by JSON name
+func jobChanges(job, update *models.Job) map[string]*models.JobFieldChange {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"title", job.Title, update.Title},
+		{"company", job.Company, update.Company},
+		{"location", job.Location, update.Location},
+		{"description", job.Description, update.Description},
+		{"requirements", job.Requirements, update.Requirements},
+		{"salary_range", job.SalaryRange, update.SalaryRange},
+		{"job_type", job.JobType, update.JobType},
+		{"experience_level", job.ExperienceLevel, update.ExperienceLevel},
+		{"remote_policy", job.RemotePolicy, update.RemotePolicy},
+		{"contact_email", job.ContactEmail, update.ContactEmail},
+	}
+
+	changes := make(map[string]*models.JobFieldChange)
+	for _, field := range fields {
+		if field.old != field.new {
+			changes[field.name] = &models.JobFieldChange{From: field.old, To: field.new}
+		}
+	}
+	return changes
+}
+
+func validJobStatus(status string) bool {
+	switch status {
+	case models.JobStatus.Pending, models.JobStatus.Active, models.JobStatus.Expired,
+		models.JobStatus.Closed, models.JobStatus.Rejected:
+		return true
+	}
+	return false
+}
+
+func canMoveApplication(from, to string) bool {
+	for _, next := range applicationTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func validApplicationStatus(status string) bool {
+	switch status {
+	case models.ApplicationStatus.Pending, models.ApplicationStatus.Reviewed, models.ApplicationStatus.Interview,
+		models.ApplicationStatus.Accepted, models.ApplicationStatus.Rejected, models.ApplicationStatus.Withdrawn:
+		return true
+	}
+	return false
+}
+
+func applicantName(app *models.JobApplication) string {
+	if app.ApplicantName != "" {
+		return app.ApplicantName
+	}
+	return "An applicant"
+}
+
+// normalizeJobSearch trims the query, rejects unknown filter values and
+// clamps the page
+func normalizeJobSearch(query *models.JobSearchQuery) error {
+	query.Text = strings.TrimSpace(query.Text)
+	query.Location = strings.TrimSpace(query.Location)
+
+	if query.JobType != "" {
+		if err := checkJobEnum("job type", query.JobType,
+			models.JobType.FullTime, models.JobType.PartTime, models.JobType.Contract); err != nil {
+			return err
+		}
+	}
+	if query.ExperienceLevel != "" {
+		if err := checkJobEnum("experience level", query.ExperienceLevel,
+			models.ExperienceLevel.Entry, models.ExperienceLevel.Mid, models.ExperienceLevel.Senior); err != nil {
+			return err
+		}
+	}
+	if query.RemotePolicy != "" {
+		if err := checkJobEnum("remote policy", query.RemotePolicy,
+			models.RemotePolicy.Remote, models.RemotePolicy.Hybrid, models.RemotePolicy.OnSite); err != nil {
+			return err
+		}
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultJobPageSize
+	}
+	if query.PageSize > maxJobPageSize {
+		query.PageSize = maxJobPageSize
+	}
+	return nil
+}
+
+// checkJobEnum reports an error unless value is one of allowed
+func checkJobEnum(field, value string, allowed ...string) error {
+	for _, v := range allowed {
+		if value == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of %s", field, strings.Join(allowed, ", "))
+}
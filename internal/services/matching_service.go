@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+// maxTeamLength matches the team columns of the matching tables
+const maxTeamLength = 100
+
+type MatchingService struct {
+	matchingRepo   repository.IMatchingRepository
+	mentorshipRepo repository.IMentorshipRepository
+	mentorships    IMentorshipService
+	notifier       INotificationService
+}
+
+func NewMatchingService(
+	matchingRepo repository.IMatchingRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	mentorships IMentorshipService,
+	notifier INotificationService,
+) IMatchingService {
+	return &MatchingService{
+		matchingRepo:   matchingRepo,
+		mentorshipRepo: mentorshipRepo,
+		mentorships:    mentorships,
+		notifier:       notifier,
+	}
+}
+
+// CreateRound opens a matching round for participants to join
+func (s *MatchingService) CreateRound(ctx context.Context, adminID int, round *models.MatchingRound) (*models.MatchingRound, error) {
+	round.Name = strings.TrimSpace(round.Name)
+	round.Description = strings.TrimSpace(round.Description)
+	if round.Name == "" {
+		return nil, errors.New("round name is required")
+	}
+
+	round.Status = models.MatchingRoundStatus.Open
+	round.CreatedBy = adminID
+	if err := s.matchingRepo.CreateRound(ctx, round); err != nil {
+		return nil, err
+	}
+
+	return round, nil
+}
+
+// ListRounds returns matching rounds, optionally only those in a status
+func (s *MatchingService) ListRounds(ctx context.Context, status string) ([]*models.MatchingRound, error) {
+	return s.matchingRepo.ListRounds(ctx, status)
+}
+
+// GetRoundDetail returns a round with its participants and proposed pairs
+func (s *MatchingService) GetRoundDetail(ctx context.Context, roundID int) (*models.MatchingRoundDetail, error) {
+	round, err := s.matchingRepo.GetRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentors, err := s.matchingRepo.ListMentors(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentees, err := s.matchingRepo.ListMentees(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := s.matchingRepo.ListPairs(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[int]bool, len(pairs))
+	for _, pair := range pairs {
+		matched[pair.MenteeID] = true
+	}
+	unmatched := []int{}
+	for _, mentee := range mentees {
+		if !matched[mentee.MenteeID] {
+			unmatched = append(unmatched, mentee.MenteeID)
+		}
+	}
+
+	return &models.MatchingRoundDetail{
+		Round:     round,
+		Mentors:   mentors,
+		Mentees:   mentees,
+		Pairs:     pairs,
+		Unmatched: unmatched,
+	}, nil
+}
+
+// ListRoundMentors returns the mentors a mentee can rank in a round. Mentors'
+// own preferences are not shown.
+func (s *MatchingService) ListRoundMentors(ctx context.Context, roundID int) ([]*models.MatchingMentor, error) {
+	if _, err := s.matchingRepo.GetRound(ctx, roundID); err != nil {
+		return nil, err
+	}
+
+	mentors, err := s.matchingRepo.ListMentors(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	for _, mentor := range mentors {
+		mentor.Preferences = nil
+	}
+
+	return mentors, nil
+}
+
+// JoinAsMentor enters a mentor into an open round with the program their
+// matches will be enrolled in, how many mentees they take and, optionally,
+// a ranking of mentees
+func (s *MatchingService) JoinAsMentor(ctx context.Context, mentorID, roundID int, entry *models.MatchingMentor) (*models.MatchingMentor, error) {
+	program, err := s.mentorshipRepo.GetProgram(ctx, entry.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	if program == nil || program.MentorID != mentorID {
+		return nil, errors.New("unauthorized: program belongs to another mentor")
+	}
+	if program.Status != models.ProgramStatus.Active {
+		return nil, errors.New("matches can only be enrolled in active programs")
+	}
+	if entry.Capacity < 1 {
+		return nil, errors.New("capacity must be at least 1")
+	}
+
+	entry.Team = strings.TrimSpace(entry.Team)
+	if len(entry.Team) > maxTeamLength {
+		return nil, fmt.Errorf("team must be at most %d characters", maxTeamLength)
+	}
+	preferences, err := uniquePreferences(entry.Preferences, int64(mentorID))
+	if err != nil {
+		return nil, err
+	}
+
+	entry.RoundID = roundID
+	entry.MentorID = mentorID
+	entry.Preferences = preferences
+	if err := s.matchingRepo.SaveMentor(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// SubmitPreferences enters a mentee into an open round with the round's
+// mentors ranked, best first
+func (s *MatchingService) SubmitPreferences(ctx context.Context, menteeID, roundID int, entry *models.MatchingMentee) (*models.MatchingMentee, error) {
+	preferences, err := uniquePreferences(entry.Preferences, int64(menteeID))
+	if err != nil {
+		return nil, err
+	}
+	if len(preferences) == 0 {
+		return nil, errors.New("rank at least one mentor")
+	}
+
+	if _, err := s.matchingRepo.GetRound(ctx, roundID); err != nil {
+		return nil, err
+	}
+	mentors, err := s.matchingRepo.ListMentors(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	inRound := make(map[int64]bool, len(mentors))
+	for _, mentor := range mentors {
+		inRound[int64(mentor.MentorID)] = true
+	}
+	for _, mentorID := range preferences {
+		if !inRound[mentorID] {
+			return nil, fmt.Errorf("mentor %d is not part of this round", mentorID)
+		}
+	}
+
+	entry.Team = strings.TrimSpace(entry.Team)
+	if len(entry.Team) > maxTeamLength {
+		return nil, fmt.Errorf("team must be at most %d characters", maxTeamLength)
+	}
+
+	entry.RoundID = roundID
+	entry.MenteeID = menteeID
+	entry.Preferences = preferences
+	if err := s.matchingRepo.SaveMentee(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// RunMatching computes a stable matching for the round and stores it as the
+// proposed pairs, replacing earlier proposals and manual changes. It can be
+// run again until the round is published.
+func (s *MatchingService) RunMatching(ctx context.Context, roundID int) (*models.MatchingRoundDetail, error) {
+	mentors, err := s.matchingRepo.ListMentors(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentees, err := s.matchingRepo.ListMentees(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := stableMatch(mentors, mentees)
+	pairs := make([]*models.MatchingPair, 0, len(matches))
+	for _, mentee := range mentees {
+		if mentorID, ok := matches[mentee.MenteeID]; ok {
+			pairs = append(pairs, &models.MatchingPair{
+				RoundID:  roundID,
+				MenteeID: mentee.MenteeID,
+				MentorID: mentorID,
+				Source:   models.MatchingPairSource.Algorithm,
+			})
+		}
+	}
+
+	if err := s.matchingRepo.ReplacePairs(ctx, roundID, pairs); err != nil {
+		return nil, err
+	}
+
+	return s.GetRoundDetail(ctx, roundID)
+}
+
+// AssignPair lets an admin match a mentee with a different mentor. The mentor
+// must have room and must not be on the mentee's team.
+func (s *MatchingService) AssignPair(ctx context.Context, roundID, menteeID, mentorID int) (*models.MatchingRoundDetail, error) {
+	detail, err := s.GetRoundDetail(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentor *models.MatchingMentor
+	for _, m := range detail.Mentors {
+		if m.MentorID == mentorID {
+			mentor = m
+		}
+	}
+	var mentee *models.MatchingMentee
+	for _, m := range detail.Mentees {
+		if m.MenteeID == menteeID {
+			mentee = m
+		}
+	}
+	if mentor == nil || mentee == nil {
+		return nil, errors.New("mentor and mentee must both be part of this round")
+	}
+	if sameTeam(mentor.Team, mentee.Team) {
+		return nil, errors.New("mentor and mentee are on the same team")
+	}
+
+	assigned := 0
+	for _, pair := range detail.Pairs {
+		if pair.MentorID == mentorID && pair.MenteeID != menteeID {
+			assigned++
+		}
+	}
+	if assigned >= mentor.Capacity {
+		return nil, fmt.Errorf("mentor already has %d of %d mentees", assigned, mentor.Capacity)
+	}
+
+	pair := &models.MatchingPair{
+		RoundID:  roundID,
+		MenteeID: menteeID,
+		MentorID: mentorID,
+		Source:   models.MatchingPairSource.Manual,
+	}
+	if err := s.matchingRepo.SavePair(ctx, pair); err != nil {
+		return nil, err
+	}
+
+	return s.GetRoundDetail(ctx, roundID)
+}
+
+// UnassignPair leaves a mentee without a mentor in the proposals
+func (s *MatchingService) UnassignPair(ctx context.Context, roundID, menteeID int) (*models.MatchingRoundDetail, error) {
+	if err := s.matchingRepo.DeletePair(ctx, roundID, menteeID); err != nil {
+		return nil, err
+	}
+	return s.GetRoundDetail(ctx, roundID)
+}
+
+// PublishRound turns every proposed pair into a mentorship request and
+// approves it the way a mentor would, so agreements and invoices apply as
+// usual, then tells both participants. A request that cannot be approved is
+// left pending for the mentor to answer.
+func (s *MatchingService) PublishRound(ctx context.Context, roundID int) (*models.MatchingRoundDetail, error) {
+	round, err := s.matchingRepo.GetRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.matchingRepo.PublishRound(ctx, roundID, fmt.Sprintf("Matched in %s", round.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, request := range requests {
+		if err := s.mentorships.ApproveRequest(ctx, request); err != nil {
+			log.Printf("Failed to approve request %d matched in round %d: %v", request.ID, roundID, err)
+		}
+
+		message := fmt.Sprintf("You have been matched in %s.", round.Name)
+		switch request.Status {
+		case models.RequestStatus.Approved:
+			message += " Your mentorship is now active."
+		case models.RequestStatus.AwaitingAgreement:
+			message += " Your mentorship starts once you have both accepted its agreement."
+		default:
+			message += " The mentor still has to confirm the mentorship."
+		}
+		for _, participant := range []int{request.MentorID, request.MenteeID} {
+			if err := s.notifier.Notify(ctx, participant, models.NotificationType.MatchPublished, "You have a new match", message); err != nil {
+				log.Printf("Failed to notify user %d of match in round %d: %v", participant, roundID, err)
+			}
+		}
+	}
+
+	return s.GetRoundDetail(ctx, roundID)
+}
+
+// stableMatch pairs mentees with mentors by deferred acceptance, with mentees
+// proposing in order of preference and each mentor holding their best
+// proposals up to capacity. The result is stable: no mentee and mentor who
+// both prefer each other to their assignment are left apart. Mentors rank
+// mentees they did not list after those they did, earliest submission first.
+// Pairs on the same team are never made. Returns mentor IDs keyed by mentee ID.
+func stableMatch(mentors []*models.MatchingMentor, mentees []*models.MatchingMentee) map[int]int {
+	type mentorState struct {
+		mentor *models.MatchingMentor
+		rank   map[int]int
+		held   []int
+	}
+
+	states := make(map[int]*mentorState, len(mentors))
+	for _, mentor := range mentors {
+		state := &mentorState{mentor: mentor, rank: make(map[int]int, len(mentees))}
+		for i, menteeID := range mentor.Preferences {
+			if _, seen := state.rank[int(menteeID)]; !seen {
+				state.rank[int(menteeID)] = i
+			}
+		}
+		for i, mentee := range mentees {
+			if _, ranked := state.rank[mentee.MenteeID]; !ranked {
+				state.rank[mentee.MenteeID] = len(mentor.Preferences) + i
+			}
+		}
+		states[mentor.MentorID] = state
+	}
+
+	byID := make(map[int]*models.MatchingMentee, len(mentees))
+	free := make([]int, 0, len(mentees))
+	for _, mentee := range mentees {
+		byID[mentee.MenteeID] = mentee
+		free = append(free, mentee.MenteeID)
+	}
+	next := make(map[int]int, len(mentees))
+
+	for len(free) > 0 {
+		menteeID := free[0]
+		free = free[1:]
+		mentee := byID[menteeID]
+
+		for next[menteeID] < len(mentee.Preferences) {
+			state := states[int(mentee.Preferences[next[menteeID]])]
+			next[menteeID]++
+			if state == nil || sameTeam(state.mentor.Team, mentee.Team) {
+				continue
+			}
+
+			state.held = append(state.held, menteeID)
+			if len(state.held) <= state.mentor.Capacity {
+				break
+			}
+
+			// Over capacity: the mentor lets go of their least preferred mentee
+			worst := 0
+			for i, held := range state.held {
+				if state.rank[held] > state.rank[state.held[worst]] {
+					worst = i
+				}
+			}
+			rejected := state.held[worst]
+			state.held = append(state.held[:worst], state.held[worst+1:]...)
+			if rejected != menteeID {
+				free = append(free, rejected)
+				break
+			}
+		}
+	}
+
+	matches := make(map[int]int, len(mentees))
+	for mentorID, state := range states {
+		for _, menteeID := range state.held {
+			matches[menteeID] = mentorID
+		}
+	}
+	return matches
+}
+
+// sameTeam reports whether two participants named the same non-empty team
+func sameTeam(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
+
+// uniquePreferences checks that a ranking lists each user once and not the
+// participant themselves
+func uniquePreferences(preferences []int64, self int64) ([]int64, error) {
+	seen := make(map[int64]bool, len(preferences))
+	for _, id := range preferences {
+		if id == self {
+			return nil, errors.New("preferences cannot include yourself")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("user %d is ranked more than once", id)
+		}
+		seen[id] = true
+	}
+	if preferences == nil {
+		preferences = []int64{}
+	}
+	return preferences, nil
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"mentorApp/internal/models"
+)
+
+func TestStableMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		mentors []*models.MatchingMentor
+		mentees []*models.MatchingMentee
+		want    map[int]int
+	}{
+		{
+			name: "mentor preference decides a contested mentor",
+			mentors: []*models.MatchingMentor{
+				{MentorID: 1, Capacity: 1, Preferences: []int64{11, 10}},
+				{MentorID: 2, Capacity: 1},
+			},
+			mentees: []*models.MatchingMentee{
+				{MenteeID: 10, Preferences: []int64{1, 2}},
+				{MenteeID: 11, Preferences: []int64{1, 2}},
+			},
+			want: map[int]int{10: 2, 11: 1},
+		},
+		{
+			name: "evicted mentee moves on to their next choice",
+			mentors: []*models.MatchingMentor{
+				{MentorID: 1, Capacity: 2, Preferences: []int64{12, 11, 10}},
+				{MentorID: 2, Capacity: 1, Preferences: []int64{10}},
+			},
+			mentees: []*models.MatchingMentee{
+				{MenteeID: 10, Preferences: []int64{1, 2}},
+				{MenteeID: 11, Preferences: []int64{1}},
+				{MenteeID: 12, Preferences: []int64{1, 2}},
+			},
+			want: map[int]int{10: 2, 11: 1, 12: 1},
+		},
+		{
+			name: "mentor with capacity 0 takes nobody",
+			mentors: []*models.MatchingMentor{
+				{MentorID: 1, Capacity: 0, Preferences: []int64{10}},
+				{MentorID: 2, Capacity: 1},
+			},
+			mentees: []*models.MatchingMentee{
+				{MenteeID: 10, Preferences: []int64{1, 2}},
+				{MenteeID: 11, Preferences: []int64{1}},
+			},
+			want: map[int]int{10: 2},
+		},
+		{
+			name: "mentees are never paired with their own team",
+			mentors: []*models.MatchingMentor{
+				{MentorID: 1, Capacity: 2, Team: "Payments"},
+				{MentorID: 2, Capacity: 2, Team: "Search"},
+			},
+			mentees: []*models.MatchingMentee{
+				{MenteeID: 10, Team: "payments", Preferences: []int64{1, 2}},
+				{MenteeID: 11, Team: "search", Preferences: []int64{2}},
+				{MenteeID: 12, Preferences: []int64{1}},
+			},
+			want: map[int]int{10: 2, 12: 1},
+		},
+		{
+			name: "unranked mentees fall back to submission order",
+			mentors: []*models.MatchingMentor{
+				{MentorID: 1, Capacity: 2, Preferences: []int64{13}},
+			},
+			mentees: []*models.MatchingMentee{
+				{MenteeID: 11, Preferences: []int64{1}},
+				{MenteeID: 10, Preferences: []int64{1}},
+				{MenteeID: 12, Preferences: []int64{1}},
+				{MenteeID: 13, Preferences: []int64{1}},
+			},
+			want: map[int]int{11: 1, 13: 1},
+		},
+		{
+			name: "mentees are only matched with mentors they ranked",
+			mentors: []*models.MatchingMentor{
+				{MentorID: 1, Capacity: 1, Preferences: []int64{10}},
+			},
+			mentees: []*models.MatchingMentee{
+				{MenteeID: 10, Preferences: []int64{}},
+				{MenteeID: 11, Preferences: []int64{1}},
+			},
+			want: map[int]int{11: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stableMatch(tt.mentors, tt.mentees)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			checkStable(t, tt.mentors, tt.mentees, got)
+		})
+	}
+}
+
+// checkStable fails the test if a mentee and a mentor who could be paired
+// both prefer each other to what the matching gave them, or a mentor holds
+// more mentees than their capacity
+func checkStable(t *testing.T, mentors []*models.MatchingMentor, mentees []*models.MatchingMentee, matches map[int]int) {
+	t.Helper()
+
+	held := make(map[int][]int)
+	for menteeID, mentorID := range matches {
+		held[mentorID] = append(held[mentorID], menteeID)
+	}
+
+	for _, mentor := range mentors {
+		if len(held[mentor.MentorID]) > mentor.Capacity {
+			t.Errorf("mentor %d holds %d mentees but has capacity %d", mentor.MentorID, len(held[mentor.MentorID]), mentor.Capacity)
+		}
+
+		rank := func(menteeID int) int {
+			for i, id := range mentor.Preferences {
+				if int(id) == menteeID {
+					return i
+				}
+			}
+			for i, mentee := range mentees {
+				if mentee.MenteeID == menteeID {
+					return len(mentor.Preferences) + i
+				}
+			}
+			return len(mentor.Preferences) + len(mentees)
+		}
+
+		for _, mentee := range mentees {
+			if sameTeam(mentor.Team, mentee.Team) || mentor.Capacity == 0 {
+				continue
+			}
+			current, matched := matches[mentee.MenteeID]
+			if current == mentor.MentorID {
+				continue
+			}
+
+			// Does the mentee rank this mentor above their assignment?
+			prefers := false
+			for _, id := range mentee.Preferences {
+				if int(id) == mentor.MentorID {
+					prefers = true
+					break
+				}
+				if matched && int(id) == current {
+					break
+				}
+			}
+			if !prefers {
+				continue
+			}
+
+			if len(held[mentor.MentorID]) < mentor.Capacity {
+				t.Errorf("mentee %d prefers mentor %d, who has a free place", mentee.MenteeID, mentor.MentorID)
+				continue
+			}
+			for _, other := range held[mentor.MentorID] {
+				if rank(mentee.MenteeID) < rank(other) {
+					t.Errorf("mentee %d and mentor %d prefer each other to mentee %d", mentee.MenteeID, mentor.MentorID, other)
+				}
+			}
+		}
+	}
+}
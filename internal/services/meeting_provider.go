@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"mentorApp/internal/models"
+)
+
+// MeetingProvider produces the video call a session's participants join.
+// Implementations are called whenever a session is scheduled or rescheduled.
+type MeetingProvider interface {
+	// Name identifies the provider in stored session data
+	Name() string
+	// CreateMeeting returns the join URL for the given session
+	CreateMeeting(ctx context.Context, session *models.MentorshipSession) (string, error)
+}
+
+// JitsiMeetingProvider generates unguessable Jitsi rooms without any API calls
+type JitsiMeetingProvider struct {
+	baseURL string
+}
+
+func NewJitsiMeetingProvider(baseURL string) *JitsiMeetingProvider {
+	if baseURL == "" {
+		baseURL = "https://meet.jit.si"
+	}
+	return &JitsiMeetingProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (p *JitsiMeetingProvider) Name() string {
+	return "jitsi"
+}
+
+// CreateMeeting builds a fresh room URL; a random suffix keeps rooms private
+// and means a reschedule always hands out a new link
+func (p *JitsiMeetingProvider) CreateMeeting(ctx context.Context, session *models.MentorshipSession) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate meeting room: %w", err)
+	}
+
+	return fmt.Sprintf("%s/nexus-mentorship-%d-%s", p.baseURL, session.RequestID, hex.EncodeToString(suffix)), nil
+}
@@ -4,16 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"mentorApp/internal/models"
 	"mentorApp/internal/repository"
+	"mentorApp/pkg/utils/ical"
 )
 
 type MentorshipService struct {
 	mentorshipRepo repository.IMentorshipRepository // Changed from *repository.MentorshipRepository
 	profileRepo    repository.IProfileRepository    // Use interface instead of concrete type
 	userRepo       repository.IUserRepository       // Use interface instead of concrete type
+	calendarRepo   repository.ICalendarRepository
+	agreementRepo  repository.IAgreementRepository
+	meetings       MeetingProvider
+	payments       IPaymentService
+	sponsors       ISponsorService
+	skills         ISkillService
+	notifier       INotificationService
 }
 
 // Updated constructor
@@ -21,11 +31,25 @@ func NewMentorshipService(
 	mentorshipRepo repository.IMentorshipRepository,
 	profileRepo repository.IProfileRepository,
 	userRepo repository.IUserRepository,
+	calendarRepo repository.ICalendarRepository,
+	agreementRepo repository.IAgreementRepository,
+	meetings MeetingProvider,
+	payments IPaymentService,
+	sponsors ISponsorService,
+	skills ISkillService,
+	notifier INotificationService,
 ) IMentorshipService {
 	return &MentorshipService{
 		mentorshipRepo: mentorshipRepo,
 		profileRepo:    profileRepo,
 		userRepo:       userRepo,
+		calendarRepo:   calendarRepo,
+		agreementRepo:  agreementRepo,
+		meetings:       meetings,
+		payments:       payments,
+		sponsors:       sponsors,
+		skills:         skills,
+		notifier:       notifier,
 	}
 }
 
@@ -40,14 +64,19 @@ func (s *MentorshipService) CreateMentorshipProgram(ctx context.Context, mentorI
 		return errors.New("unauthorized: user is not an approved mentor")
 	}
 
+	if err := validateProgram(program); err != nil {
+		return err
+	}
+
 	program.MentorID = mentorID // Fixed from MentorId
-	program.Status = "active"
+	program.Status = models.ProgramStatus.Active
 
 	return s.mentorshipRepo.CreateProgram(ctx, program)
 }
 
-// RequestMentorship handles a mentee's request for mentorship
-func (s *MentorshipService) RequestMentorship(ctx context.Context, menteeID, programID int, message string) error {
+// RequestMentorship handles a mentee's request for mentorship. A sponsorship code
+// lets a sponsor's credit budget pay for a paid program instead of the mentee.
+func (s *MentorshipService) RequestMentorship(ctx context.Context, menteeID, programID int, message, sponsorshipCode string) error {
 	// Verify mentee exists
 	mentee, err := s.userRepo.GetUserByID(ctx, menteeID)
 	if err != nil {
@@ -62,6 +91,9 @@ func (s *MentorshipService) RequestMentorship(ctx context.Context, menteeID, pro
 	if err != nil {
 		return err
 	}
+	if program.Status != models.ProgramStatus.Active {
+		return errors.New("program is not accepting new mentees")
+	}
 
 	// Create request
 	request := &models.MentorshipRequest{
@@ -72,10 +104,19 @@ func (s *MentorshipService) RequestMentorship(ctx context.Context, menteeID, pro
 		Message:   message,
 	}
 
+	// A sponsored request is stored together with its sponsorship
+	if strings.TrimSpace(sponsorshipCode) != "" {
+		if program.Price <= 0 {
+			return errors.New("sponsorship codes only apply to paid programs")
+		}
+		_, err := s.sponsors.RedeemCode(ctx, sponsorshipCode, request)
+		return err
+	}
+
 	return s.mentorshipRepo.CreateRequest(ctx, request)
 }
 
-// RespondToRequest handles a mentor's response to a mentorship request
+// RespondToRequest handles a mentor's response to a pending mentorship request
 func (s *MentorshipService) RespondToRequest(ctx context.Context, mentorID, requestID int, approve bool) error {
 	// Verify mentor owns the request
 	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
@@ -85,43 +126,348 @@ func (s *MentorshipService) RespondToRequest(ctx context.Context, mentorID, requ
 	if request.MentorID != mentorID { // Fixed from MentorId
 		return errors.New("unauthorized: request belongs to different mentor")
 	}
+	if request.Status != models.RequestStatus.Pending {
+		return fmt.Errorf("%w: a %s request has already been answered", repository.ErrInvalidStatus, request.Status)
+	}
+
+	if !approve {
+		if err := s.mentorshipRepo.UpdateRequestStatus(ctx, requestID, request.Status, models.RequestStatus.Rejected); err != nil {
+			return err
+		}
+		if err := s.sponsors.ReleaseCredits(ctx, requestID); err != nil {
+			log.Printf("Failed to release sponsor credits of rejected request %d: %v", requestID, err)
+		}
+		return nil
+	}
+
+	return s.ApproveRequest(ctx, request)
+}
+
+// ApproveRequest starts a pending mentorship if its program has room; the
+// repository checks the seat count and changes the status under the program's
+// lock. When an agreement applies, both participants are asked to accept it
+// and the mentorship only starts once they have; otherwise it is approved
+// straight away and the mentee invoiced for a paid program. Mentor responses
+// and published matching rounds both approve requests through here.
+func (s *MentorshipService) ApproveRequest(ctx context.Context, request *models.MentorshipRequest) error {
+	if request.Status != models.RequestStatus.Pending {
+		return fmt.Errorf("%w: a %s request cannot be approved", repository.ErrInvalidStatus, request.Status)
+	}
+
+	template, err := s.agreementRepo.GetEffectiveTemplate(ctx, request.ProgramID)
+	if errors.Is(err, repository.ErrAgreementNotFound) {
+		if err := s.mentorshipRepo.ApproveRequest(ctx, request.ID); err != nil {
+			return err
+		}
+		request.Status = models.RequestStatus.Approved
+		if _, err := s.payments.IssueInvoice(ctx, request); err != nil {
+			log.Printf("Failed to issue invoice for request %d: %v", request.ID, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.agreementRepo.RequireAgreement(ctx, request.ID, template.ID); err != nil {
+		return err
+	}
+	request.Status = models.RequestStatus.AwaitingAgreement
+
+	for _, participant := range []int{request.MentorID, request.MenteeID} {
+		if err := s.notifier.Notify(ctx, participant, models.NotificationType.AgreementRequired,
+			"Agreement required", "Please review and accept the mentorship agreement to start the mentorship."); err != nil {
+			log.Printf("Failed to notify user %d of agreement for request %d: %v", participant, request.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// CancelMentorship ends a mentorship that is awaiting its agreement or under way.
+// Its invoice is voided or refunded according to the refund policy.
+func (s *MentorshipService) CancelMentorship(ctx context.Context, userID, requestID int) (*models.MentorshipRequest, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	if request.Status != models.RequestStatus.Approved && request.Status != models.RequestStatus.AwaitingAgreement {
+		return nil, fmt.Errorf("%w: a %s mentorship cannot be cancelled", repository.ErrInvalidStatus, request.Status)
+	}
 
-	status := "rejected"
-	if approve {
-		status = "approved"
+	if err := s.mentorshipRepo.UpdateRequestStatus(ctx, requestID, request.Status, models.RequestStatus.Cancelled); err != nil {
+		return nil, err
 	}
+	request.Status = models.RequestStatus.Cancelled
 
-	return s.mentorshipRepo.UpdateRequestStatus(ctx, requestID, status)
+	if _, err := s.payments.SettleCancellation(ctx, request, userID); err != nil {
+		log.Printf("Failed to settle invoice of cancelled request %d: %v", requestID, err)
+	}
+	if err := s.sponsors.ReleaseCredits(ctx, requestID); err != nil {
+		log.Printf("Failed to release sponsor credits of cancelled request %d: %v", requestID, err)
+	}
+
+	other := request.MentorID
+	if userID == request.MentorID {
+		other = request.MenteeID
+	}
+	if err := s.notifier.Notify(ctx, other, models.NotificationType.MentorshipCancelled,
+		"Mentorship cancelled", "Your mentorship was cancelled by the other participant."); err != nil {
+		log.Printf("Failed to notify user %d of cancelled request %d: %v", other, requestID, err)
+	}
+
+	return request, nil
 }
 
-// ScheduleSession schedules a new mentorship session
+// ScheduleSession schedules a new session on an approved mentorship whose
+// program has been paid for
 func (s *MentorshipService) ScheduleSession(ctx context.Context, userID int, session *models.MentorshipSession) error {
 	// Get the request to verify permissions
 	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID) // Fixed from RequestId
 	if err != nil {
 		return err
 	}
+	if request == nil {
+		return errors.New("mentorship request not found")
+	}
 
 	// Verify user is part of this mentorship
 	if request.MentorID != userID && request.MenteeID != userID { // Fixed from MentorId/MenteeId
 		return errors.New("unauthorized: user not part of this mentorship")
 	}
+	if err := requireActiveMentorship(request); err != nil {
+		return err
+	}
+	if err := s.payments.RequirePaid(ctx, request); err != nil {
+		return err
+	}
 
-	// Validate session time
-	if session.StartTime.Before(time.Now()) {
+	if err := s.validateSessionTimes(ctx, request.MentorID, 0, session.StartTime, session.EndTime); err != nil {
+		return err
+	}
+
+	joinURL, err := s.meetings.CreateMeeting(ctx, session)
+	if err != nil {
+		return err
+	}
+	session.MeetingProvider = s.meetings.Name()
+	session.MeetingURL = joinURL
+
+	// Sponsored sessions reserve their credits as they are stored
+	session.Status = "scheduled"
+	if err := s.sponsors.CreateSession(ctx, session); err != nil {
+		return err
+	}
+
+	s.sendSessionInvites(ctx, request, session, models.NotificationType.SessionScheduled, "Mentorship session scheduled")
+	return nil
+}
+
+// RescheduleSession moves a scheduled session to new times. A new meeting is
+// generated so the old join link stops being valid, and updated invites go out.
+func (s *MentorshipService) RescheduleSession(ctx context.Context, userID, sessionID int, startTime, endTime time.Time) (*models.MentorshipSession, error) {
+	session, err := s.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.SessionStatus.Scheduled {
+		return nil, errors.New("only scheduled sessions can be rescheduled")
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireActiveMentorship(request); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSessionTimes(ctx, request.MentorID, session.ID, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	session.StartTime = startTime
+	session.EndTime = endTime
+
+	joinURL, err := s.meetings.CreateMeeting(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	session.MeetingProvider = s.meetings.Name()
+	session.MeetingURL = joinURL
+
+	if err := s.mentorshipRepo.UpdateSessionSchedule(ctx, session); err != nil {
+		return nil, err
+	}
+
+	s.sendSessionInvites(ctx, request, session, models.NotificationType.SessionRescheduled, "Mentorship session rescheduled")
+	return session, nil
+}
+
+// CompleteSession lets the mentor mark a session that has started as completed,
+// which opens it for feedback
+func (s *MentorshipService) CompleteSession(ctx context.Context, mentorID, sessionID int) (*models.MentorshipSession, error) {
+	session, err := s.GetSession(ctx, mentorID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.MentorID != mentorID {
+		return nil, errors.New("unauthorized: only the mentor can complete a session")
+	}
+	if err := s.payments.RequirePaid(ctx, request); err != nil {
+		return nil, err
+	}
+	if session.Status != models.SessionStatus.Scheduled {
+		return nil, errors.New("only scheduled sessions can be completed")
+	}
+	if time.Now().Before(session.StartTime) {
+		return nil, errors.New("session has not started yet")
+	}
+
+	if err := s.mentorshipRepo.UpdateSessionStatus(ctx, sessionID, models.SessionStatus.Completed); err != nil {
+		return nil, err
+	}
+
+	session.Status = models.SessionStatus.Completed
+
+	if err := s.sponsors.RecordSessionUsage(ctx, request, session); err != nil {
+		log.Printf("Failed to record sponsor credits for session %d: %v", sessionID, err)
+	}
+
+	return session, nil
+}
+
+// GetSession returns a session if the user is one of its participants
+func (s *MentorshipService) GetSession(ctx context.Context, userID, sessionID int) (*models.MentorshipSession, error) {
+	session, err := s.mentorshipRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errors.New("session not found")
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || (request.MentorID != userID && request.MenteeID != userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+
+	return session, nil
+}
+
+// requireActiveMentorship checks that sessions may be booked on a mentorship:
+// it must be approved, which also means any agreement has been accepted
+func requireActiveMentorship(request *models.MentorshipRequest) error {
+	if request.Status != models.RequestStatus.Approved {
+		return fmt.Errorf("%w: sessions cannot be scheduled on a %s mentorship", repository.ErrInvalidStatus, request.Status)
+	}
+	return nil
+}
+
+// validateSessionTimes checks session bounds against the mentor's synced calendar
+// and their other scheduled sessions; sessionID is the session being moved, if any
+func (s *MentorshipService) validateSessionTimes(ctx context.Context, mentorID, sessionID int, startTime, endTime time.Time) error {
+	if startTime.Before(time.Now()) {
 		return errors.New("cannot schedule session in the past")
 	}
-	if session.EndTime.Before(session.StartTime) {
+	if !endTime.After(startTime) {
 		return errors.New("end time must be after start time")
 	}
 
 	maxSessionDuration := 4 * time.Hour
-	if session.EndTime.Sub(session.StartTime) > maxSessionDuration {
+	if endTime.Sub(startTime) > maxSessionDuration {
 		return errors.New("session duration exceeds program limits")
 	}
 
-	session.Status = "scheduled"
-	return s.mentorshipRepo.CreateSession(ctx, session)
+	// Reject times the mentor's calendar or another of their sessions already takes
+	busy, err := s.mentorBusy(ctx, mentorID, sessionID, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	if len(busy) > 0 {
+		return errors.New("mentor is busy during the requested time")
+	}
+
+	return nil
+}
+
+// mentorBusy returns the periods between from and to that the mentor's
+// external calendar marks as busy or that their scheduled sessions take up.
+// The session being rescheduled, if any, is left out.
+func (s *MentorshipService) mentorBusy(ctx context.Context, mentorID, excludeSessionID int, from, to time.Time) ([]*models.BusyBlock, error) {
+	busy, err := s.calendarRepo.ListBusyBlocks(ctx, mentorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.mentorshipRepo.ListScheduledSessionsBetween(ctx, mentorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.ID == excludeSessionID {
+			continue
+		}
+		busy = append(busy, &models.BusyBlock{
+			MentorID:  mentorID,
+			StartTime: session.StartTime,
+			EndTime:   session.EndTime,
+		})
+	}
+
+	return busy, nil
+}
+
+// sendSessionInvites emails both participants a calendar invite carrying the join link.
+// Delivery problems are logged rather than failing the scheduling operation.
+func (s *MentorshipService) sendSessionInvites(ctx context.Context, request *models.MentorshipRequest, session *models.MentorshipSession, notificationType, title string) {
+	var attendees []string
+	for _, id := range []int{request.MentorID, request.MenteeID} {
+		user, err := s.userRepo.GetUserByID(ctx, id)
+		if err != nil || user == nil {
+			log.Printf("Failed to look up participant %d for session %d invite", id, session.ID)
+			continue
+		}
+		attendees = append(attendees, user.Email)
+	}
+
+	summary := session.Title
+	if summary == "" {
+		summary = "Mentorship session"
+	}
+
+	invite := ical.Invite{
+		UID:         fmt.Sprintf("session-%d@nexusmentors.org", session.ID),
+		Sequence:    session.Sequence,
+		Start:       session.StartTime,
+		End:         session.EndTime,
+		Summary:     summary,
+		Description: session.Topic,
+		Location:    session.MeetingURL,
+		URL:         session.MeetingURL,
+		Organizer:   "noreply@nexusmentors.org",
+		Attendees:   attendees,
+	}
+
+	message := fmt.Sprintf("%q is scheduled for %s. Join here: %s",
+		summary, session.StartTime.UTC().Format("Mon Jan 2, 15:04 MST"), session.MeetingURL)
+
+	for _, id := range []int{request.MentorID, request.MenteeID} {
+		if err := s.notifier.SendCalendarInvite(ctx, id, notificationType, title, message, invite.String()); err != nil {
+			log.Printf("Failed to send session %d invite to user %d: %v", session.ID, id, err)
+		}
+	}
 }
 
 // ListMentorPrograms returns all programs created by a mentor
@@ -134,22 +480,6 @@ func (s *MentorshipService) ListMenteeRequests(ctx context.Context, menteeID int
 	return s.mentorshipRepo.ListMenteeRequests(ctx, menteeID)
 }
 
-// Add these methods to your MentorshipService struct
-
-// GetFeaturedMentors returns a list of featured mentors
-func (s *MentorshipService) GetFeaturedMentors(ctx context.Context) ([]*models.Profile, error) {
-	// TODO: Implement actual featured mentors logic
-	// For now, return empty slice
-	return []*models.Profile{}, nil
-}
-
-// GetAvailableJobs returns a list of available jobs
-func (s *MentorshipService) GetAvailableJobs(ctx context.Context) ([]*models.Job, error) {
-	// TODO: Implement actual job listing logic
-	// For now, return empty slice
-	return []*models.Job{}, nil
-}
-
 // GetActiveMentorships returns active mentorships for a user
 func (s *MentorshipService) GetActiveMentorships(ctx context.Context, userID int) ([]*models.MentorshipRequest, error) {
 	// TODO: Implement actual active mentorships logic
@@ -164,13 +494,6 @@ func (s *MentorshipService) GetUpcomingSessions(ctx context.Context, userID int)
 	return []*models.MentorshipSession{}, nil
 }
 
-// GetRecommendedMentors returns recommended mentors for a mentee
-func (s *MentorshipService) GetRecommendedMentors(ctx context.Context, userID int) ([]*models.Profile, error) {
-	// TODO: Implement actual mentor recommendations logic
-	// For now, return empty slice
-	return []*models.Profile{}, nil
-}
-
 // GetMentorPrograms returns all programs for a mentor
 func (s *MentorshipService) GetMentorPrograms(ctx context.Context, mentorID int) ([]*models.MentorshipProgram, error) {
 	// Query programs for this mentor
@@ -199,73 +522,271 @@ func (s *MentorshipService) GetPendingRequests(ctx context.Context, mentorID int
 
 // GetMentorAnalytics returns analytics for a mentor
 func (s *MentorshipService) GetMentorAnalytics(ctx context.Context, mentorID int) (map[string]interface{}, error) {
-	// TODO: Implement actual analytics logic
-	// For now, return empty map
+	rating, err := s.profileRepo.GetRatingSummary(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.mentorshipRepo.GetMentorActivity(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
-		"total_sessions": 0,
-		"total_mentees":  0,
-		"average_rating": 0.0,
+		"total_sessions": activity.CompletedSessions,
+		"total_mentees":  activity.Mentees,
+		"average_rating": rating.AverageRating,
+		"rating_count":   rating.RatingCount,
 	}, nil
 }
 
-// GetAvailableSpecialties returns list of available specialties
+// GetAvailableSpecialties returns the names of the specialties in the skills taxonomy
 func (s *MentorshipService) GetAvailableSpecialties(ctx context.Context) []string {
-	// TODO: Implement actual specialties logic
-	// For now, return some default specialties
-	return []string{
-		"Backend Development",
-		"Frontend Development",
-		"DevOps",
-		"Cloud Architecture",
-		"Cloud Native Technologies",
-		"Mobile Development",
-		"Data Science",
-		"AI",
-		"NLP",
-		"ML",
+	specialties, err := s.skills.ListSpecialtyNames(ctx)
+	if err != nil {
+		log.Printf("Failed to list specialties: %v", err)
+		return []string{}
 	}
+	return specialties
 }
 
 // ListAvailablePrograms returns all active mentorship programs
 func (s *MentorshipService) ListAvailablePrograms(ctx context.Context) ([]*models.MentorshipProgram, error) {
-	// Implement by filtering for active programs only
-	programs, err := s.mentorshipRepo.ListMentorPrograms(ctx, 0) // 0 means all mentors
+	return s.mentorshipRepo.ListActivePrograms(ctx)
+}
+
+// GetProgramDetails returns detailed information about a specific program.
+// The program's mentor also sees its enrollment; archived programs are hidden from everyone else.
+func (s *MentorshipService) GetProgramDetails(ctx context.Context, userID, programID int) (*models.MentorshipProgram, error) {
+	program, err := s.mentorshipRepo.GetProgram(ctx, programID)
 	if err != nil {
 		return nil, err
 	}
 
-	var activePrograms []*models.MentorshipProgram
-	for _, program := range programs {
-		if program.Status == "active" {
-			activePrograms = append(activePrograms, program)
+	if program.MentorID != userID {
+		if program.Status == models.ProgramStatus.Archived {
+			return nil, repository.ErrProgramNotFound
 		}
+		return program, nil
 	}
-	return activePrograms, nil
+
+	program.Enrollment, err = s.mentorshipRepo.GetProgramEnrollment(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	return program, nil
+}
+
+// UpdateProgram edits a program's details. Once mentees have requested or joined a
+// program its price and duration are locked, and capacity cannot drop below the
+// number of active mentorships.
+func (s *MentorshipService) UpdateProgram(ctx context.Context, mentorID, programID int, update *models.MentorshipProgram) (*models.MentorshipProgram, error) {
+	program, err := s.mentorProgram(ctx, mentorID, programID)
+	if err != nil {
+		return nil, err
+	}
+	if program.Status == models.ProgramStatus.Archived {
+		return nil, errors.New("archived programs must be reactivated before editing")
+	}
+	if err := validateProgram(update); err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.mentorshipRepo.GetProgramEnrollment(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.Pending+enrollment.Active > 0 {
+		if update.Price != program.Price {
+			return nil, errors.New("price cannot change while mentees are enrolled or waiting for a response")
+		}
+		if update.Duration != program.Duration {
+			return nil, errors.New("duration cannot change while mentees are enrolled or waiting for a response")
+		}
+	}
+	if update.MaxMentees < enrollment.Active {
+		return nil, fmt.Errorf("max mentees cannot be lower than the %d active mentorships", enrollment.Active)
+	}
+
+	program.Title = update.Title
+	program.Description = update.Description
+	program.Duration = update.Duration
+	program.Price = update.Price
+	program.MaxMentees = update.MaxMentees
+
+	if err := s.mentorshipRepo.UpdateProgram(ctx, program); err != nil {
+		return nil, err
+	}
+
+	program.Enrollment = enrollment
+	return program, nil
 }
 
-// GetProgramDetails returns detailed information about a specific program
-func (s *MentorshipService) GetProgramDetails(ctx context.Context, programID int) (*models.MentorshipProgram, error) {
-	return s.mentorshipRepo.GetProgram(ctx, programID)
+// programTransitions lists the statuses a program may move to from each status
+var programTransitions = map[string][]string{
+	models.ProgramStatus.Active:   {models.ProgramStatus.Paused, models.ProgramStatus.Archived},
+	models.ProgramStatus.Paused:   {models.ProgramStatus.Active, models.ProgramStatus.Archived},
+	models.ProgramStatus.Archived: {models.ProgramStatus.Active},
 }
 
-// SearchMentors searches for mentors based on specified filters
-func (s *MentorshipService) SearchMentors(ctx context.Context, filters map[string]interface{}) ([]*models.PublicProfile, error) {
-	profiles, err := s.profileRepo.SearchProfiles(ctx, filters)
+// SetProgramStatus pauses, archives or reactivates a program. Paused programs stop
+// taking requests but keep running; archiving requires every mentorship to be settled.
+func (s *MentorshipService) SetProgramStatus(ctx context.Context, mentorID, programID int, status string) (*models.MentorshipProgram, error) {
+	program, err := s.mentorProgram(ctx, mentorID, programID)
 	if err != nil {
 		return nil, err
 	}
 
-	var results []*models.PublicProfile
-	for _, profile := range profiles {
-		results = append(results, &models.PublicProfile{
-			FirstName: profile.FirstName,
-			LastName:  profile.LastName,
-			Bio:       profile.Bio,
-			Skills:    profile.Skills,
-			Rate:      profile.Rate,
-		})
+	allowed := false
+	for _, next := range programTransitions[program.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: program cannot move from %s to %s", repository.ErrInvalidStatus, program.Status, status)
+	}
+
+	enrollment, err := s.mentorshipRepo.GetProgramEnrollment(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	if status == models.ProgramStatus.Archived && enrollment.Pending+enrollment.Active > 0 {
+		return nil, errors.New("program still has pending or active mentorships; pause it instead")
+	}
+
+	if err := s.mentorshipRepo.UpdateProgramStatus(ctx, programID, status); err != nil {
+		return nil, err
 	}
-	return results, nil
+
+	program.Status = status
+	program.Enrollment = enrollment
+	return program, nil
+}
+
+// DuplicateProgram copies a program's details into a new paused program the mentor
+// can adjust before opening it to mentees
+func (s *MentorshipService) DuplicateProgram(ctx context.Context, mentorID, programID int) (*models.MentorshipProgram, error) {
+	source, err := s.mentorProgram(ctx, mentorID, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := source.Title + " (copy)"
+	if len(title) > maxProgramTitleLength {
+		title = source.Title[:maxProgramTitleLength-len(" (copy)")] + " (copy)"
+	}
+
+	program := &models.MentorshipProgram{
+		MentorID:    mentorID,
+		Title:       title,
+		Description: source.Description,
+		Duration:    source.Duration,
+		Price:       source.Price,
+		MaxMentees:  source.MaxMentees,
+		Status:      models.ProgramStatus.Paused,
+	}
+	if err := s.mentorshipRepo.CreateProgram(ctx, program); err != nil {
+		return nil, err
+	}
+
+	return program, nil
+}
+
+// DeleteProgram removes a program nobody has requested yet; others can only be archived
+func (s *MentorshipService) DeleteProgram(ctx context.Context, mentorID, programID int) error {
+	if _, err := s.mentorProgram(ctx, mentorID, programID); err != nil {
+		return err
+	}
+	return s.mentorshipRepo.DeleteProgram(ctx, programID)
+}
+
+// mentorProgram loads a program owned by the mentor
+func (s *MentorshipService) mentorProgram(ctx context.Context, mentorID, programID int) (*models.MentorshipProgram, error) {
+	program, err := s.mentorshipRepo.GetProgram(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	if program.MentorID != mentorID {
+		return nil, errors.New("unauthorized: program belongs to another mentor")
+	}
+	return program, nil
+}
+
+// maxProgramTitleLength matches the mentorship_programs.title column
+const maxProgramTitleLength = 128
+
+func validateProgram(program *models.MentorshipProgram) error {
+	program.Title = strings.TrimSpace(program.Title)
+	program.Description = strings.TrimSpace(program.Description)
+	program.Duration = strings.TrimSpace(program.Duration)
+
+	switch {
+	case program.Title == "":
+		return errors.New("program title is required")
+	case len(program.Title) > maxProgramTitleLength:
+		return fmt.Errorf("program title cannot exceed %d characters", maxProgramTitleLength)
+	case program.Description == "":
+		return errors.New("program description is required")
+	case program.Duration == "":
+		return errors.New("program duration is required")
+	case program.Price < 0:
+		return errors.New("program price cannot be negative")
+	case program.MaxMentees < 1:
+		return errors.New("program needs room for at least one mentee")
+	}
+	return nil
+}
+
+// Mentor search page sizes
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// SearchMentors runs a full-text search over approved mentors. Without a text
+// query results are ordered by rating unless another sort is asked for.
+func (s *MentorshipService) SearchMentors(ctx context.Context, query *models.MentorSearchQuery) (*models.MentorSearchResult, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	query.Timezone = strings.TrimSpace(query.Timezone)
+
+	skills := query.Skills[:0]
+	for _, skill := range query.Skills {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			skills = append(skills, skill)
+		}
+	}
+	query.Skills = skills
+
+	if query.RateMin != nil && query.RateMax != nil && *query.RateMin > *query.RateMax {
+		return nil, errors.New("minimum rate cannot exceed maximum rate")
+	}
+
+	switch query.Sort {
+	case "":
+		query.Sort = models.MentorSearchSort.Relevance
+		if query.Text == "" {
+			query.Sort = models.MentorSearchSort.Rating
+		}
+	case models.MentorSearchSort.Relevance, models.MentorSearchSort.Rating, models.MentorSearchSort.PriceAsc,
+		models.MentorSearchSort.PriceDesc, models.MentorSearchSort.Newest:
+	default:
+		return nil, fmt.Errorf("unknown sort %q", query.Sort)
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultSearchPageSize
+	}
+	if query.PageSize > maxSearchPageSize {
+		query.PageSize = maxSearchPageSize
+	}
+
+	return s.profileRepo.SearchMentors(ctx, query)
 }
 
 // UpdateAvailability updates a mentor's availability schedule
@@ -289,17 +810,82 @@ func (s *MentorshipService) UpdateAvailability(ctx context.Context, mentorID int
 	return nil
 }
 
-// SubmitSessionFeedback submits feedback for a mentorship session
-func (s *MentorshipService) SubmitSessionFeedback(ctx context.Context, feedback *models.SessionFeedback) error {
-	// Implement feedback submission logic
-	return s.mentorshipRepo.CreateSessionFeedback(ctx, feedback)
-}
-
 // GetAvailability retrieves a mentor's availability schedule
 func (s *MentorshipService) GetAvailability(ctx context.Context, mentorID int) ([]models.Availability, error) {
 	return s.profileRepo.GetProfileAvailability(ctx, mentorID)
 }
 
+// GetBookableSlots splits a mentor's weekly availability into slots of the
+// given length between from and to, dropping any that overlap busy blocks
+// imported from the mentor's calendar or sessions already scheduled
+func (s *MentorshipService) GetBookableSlots(ctx context.Context, mentorID int, from, to time.Time, length time.Duration) ([]models.BookableSlot, error) {
+	if length <= 0 {
+		return nil, errors.New("slot length must be positive")
+	}
+	if !to.After(from) {
+		return nil, errors.New("end of range must be after start")
+	}
+
+	availability, err := s.GetAvailability(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+
+	busy, err := s.mentorBusy(ctx, mentorID, 0, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Weekly availability is stored as wall-clock times in the mentor's timezone
+	loc := time.UTC
+	profile, err := s.profileRepo.GetProfileByUserID(ctx, mentorID)
+	if err == nil && profile != nil && profile.Timezone != "" {
+		if tz, err := time.LoadLocation(profile.Timezone); err == nil {
+			loc = tz
+		}
+	}
+
+	return bookableSlots(availability, busy, loc, from, to, length), nil
+}
+
+func bookableSlots(availability []models.Availability, busy []*models.BusyBlock, loc *time.Location, from, to time.Time, length time.Duration) []models.BookableSlot {
+	slots := []models.BookableSlot{}
+
+	first := from.In(loc)
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, window := range availability {
+			if int(day.Weekday()) != window.DayOfWeek {
+				continue
+			}
+
+			windowStart := time.Date(day.Year(), day.Month(), day.Day(),
+				window.StartTime.Hour(), window.StartTime.Minute(), 0, 0, loc)
+			windowEnd := time.Date(day.Year(), day.Month(), day.Day(),
+				window.EndTime.Hour(), window.EndTime.Minute(), 0, 0, loc)
+
+			for start := windowStart; !start.Add(length).After(windowEnd); start = start.Add(length) {
+				end := start.Add(length)
+				if start.Before(from) || end.After(to) {
+					continue
+				}
+
+				free := true
+				for _, block := range busy {
+					if block.Overlaps(start, end) {
+						free = false
+						break
+					}
+				}
+				if free {
+					slots = append(slots, models.BookableSlot{StartTime: start, EndTime: end})
+				}
+			}
+		}
+	}
+
+	return slots
+}
+
 // GetMentorshipStats returns statistics about a mentor's programs and sessions
 func (s *MentorshipService) GetMentorshipStats(ctx context.Context, mentorID int) (map[string]interface{}, error) {
 	// This could be optimized with specific queries rather than loading all data
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+	"mentorApp/pkg/utils/email"
+)
+
+type NotificationService struct {
+	notificationRepo repository.INotificationRepository
+	userRepo         repository.IUserRepository
+	emailSvc         *email.EmailService
+}
+
+func NewNotificationService(
+	notificationRepo repository.INotificationRepository,
+	userRepo repository.IUserRepository,
+	emailSvc *email.EmailService,
+) INotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		emailSvc:         emailSvc,
+	}
+}
+
+// Notify stores an in-app notification and emails it to the user
+func (s *NotificationService) Notify(ctx context.Context, userID int, notificationType, title, message string) error {
+	user, err := s.store(ctx, userID, notificationType, title, message)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emailSvc.SendNotificationEmail(user.Email, title, message); err != nil {
+		// The in-app notification is already stored, so a mail failure is not fatal
+		log.Printf("Failed to email notification to user %d: %v", userID, err)
+	}
+	return nil
+}
+
+// SendCalendarInvite stores an in-app notification and emails it with an
+// attached iCalendar invite
+func (s *NotificationService) SendCalendarInvite(ctx context.Context, userID int, notificationType, title, message, invite string) error {
+	user, err := s.store(ctx, userID, notificationType, title, message)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emailSvc.SendCalendarInvite(user.Email, title, message, invite); err != nil {
+		log.Printf("Failed to email calendar invite to user %d: %v", userID, err)
+	}
+	return nil
+}
+
+// ListNotifications returns a user's notifications, newest first
+func (s *NotificationService) ListNotifications(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error) {
+	return s.notificationRepo.ListNotifications(ctx, userID, unreadOnly)
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID int) error {
+	return s.notificationRepo.MarkNotificationRead(ctx, userID, notificationID)
+}
+
+func (s *NotificationService) store(ctx context.Context, userID int, notificationType, title, message string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+	}
+	if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
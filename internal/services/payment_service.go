@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type PaymentService struct {
+	paymentRepo    repository.IPaymentRepository
+	mentorshipRepo repository.IMentorshipRepository
+	sponsorRepo    repository.ISponsorRepository
+	provider       PaymentProvider
+	notifier       INotificationService
+	currency       string
+	feePercent     int64
+}
+
+// NewPaymentService bills paid programs in currency and keeps feePercent of
+// every payment as the platform fee
+func NewPaymentService(
+	paymentRepo repository.IPaymentRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	sponsorRepo repository.ISponsorRepository,
+	provider PaymentProvider,
+	notifier INotificationService,
+	currency string,
+	feePercent int,
+) IPaymentService {
+	return &PaymentService{
+		paymentRepo:    paymentRepo,
+		mentorshipRepo: mentorshipRepo,
+		sponsorRepo:    sponsorRepo,
+		provider:       provider,
+		notifier:       notifier,
+		currency:       strings.ToUpper(currency),
+		feePercent:     int64(feePercent),
+	}
+}
+
+// IssueInvoice bills the mentee of an approved mentorship for its program. Free
+// programs and sponsored mentorships return a nil invoice; an existing invoice
+// is returned unchanged.
+func (s *PaymentService) IssueInvoice(ctx context.Context, request *models.MentorshipRequest) (*models.Invoice, error) {
+	program, err := s.mentorshipRepo.GetProgram(ctx, request.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	if program.Price <= 0 {
+		return nil, nil
+	}
+
+	// Sponsors pay through their credit budget rather than an invoice
+	_, err = s.sponsorRepo.GetSponsorship(ctx, request.ID)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, repository.ErrSponsorshipNotFound) {
+		return nil, err
+	}
+
+	amount := int64(math.Round(program.Price * 100))
+	invoice := &models.Invoice{
+		RequestID:        request.ID,
+		MenteeID:         request.MenteeID,
+		MentorID:         request.MentorID,
+		ProgramID:        program.ID,
+		Description:      fmt.Sprintf("Mentorship program: %s (%s)", program.Title, program.Duration),
+		Currency:         s.currency,
+		AmountCents:      amount,
+		PlatformFeeCents: amount * s.feePercent / 100,
+		Status:           models.InvoiceStatus.Open,
+	}
+
+	err = s.paymentRepo.CreateInvoice(ctx, invoice)
+	if errors.Is(err, repository.ErrInvoiceExists) {
+		return s.paymentRepo.GetInvoiceByRequest(ctx, request.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Invoice %s for %s is ready. Complete checkout to start your mentorship.",
+		invoice.Number, models.FormatCents(invoice.AmountCents, invoice.Currency))
+	if err := s.notifier.Notify(ctx, request.MenteeID, models.NotificationType.InvoiceIssued, "Invoice issued", message); err != nil {
+		log.Printf("Failed to notify mentee %d of invoice %d: %v", request.MenteeID, invoice.ID, err)
+	}
+
+	return s.paymentRepo.GetInvoice(ctx, invoice.ID)
+}
+
+// RequirePaid checks that a mentorship has been paid for. Free and sponsored
+// mentorships always have been; others need a paid invoice. A mentorship whose
+// invoice failed to be issued gets one now, so the mentee can check out.
+func (s *PaymentService) RequirePaid(ctx context.Context, request *models.MentorshipRequest) error {
+	invoice, err := s.IssueInvoice(ctx, request)
+	if err != nil {
+		return err
+	}
+	if invoice == nil {
+		return nil
+	}
+
+	switch invoice.Status {
+	case models.InvoiceStatus.Paid, models.InvoiceStatus.PartiallyRefunded:
+		return nil
+	}
+	return fmt.Errorf("%w: invoice %s is %s", repository.ErrInvoiceUnpaid, invoice.Number, invoice.Status)
+}
+
+// Checkout charges the mentee for their approved mentorship's open invoice
+func (s *PaymentService) Checkout(ctx context.Context, menteeID, requestID int, paymentToken string) (*models.Invoice, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || request.MenteeID != menteeID {
+		return nil, errors.New("unauthorized: only the mentee can pay for this mentorship")
+	}
+	if request.Status != models.RequestStatus.Approved {
+		return nil, errors.New("payment is due once the mentorship is approved")
+	}
+
+	invoice, err := s.IssueInvoice(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, errors.New("there is nothing to pay for this mentorship")
+	}
+	if invoice.Status != models.InvoiceStatus.Open {
+		return nil, repository.ErrInvoiceNotOpen
+	}
+
+	payment := &models.Payment{
+		InvoiceID:   invoice.ID,
+		Kind:        models.PaymentKind.Charge,
+		Provider:    s.provider.Name(),
+		AmountCents: invoice.AmountCents,
+	}
+
+	payment.ProviderRef, err = s.provider.Charge(ctx, &Charge{
+		Reference:   invoice.Number,
+		Description: invoice.Description,
+		Currency:    invoice.Currency,
+		AmountCents: invoice.AmountCents,
+		Token:       paymentToken,
+	})
+	if err != nil {
+		payment.Status = models.PaymentStatus.Failed
+		payment.FailureReason = err.Error()
+		if recordErr := s.paymentRepo.RecordFailedPayment(ctx, payment); recordErr != nil {
+			log.Printf("Failed to record declined payment for invoice %d: %v", invoice.ID, recordErr)
+		}
+		return nil, err
+	}
+
+	payment.Status = models.PaymentStatus.Succeeded
+	err = s.paymentRepo.RecordCharge(ctx, payment, chargeLedger(invoice))
+	if err != nil {
+		// The money moved but the invoice could not take it; hand it back
+		if _, refundErr := s.provider.Refund(ctx, payment.ProviderRef, payment.AmountCents); refundErr != nil {
+			log.Printf("Failed to reverse unrecorded charge %s for invoice %d: %v", payment.ProviderRef, invoice.ID, refundErr)
+		}
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%s paid invoice %s for %s.", invoice.MenteeName, invoice.Number,
+		models.FormatCents(invoice.AmountCents, invoice.Currency))
+	if err := s.notifier.Notify(ctx, invoice.MentorID, models.NotificationType.PaymentReceived, "Payment received", message); err != nil {
+		log.Printf("Failed to notify mentor %d of payment for invoice %d: %v", invoice.MentorID, invoice.ID, err)
+	}
+
+	return s.paymentRepo.GetInvoice(ctx, invoice.ID)
+}
+
+// SettleCancellation voids the unpaid invoice of a cancelled mentorship or refunds
+// a paid one. Mentees get a full refund when the mentor cancels or no session has
+// been completed yet; after that, refunds are left to admins.
+func (s *PaymentService) SettleCancellation(ctx context.Context, request *models.MentorshipRequest, cancelledBy int) (*models.Invoice, error) {
+	invoice, err := s.paymentRepo.GetInvoiceByRequest(ctx, request.ID)
+	if errors.Is(err, repository.ErrInvoiceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.Status == models.InvoiceStatus.Open {
+		if err := s.paymentRepo.VoidInvoice(ctx, invoice.ID); err != nil {
+			return nil, err
+		}
+		return s.paymentRepo.GetInvoice(ctx, invoice.ID)
+	}
+	if invoice.RefundableCents() == 0 {
+		return invoice, nil
+	}
+
+	if cancelledBy != request.MentorID {
+		sessions, err := s.mentorshipRepo.ListSessionsByRequest(ctx, request.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range sessions {
+			if session.Status == models.SessionStatus.Completed {
+				return invoice, nil
+			}
+		}
+	}
+
+	return s.refund(ctx, invoice, invoice.RefundableCents(), "mentorship cancelled")
+}
+
+// RefundInvoice returns money to the mentee. An amount of zero refunds everything still refundable.
+func (s *PaymentService) RefundInvoice(ctx context.Context, invoiceID int, amountCents int64, reason string) (*models.Invoice, error) {
+	invoice, err := s.paymentRepo.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if amountCents == 0 {
+		amountCents = invoice.RefundableCents()
+	}
+	if amountCents <= 0 {
+		return nil, repository.ErrRefundExceedsBalance
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = "refund issued by admin"
+	}
+
+	return s.refund(ctx, invoice, amountCents, reason)
+}
+
+// GetInvoice returns an invoice to the mentee it bills or the mentor it pays
+func (s *PaymentService) GetInvoice(ctx context.Context, userID, invoiceID int) (*models.Invoice, error) {
+	invoice, err := s.paymentRepo.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.MenteeID != userID && invoice.MentorID != userID {
+		return nil, errors.New("unauthorized: invoice belongs to another mentorship")
+	}
+	return invoice, nil
+}
+
+// GetMentorshipInvoice returns the invoice of a mentorship to either participant
+func (s *PaymentService) GetMentorshipInvoice(ctx context.Context, userID, requestID int) (*models.Invoice, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	return s.paymentRepo.GetInvoiceByRequest(ctx, requestID)
+}
+
+// ListInvoices returns the invoices a user pays or is paid through
+func (s *PaymentService) ListInvoices(ctx context.Context, userID int) ([]*models.Invoice, error) {
+	return s.paymentRepo.ListInvoices(ctx, userID)
+}
+
+// GetInvoiceLedger returns every ledger posting made for an invoice
+func (s *PaymentService) GetInvoiceLedger(ctx context.Context, invoiceID int) ([]*models.LedgerTransaction, error) {
+	if _, err := s.paymentRepo.GetInvoice(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+	return s.paymentRepo.ListLedgerTransactions(ctx, invoiceID)
+}
+
+// GetPayoutSummary totals what the mentor has earned, per program and overall
+func (s *PaymentService) GetPayoutSummary(ctx context.Context, mentorID int) (*models.PayoutSummary, error) {
+	programs, err := s.paymentRepo.ListProgramPayouts(ctx, mentorID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.PayoutSummary{
+		MentorID: mentorID,
+		Currency: s.currency,
+		Programs: programs,
+	}
+	for _, program := range programs {
+		summary.GrossCents += program.GrossCents
+		summary.FeeCents += program.FeeCents
+		summary.RefundedCents += program.RefundedCents
+		summary.BalanceCents += program.NetCents
+		summary.PaidInvoices += program.PaidInvoices
+	}
+
+	return summary, nil
+}
+
+// refund reserves the amount on the invoice, returns it through the provider and
+// reverses the matching share of the mentor payable and platform fee
+func (s *PaymentService) refund(ctx context.Context, invoice *models.Invoice, amountCents int64, reason string) (*models.Invoice, error) {
+	var chargeRef string
+	for _, payment := range invoice.Payments {
+		if payment.Kind == models.PaymentKind.Charge && payment.Status == models.PaymentStatus.Succeeded {
+			chargeRef = payment.ProviderRef
+		}
+	}
+	if chargeRef == "" {
+		return nil, errors.New("invoice has no successful charge to refund")
+	}
+
+	previous, err := s.paymentRepo.ReserveRefund(ctx, invoice.ID, amountCents)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &models.Payment{
+		InvoiceID:   invoice.ID,
+		Kind:        models.PaymentKind.Refund,
+		Provider:    s.provider.Name(),
+		AmountCents: amountCents,
+	}
+
+	payment.ProviderRef, err = s.provider.Refund(ctx, chargeRef, amountCents)
+	if err != nil {
+		if releaseErr := s.paymentRepo.ReleaseRefund(ctx, invoice.ID, amountCents); releaseErr != nil {
+			log.Printf("Failed to release refund reservation on invoice %d: %v", invoice.ID, releaseErr)
+		}
+		payment.Status = models.PaymentStatus.Failed
+		payment.FailureReason = err.Error()
+		if recordErr := s.paymentRepo.RecordFailedPayment(ctx, payment); recordErr != nil {
+			log.Printf("Failed to record declined refund for invoice %d: %v", invoice.ID, recordErr)
+		}
+		return nil, err
+	}
+
+	payment.Status = models.PaymentStatus.Succeeded
+	if err := s.paymentRepo.RecordRefund(ctx, payment, refundLedger(invoice, previous, amountCents, reason)); err != nil {
+		// The provider already refunded; the reservation keeps the invoice correct
+		log.Printf("Failed to record refund %s for invoice %d: %v", payment.ProviderRef, invoice.ID, err)
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%s of invoice %s was refunded.", models.FormatCents(amountCents, invoice.Currency), invoice.Number)
+	if err := s.notifier.Notify(ctx, invoice.MenteeID, models.NotificationType.PaymentRefunded, "Payment refunded", message); err != nil {
+		log.Printf("Failed to notify mentee %d of refund on invoice %d: %v", invoice.MenteeID, invoice.ID, err)
+	}
+
+	return s.paymentRepo.GetInvoice(ctx, invoice.ID)
+}
+
+// chargeLedger debits cash for the full amount and credits the platform fee and
+// the mentor's share
+func chargeLedger(invoice *models.Invoice) *models.LedgerTransaction {
+	invoiceID := invoice.ID
+	return &models.LedgerTransaction{
+		InvoiceID:   &invoiceID,
+		Kind:        models.PaymentKind.Charge,
+		Description: "Payment of invoice " + invoice.Number,
+		Entries: []*models.LedgerEntry{
+			{Account: models.LedgerAccount.Cash, AmountCents: invoice.AmountCents},
+			{Account: models.LedgerAccount.PlatformFees, AmountCents: -invoice.PlatformFeeCents},
+			{Account: models.MentorPayableAccount(invoice.MentorID), AmountCents: -(invoice.AmountCents - invoice.PlatformFeeCents)},
+		},
+	}
+}
+
+// refundLedger reverses a refund's share of the charge. The fee share is computed
+// on the running total so that a series of partial refunds reverses the fee exactly.
+func refundLedger(invoice *models.Invoice, previous, amountCents int64, reason string) *models.LedgerTransaction {
+	feeBefore := invoice.PlatformFeeCents * previous / invoice.AmountCents
+	feeAfter := invoice.PlatformFeeCents * (previous + amountCents) / invoice.AmountCents
+	fee := feeAfter - feeBefore
+
+	invoiceID := invoice.ID
+	return &models.LedgerTransaction{
+		InvoiceID:   &invoiceID,
+		Kind:        models.PaymentKind.Refund,
+		Description: fmt.Sprintf("Refund on invoice %s: %s", invoice.Number, reason),
+		Entries: []*models.LedgerEntry{
+			{Account: models.LedgerAccount.Cash, AmountCents: -amountCents},
+			{Account: models.LedgerAccount.PlatformFees, AmountCents: fee},
+			{Account: models.MentorPayableAccount(invoice.MentorID), AmountCents: amountCents - fee},
+		},
+	}
+}
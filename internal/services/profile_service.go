@@ -76,6 +76,9 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, userID int, updates
 	if updates.Skills != nil {
 		profile.Skills = *updates.Skills
 	}
+	if updates.Specialties != nil {
+		profile.Specialties = *updates.Specialties
+	}
 	if updates.TimeZone != nil {
 		profile.Timezone = *updates.TimeZone
 	}
@@ -104,22 +107,12 @@ func (s *ProfileService) GetPublicProfile(ctx context.Context, userID int) (*mod
 		return nil, errors.New("profile not found")
 	}
 
-	// Get user's ratings and reviews
-	ratings, err := s.profileRepo.GetUserRatings(ctx, userID)
+	// Ratings are read from the counters kept up to date as feedback arrives
+	rating, err := s.profileRepo.GetRatingSummary(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate average rating
-	var averageRating float64
-	if len(ratings) > 0 {
-		var total float64
-		for _, r := range ratings {
-			total += r.Rating
-		}
-		averageRating = total / float64(len(ratings))
-	}
-
 	return &models.PublicProfile{
 		FirstName:      profile.FirstName,
 		LastName:       profile.LastName,
@@ -127,8 +120,8 @@ func (s *ProfileService) GetPublicProfile(ctx context.Context, userID int) (*mod
 		Skills:         profile.Skills,
 		Rate:           profile.Rate,
 		ProfilePicture: profile.ProfilePicture,
-		AverageRating:  averageRating,
-		RatingCount:    len(ratings),
+		AverageRating:  rating.AverageRating,
+		RatingCount:    rating.RatingCount,
 	}, nil
 }
 
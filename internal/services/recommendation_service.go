@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+// Recommendation scoring weights. A mentor covering every interest, in the
+// mentee's timezone, available, top rated and with plenty of room scores 100.
+const (
+	skillMatchWeight   = 40.0
+	timezoneWeight     = 15.0
+	availabilityWeight = 10.0
+	ratingWeight       = 20.0
+	capacityWeight     = 15.0
+
+	// rejectionPenalty is subtracted for each earlier request the mentor rejected
+	rejectionPenalty = 30.0
+)
+
+const (
+	defaultRecommendationLimit = 6
+	maxRecommendationLimit     = 20
+
+	// ratingConfidenceReviews is how many reviews a rating needs to count in full
+	ratingConfidenceReviews = 5
+	// capacityTargetSpots is the number of open spots that earns the full capacity score
+	capacityTargetSpots = 5
+	// dismissalPeriod is how long a dismissed mentor stays out of recommendations
+	dismissalPeriod = 30 * 24 * time.Hour
+)
+
+type RecommendationService struct {
+	recommendationRepo repository.IRecommendationRepository
+	profileRepo        repository.IProfileRepository
+	userRepo           repository.IUserRepository
+}
+
+func NewRecommendationService(
+	recommendationRepo repository.IRecommendationRepository,
+	profileRepo repository.IProfileRepository,
+	userRepo repository.IUserRepository,
+) IRecommendationService {
+	return &RecommendationService{
+		recommendationRepo: recommendationRepo,
+		profileRepo:        profileRepo,
+		userRepo:           userRepo,
+	}
+}
+
+// GetRecommendations scores the mentors a mentee could request and returns the
+// best matches, each with the reasons it was suggested. Mentors with no open
+// spots in their active programs are left out.
+func (s *RecommendationService) GetRecommendations(ctx context.Context, menteeID, limit int) ([]*models.MentorRecommendation, error) {
+	if limit < 1 {
+		limit = defaultRecommendationLimit
+	}
+	if limit > maxRecommendationLimit {
+		limit = maxRecommendationLimit
+	}
+
+	interestIDs, err := s.recommendationRepo.ListMenteeInterests(ctx, menteeID)
+	if err != nil {
+		return nil, err
+	}
+	interests := make(map[int64]bool, len(interestIDs))
+	for _, id := range interestIDs {
+		interests[int64(id)] = true
+	}
+
+	profile, err := s.profileRepo.GetProfileByUserID(ctx, menteeID)
+	if err != nil {
+		return nil, err
+	}
+	menteeTimezone := ""
+	if profile != nil {
+		menteeTimezone = profile.Timezone
+	}
+
+	now := time.Now()
+	candidates, err := s.recommendationRepo.ListCandidates(ctx, menteeID, now.Add(-dismissalPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]*models.MentorRecommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.OpenSpots < 1 {
+			continue
+		}
+		recommendations = append(recommendations, scoreCandidate(candidate, interests, menteeTimezone, now))
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Mentor.AverageRating != b.Mentor.AverageRating {
+			return a.Mentor.AverageRating > b.Mentor.AverageRating
+		}
+		return a.Mentor.UserID < b.Mentor.UserID
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations, nil
+}
+
+// Dismiss hides a mentor from the mentee's recommendations for a while
+func (s *RecommendationService) Dismiss(ctx context.Context, menteeID, mentorID int) error {
+	return s.saveFeedback(ctx, menteeID, mentorID, models.RecommendationFeedback.Dismissed)
+}
+
+// MarkNotInterested hides a mentor from the mentee's recommendations until cleared
+func (s *RecommendationService) MarkNotInterested(ctx context.Context, menteeID, mentorID int) error {
+	return s.saveFeedback(ctx, menteeID, mentorID, models.RecommendationFeedback.NotInterested)
+}
+
+// ClearFeedback lets a dismissed mentor be recommended again
+func (s *RecommendationService) ClearFeedback(ctx context.Context, menteeID, mentorID int) error {
+	return s.recommendationRepo.DeleteFeedback(ctx, menteeID, mentorID)
+}
+
+func (s *RecommendationService) saveFeedback(ctx context.Context, menteeID, mentorID int, kind string) error {
+	mentor, err := s.userRepo.GetUserByID(ctx, mentorID)
+	if err != nil {
+		return err
+	}
+	if mentor == nil || !mentor.IsMentor {
+		return errors.New("mentor not found")
+	}
+	return s.recommendationRepo.SaveFeedback(ctx, menteeID, mentorID, kind)
+}
+
+// scoreCandidate weighs a mentor's skill overlap, timezone distance,
+// availability, rating, capacity and past rejections of the mentee
+func scoreCandidate(candidate *models.MentorCandidate, interests map[int64]bool, menteeTimezone string, now time.Time) *models.MentorRecommendation {
+	recommendation := &models.MentorRecommendation{
+		Mentor:        candidate,
+		MatchedSkills: []string{},
+		Reasons:       []string{},
+	}
+
+	for i, skillID := range candidate.SkillIDs {
+		if interests[skillID] && i < len(candidate.SkillNames) {
+			recommendation.MatchedSkills = append(recommendation.MatchedSkills, candidate.SkillNames[i])
+		}
+	}
+	if matched := len(recommendation.MatchedSkills); matched > 0 {
+		recommendation.Score += skillMatchWeight * float64(matched) / float64(len(interests))
+		recommendation.Reasons = append(recommendation.Reasons,
+			fmt.Sprintf("Matches %d of your skills: %s", matched, strings.Join(recommendation.MatchedSkills, ", ")))
+	}
+
+	if hours, ok := timezoneDistance(menteeTimezone, candidate.Timezone, now); ok {
+		recommendation.Score += timezoneWeight * (1 - hours/12)
+		switch {
+		case hours == 0:
+			recommendation.Reasons = append(recommendation.Reasons, "In your timezone")
+		case hours <= 3:
+			recommendation.Reasons = append(recommendation.Reasons, fmt.Sprintf("Within %g hours of your timezone", hours))
+		}
+	}
+
+	if candidate.Available {
+		recommendation.Score += availabilityWeight
+		recommendation.Reasons = append(recommendation.Reasons, "Available now")
+	}
+
+	if candidate.RatingCount > 0 {
+		confidence := math.Min(float64(candidate.RatingCount)/ratingConfidenceReviews, 1)
+		recommendation.Score += ratingWeight * candidate.AverageRating / 5 * confidence
+		if candidate.AverageRating >= 4 {
+			recommendation.Reasons = append(recommendation.Reasons,
+				fmt.Sprintf("Rated %.1f from %d reviews", candidate.AverageRating, candidate.RatingCount))
+		}
+	}
+
+	recommendation.Score += capacityWeight * math.Min(float64(candidate.OpenSpots)/capacityTargetSpots, 1)
+	if candidate.OpenSpots == 1 {
+		recommendation.Reasons = append(recommendation.Reasons, "1 spot left")
+	} else {
+		recommendation.Reasons = append(recommendation.Reasons, fmt.Sprintf("%d spots open", candidate.OpenSpots))
+	}
+
+	recommendation.Score -= rejectionPenalty * float64(candidate.Rejections)
+	recommendation.Score = math.Round(recommendation.Score*10) / 10
+
+	return recommendation
+}
+
+// timezoneDistance returns how many hours apart two IANA timezones are at now,
+// going the short way around the clock. ok is false if either is unknown.
+func timezoneDistance(a, b string, now time.Time) (hours float64, ok bool) {
+	if a == "" || b == "" {
+		return 0, false
+	}
+	locA, err := time.LoadLocation(a)
+	if err != nil {
+		return 0, false
+	}
+	locB, err := time.LoadLocation(b)
+	if err != nil {
+		return 0, false
+	}
+
+	_, offsetA := now.In(locA).Zone()
+	_, offsetB := now.In(locB).Zone()
+	hours = math.Abs(float64(offsetA-offsetB)) / 3600
+	if hours > 12 {
+		hours = math.Abs(24 - hours)
+	}
+	return hours, true
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type ReminderService struct {
+	mentorshipRepo  repository.IMentorshipRepository
+	notificationSvc INotificationService
+	leadTime        time.Duration
+}
+
+// NewReminderService creates a service that reminds participants leadTime before a session starts
+func NewReminderService(
+	mentorshipRepo repository.IMentorshipRepository,
+	notificationSvc INotificationService,
+	leadTime time.Duration,
+) IReminderService {
+	return &ReminderService{
+		mentorshipRepo:  mentorshipRepo,
+		notificationSvc: notificationSvc,
+		leadTime:        leadTime,
+	}
+}
+
+// SendDueReminders notifies both participants of every session starting within the lead time
+func (s *ReminderService) SendDueReminders(ctx context.Context) error {
+	sessions, err := s.mentorshipRepo.ListSessionsDueForReminder(ctx, time.Now().UTC().Add(s.leadTime))
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+		if err != nil || request == nil {
+			log.Printf("Skipping reminder for session %d: mentorship request unavailable", session.ID)
+			continue
+		}
+
+		title := "Upcoming mentorship session"
+		message := sessionReminderMessage(session)
+		for _, userID := range []int{request.MentorID, request.MenteeID} {
+			if err := s.notificationSvc.Notify(ctx, userID, models.NotificationType.SessionReminder, title, message); err != nil {
+				log.Printf("Failed to send reminder for session %d to user %d: %v", session.ID, userID, err)
+			}
+		}
+
+		if err := s.mentorshipRepo.MarkReminderSent(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run sends due reminders immediately and then on every interval until ctx is cancelled
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDueReminders(ctx); err != nil {
+			log.Printf("Session reminders: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func sessionReminderMessage(session *models.MentorshipSession) string {
+	message := fmt.Sprintf("Your session %q starts at %s.",
+		session.Title, session.StartTime.UTC().Format("Mon Jan 2, 15:04 MST"))
+	if session.MeetingURL != "" {
+		message += " Join here: " + session.MeetingURL
+	}
+	return message
+}
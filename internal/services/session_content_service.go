@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+type SessionContentService struct {
+	contentRepo    repository.ISessionContentRepository
+	mentorshipRepo repository.IMentorshipRepository
+}
+
+func NewSessionContentService(
+	contentRepo repository.ISessionContentRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+) ISessionContentService {
+	return &SessionContentService{
+		contentRepo:    contentRepo,
+		mentorshipRepo: mentorshipRepo,
+	}
+}
+
+// GetContent returns a session's agenda, shared notes, the caller's private
+// notes and the action items relevant to the session
+func (s *SessionContentService) GetContent(ctx context.Context, userID, sessionID int) (*models.SessionContent, error) {
+	session, _, err := s.participantSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.contentRepo.GetContent(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	content.PrivateNotes, err = s.contentRepo.GetPrivateNotes(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	content.ActionItems, err = s.contentRepo.ListSessionActionItems(ctx, session.RequestID, sessionID, session.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// UpdateAgenda replaces the agenda; version must be the one the caller last read
+func (s *SessionContentService) UpdateAgenda(ctx context.Context, userID, sessionID int, agenda string, version int) (int, error) {
+	session, _, err := s.participantSession(ctx, userID, sessionID)
+	if err != nil {
+		return 0, err
+	}
+	if session.Status != models.SessionStatus.Scheduled {
+		return 0, errors.New("agenda can only be edited before the session takes place")
+	}
+
+	return s.contentRepo.SaveAgenda(ctx, sessionID, userID, agenda, version)
+}
+
+// UpdateSharedNotes replaces the notes both participants see
+func (s *SessionContentService) UpdateSharedNotes(ctx context.Context, userID, sessionID int, notes string, version int) (int, error) {
+	session, _, err := s.participantSession(ctx, userID, sessionID)
+	if err != nil {
+		return 0, err
+	}
+	if session.Status == models.SessionStatus.Cancelled {
+		return 0, errors.New("cannot edit notes of a cancelled session")
+	}
+
+	return s.contentRepo.SaveSharedNotes(ctx, sessionID, userID, notes, version)
+}
+
+// UpdatePrivateNotes replaces the caller's own notes for a session
+func (s *SessionContentService) UpdatePrivateNotes(ctx context.Context, userID, sessionID int, notes string) error {
+	if _, _, err := s.participantSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	return s.contentRepo.SavePrivateNotes(ctx, sessionID, userID, notes)
+}
+
+// AddActionItem records a follow-up task; the owner defaults to the creator
+func (s *SessionContentService) AddActionItem(ctx context.Context, userID, sessionID int, item *models.ActionItem) error {
+	session, request, err := s.participantSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	item.Title = strings.TrimSpace(item.Title)
+	if item.Title == "" {
+		return errors.New("action item title is required")
+	}
+
+	if item.OwnerID == 0 {
+		item.OwnerID = userID
+	}
+	if !isParticipant(request, item.OwnerID) {
+		return errors.New("action item owner must be a participant of this mentorship")
+	}
+
+	item.RequestID = session.RequestID
+	item.SessionID = sessionID
+	item.CreatedBy = userID
+
+	return s.contentRepo.CreateActionItem(ctx, item)
+}
+
+// UpdateActionItem applies a partial update to an action item
+func (s *SessionContentService) UpdateActionItem(ctx context.Context, userID, itemID int, update *models.ActionItemUpdate) (*models.ActionItem, error) {
+	item, request, err := s.participantActionItem(ctx, userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Title != nil {
+		title := strings.TrimSpace(*update.Title)
+		if title == "" {
+			return nil, errors.New("action item title is required")
+		}
+		item.Title = title
+	}
+	if update.Description != nil {
+		item.Description = *update.Description
+	}
+	if update.OwnerID != nil {
+		if !isParticipant(request, *update.OwnerID) {
+			return nil, errors.New("action item owner must be a participant of this mentorship")
+		}
+		item.OwnerID = *update.OwnerID
+	}
+	if update.ClearDue {
+		item.DueDate = nil
+	} else if update.DueDate != nil {
+		item.DueDate = update.DueDate
+	}
+	if update.Done != nil && *update.Done != item.Done {
+		item.Done = *update.Done
+		if item.Done {
+			now := time.Now().UTC()
+			item.CompletedAt = &now
+		} else {
+			item.CompletedAt = nil
+		}
+	}
+
+	if err := s.contentRepo.UpdateActionItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// DeleteActionItem removes an action item; only its creator may do so
+func (s *SessionContentService) DeleteActionItem(ctx context.Context, userID, itemID int) error {
+	item, _, err := s.participantActionItem(ctx, userID, itemID)
+	if err != nil {
+		return err
+	}
+	if item.CreatedBy != userID {
+		return errors.New("unauthorized: only the creator can delete this action item")
+	}
+
+	return s.contentRepo.DeleteActionItem(ctx, itemID)
+}
+
+// ListActionItems returns the action items across all sessions of a mentorship
+func (s *SessionContentService) ListActionItems(ctx context.Context, userID, requestID int, openOnly bool) ([]*models.ActionItem, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+
+	return s.contentRepo.ListRequestActionItems(ctx, requestID, openOnly)
+}
+
+// participantSession loads a session and its request, checking the user takes part in it
+func (s *SessionContentService) participantSession(ctx context.Context, userID, sessionID int) (*models.MentorshipSession, *models.MentorshipRequest, error) {
+	session, err := s.mentorshipRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session == nil {
+		return nil, nil, errors.New("session not found")
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, session.RequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+
+	return session, request, nil
+}
+
+func (s *SessionContentService) participantActionItem(ctx context.Context, userID, itemID int) (*models.ActionItem, *models.MentorshipRequest, error) {
+	item, err := s.contentRepo.GetActionItem(ctx, itemID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request, err := s.mentorshipRepo.GetRequest(ctx, item.RequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+
+	return item, request, nil
+}
+
+func isParticipant(request *models.MentorshipRequest, userID int) bool {
+	return request.MentorID == userID || request.MenteeID == userID
+}
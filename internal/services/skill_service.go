@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+// Autocomplete result limits
+const (
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 25
+)
+
+// maxSkillNameLength matches the name columns of the taxonomy tables
+const maxSkillNameLength = 64
+
+type SkillService struct {
+	skillRepo repository.ISkillRepository
+}
+
+func NewSkillService(skillRepo repository.ISkillRepository) ISkillService {
+	return &SkillService{
+		skillRepo: skillRepo,
+	}
+}
+
+// CreateCategory adds a skill category
+func (s *SkillService) CreateCategory(ctx context.Context, category *models.SkillCategory) (*models.SkillCategory, error) {
+	if err := validateSkillCategory(category); err != nil {
+		return nil, err
+	}
+	if err := s.skillRepo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory renames, describes or reorders a category
+func (s *SkillService) UpdateCategory(ctx context.Context, categoryID int, update *models.SkillCategory) (*models.SkillCategory, error) {
+	update.ID = categoryID
+	if err := validateSkillCategory(update); err != nil {
+		return nil, err
+	}
+	if err := s.skillRepo.UpdateCategory(ctx, update); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetCategory(ctx, categoryID)
+}
+
+// DeleteCategory removes a category, leaving its skills uncategorised
+func (s *SkillService) DeleteCategory(ctx context.Context, categoryID int) error {
+	return s.skillRepo.DeleteCategory(ctx, categoryID)
+}
+
+// ListCategories returns every category in display order
+func (s *SkillService) ListCategories(ctx context.Context) ([]*models.SkillCategory, error) {
+	return s.skillRepo.ListCategories(ctx)
+}
+
+// CreateSkill adds a skill to the taxonomy
+func (s *SkillService) CreateSkill(ctx context.Context, skill *models.Skill) (*models.Skill, error) {
+	if err := s.validateSkill(ctx, skill); err != nil {
+		return nil, err
+	}
+	if err := s.skillRepo.CreateSkill(ctx, skill); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetSkill(ctx, skill.ID)
+}
+
+// UpdateSkill renames, recategorises or describes a skill
+func (s *SkillService) UpdateSkill(ctx context.Context, skillID int, update *models.Skill) (*models.Skill, error) {
+	update.ID = skillID
+	if err := s.validateSkill(ctx, update); err != nil {
+		return nil, err
+	}
+	if err := s.skillRepo.UpdateSkill(ctx, update); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetSkill(ctx, skillID)
+}
+
+// DeleteSkill removes a skill from the taxonomy and from every profile
+func (s *SkillService) DeleteSkill(ctx context.Context, skillID int) error {
+	return s.skillRepo.DeleteSkill(ctx, skillID)
+}
+
+// GetSkill returns a skill with its synonyms
+func (s *SkillService) GetSkill(ctx context.Context, skillID int) (*models.Skill, error) {
+	return s.skillRepo.GetSkill(ctx, skillID)
+}
+
+// ListSkills returns the taxonomy, optionally limited to a category or to specialties
+func (s *SkillService) ListSkills(ctx context.Context, categoryID *int, specialtiesOnly bool) ([]*models.Skill, error) {
+	return s.skillRepo.ListSkills(ctx, categoryID, specialtiesOnly)
+}
+
+// AddSynonym adds an alternative name for a skill
+func (s *SkillService) AddSynonym(ctx context.Context, skillID int, name string) (*models.Skill, error) {
+	name = strings.TrimSpace(name)
+	slug := models.SkillSlug(name)
+	if slug == "" || len(name) > maxSkillNameLength {
+		return nil, fmt.Errorf("synonym must be 1 to %d characters with at least one letter or digit", maxSkillNameLength)
+	}
+	if err := s.skillRepo.AddSynonym(ctx, skillID, name, slug); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetSkill(ctx, skillID)
+}
+
+// RemoveSynonym removes an alternative name from a skill
+func (s *SkillService) RemoveSynonym(ctx context.Context, skillID int, name string) (*models.Skill, error) {
+	if err := s.skillRepo.RemoveSynonym(ctx, skillID, models.SkillSlug(name)); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetSkill(ctx, skillID)
+}
+
+// MergeSkill folds a duplicate skill into another and returns the merged skill
+func (s *SkillService) MergeSkill(ctx context.Context, sourceID, targetID int) (*models.Skill, error) {
+	if sourceID == targetID {
+		return nil, errors.New("cannot merge a skill into itself")
+	}
+	if err := s.skillRepo.MergeSkill(ctx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetSkill(ctx, targetID)
+}
+
+// Autocomplete suggests skills whose name or synonym starts with prefix
+func (s *SkillService) Autocomplete(ctx context.Context, prefix string, specialtiesOnly bool, limit int) ([]*models.SkillSuggestion, error) {
+	slug := models.SkillSlug(prefix)
+	if slug == "" {
+		return []*models.SkillSuggestion{}, nil
+	}
+
+	if limit < 1 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	return s.skillRepo.SuggestSkills(ctx, slug, specialtiesOnly, limit)
+}
+
+// ListSpecialtyNames returns the names of every specialty, for mentor registration
+func (s *SkillService) ListSpecialtyNames(ctx context.Context) ([]string, error) {
+	specialties, err := s.skillRepo.ListSkills(ctx, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(specialties))
+	for _, specialty := range specialties {
+		names = append(names, specialty.Name)
+	}
+	return names, nil
+}
+
+// GetProfileSkills returns the skills on a user's profile with their levels
+func (s *SkillService) GetProfileSkills(ctx context.Context, userID int) ([]*models.ProfileSkill, error) {
+	return s.skillRepo.GetProfileSkills(ctx, userID)
+}
+
+// SetProfileSkills replaces the skills on a user's profile. Levels default to intermediate.
+func (s *SkillService) SetProfileSkills(ctx context.Context, userID int, skills []*models.ProfileSkill) ([]*models.ProfileSkill, error) {
+	seen := make(map[int]bool, len(skills))
+	for _, skill := range skills {
+		if seen[skill.SkillID] {
+			return nil, fmt.Errorf("skill %d is listed more than once", skill.SkillID)
+		}
+		seen[skill.SkillID] = true
+
+		if skill.Level == "" {
+			skill.Level = models.SkillLevel.Intermediate
+		}
+		if !validSkillLevel(skill.Level) {
+			return nil, fmt.Errorf("unknown skill level %q", skill.Level)
+		}
+	}
+
+	if err := s.skillRepo.SetProfileSkills(ctx, userID, skills); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.GetProfileSkills(ctx, userID)
+}
+
+// AddProfileSkillNames links comma-separated skill and specialty names to a
+// profile, resolving synonyms and adding unknown names to the taxonomy
+func (s *SkillService) AddProfileSkillNames(ctx context.Context, userID int, skills, specialties string) error {
+	var skillIDs []int
+	for _, list := range []struct {
+		names       string
+		isSpecialty bool
+	}{
+		{skills, false},
+		{specialties, true},
+	} {
+		for _, name := range strings.Split(list.names, ",") {
+			name = strings.TrimSpace(name)
+			slug := models.SkillSlug(name)
+			if slug == "" || len(name) > maxSkillNameLength {
+				continue
+			}
+			skill, err := s.skillRepo.ResolveSkill(ctx, name, slug, list.isSpecialty)
+			if err != nil {
+				return err
+			}
+			skillIDs = append(skillIDs, skill.ID)
+		}
+	}
+
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	return s.skillRepo.AddProfileSkills(ctx, userID, skillIDs, models.SkillLevel.Intermediate)
+}
+
+func (s *SkillService) validateSkill(ctx context.Context, skill *models.Skill) error {
+	skill.Name = strings.TrimSpace(skill.Name)
+	skill.Description = strings.TrimSpace(skill.Description)
+	skill.Slug = models.SkillSlug(skill.Name)
+	if skill.Slug == "" || len(skill.Name) > maxSkillNameLength {
+		return fmt.Errorf("skill name must be 1 to %d characters with at least one letter or digit", maxSkillNameLength)
+	}
+
+	if skill.CategoryID != nil {
+		if _, err := s.skillRepo.GetCategory(ctx, *skill.CategoryID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSkillCategory(category *models.SkillCategory) error {
+	category.Name = strings.TrimSpace(category.Name)
+	category.Description = strings.TrimSpace(category.Description)
+	category.Slug = models.SkillSlug(category.Name)
+	if category.Slug == "" || len(category.Name) > maxSkillNameLength {
+		return fmt.Errorf("category name must be 1 to %d characters with at least one letter or digit", maxSkillNameLength)
+	}
+	return nil
+}
+
+func validSkillLevel(level string) bool {
+	switch level {
+	case models.SkillLevel.Beginner, models.SkillLevel.Intermediate, models.SkillLevel.Advanced, models.SkillLevel.Expert:
+		return true
+	}
+	return false
+}
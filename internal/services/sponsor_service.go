@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"mentorApp/internal/models"
+	"mentorApp/internal/repository"
+)
+
+// sessionCreditCost is the number of credits a completed session draws from its sponsor's budget
+const sessionCreditCost = 1
+
+var sponsorCodePattern = regexp.MustCompile(`^[A-Z0-9-]{4,32}$`)
+
+type SponsorService struct {
+	sponsorRepo    repository.ISponsorRepository
+	mentorshipRepo repository.IMentorshipRepository
+	notifier       INotificationService
+}
+
+func NewSponsorService(
+	sponsorRepo repository.ISponsorRepository,
+	mentorshipRepo repository.IMentorshipRepository,
+	notifier INotificationService,
+) ISponsorService {
+	return &SponsorService{
+		sponsorRepo:    sponsorRepo,
+		mentorshipRepo: mentorshipRepo,
+		notifier:       notifier,
+	}
+}
+
+// CreateSponsor opens a sponsor account. A code is generated when none is given.
+func (s *SponsorService) CreateSponsor(ctx context.Context, adminID int, sponsor *models.Sponsor) (*models.Sponsor, error) {
+	if err := validateSponsor(sponsor); err != nil {
+		return nil, err
+	}
+
+	sponsor.Code = normalizeSponsorCode(sponsor.Code)
+	if sponsor.Code == "" {
+		code, err := generateSponsorCode()
+		if err != nil {
+			return nil, err
+		}
+		sponsor.Code = code
+	}
+	if !sponsorCodePattern.MatchString(sponsor.Code) {
+		return nil, errors.New("sponsorship code must be 4 to 32 letters, digits or dashes")
+	}
+
+	sponsor.CreatedBy = adminID
+	if err := s.sponsorRepo.CreateSponsor(ctx, sponsor); err != nil {
+		return nil, err
+	}
+
+	return s.sponsorRepo.GetSponsor(ctx, sponsor.ID)
+}
+
+// UpdateSponsor changes a sponsor's details, budget, status or expiry. The budget
+// cannot drop below the credits already used or reserved.
+func (s *SponsorService) UpdateSponsor(ctx context.Context, sponsorID int, update *models.Sponsor) (*models.Sponsor, error) {
+	sponsor, err := s.sponsorRepo.GetSponsor(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSponsor(update); err != nil {
+		return nil, err
+	}
+	if committed := sponsor.UsedCredits + sponsor.ReservedCredits; update.BudgetCredits < committed {
+		return nil, fmt.Errorf("budget cannot be lower than the %d credits already used or reserved", committed)
+	}
+
+	sponsor.Name = update.Name
+	sponsor.ContactEmail = update.ContactEmail
+	sponsor.BudgetCredits = update.BudgetCredits
+	sponsor.Active = update.Active
+	sponsor.ExpiresAt = update.ExpiresAt
+	if err := s.sponsorRepo.UpdateSponsor(ctx, sponsor); err != nil {
+		return nil, err
+	}
+
+	return sponsor, nil
+}
+
+// GetSponsor returns a sponsor with its current usage
+func (s *SponsorService) GetSponsor(ctx context.Context, sponsorID int) (*models.Sponsor, error) {
+	return s.sponsorRepo.GetSponsor(ctx, sponsorID)
+}
+
+// ListSponsors returns every sponsor with its current usage
+func (s *SponsorService) ListSponsors(ctx context.Context) ([]*models.Sponsor, error) {
+	return s.sponsorRepo.ListSponsors(ctx)
+}
+
+// GetSponsorReport breaks a sponsor's budget usage down by mentorship and month
+func (s *SponsorService) GetSponsorReport(ctx context.Context, sponsorID int) (*models.SponsorReport, error) {
+	sponsor, err := s.sponsorRepo.GetSponsor(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentorships, err := s.sponsorRepo.ListSponsoredMentorships(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+
+	monthly, err := s.sponsorRepo.ListMonthlyUsage(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SponsorReport{
+		Sponsor:          sponsor,
+		RemainingCredits: sponsor.RemainingCredits(),
+		Mentorships:      mentorships,
+		Monthly:          monthly,
+	}
+	if sponsor.UsedCredits > sponsor.BudgetCredits {
+		report.OverdrawnCredits = sponsor.UsedCredits - sponsor.BudgetCredits
+	}
+	for _, mentorship := range mentorships {
+		switch mentorship.Status {
+		case models.RequestStatus.Pending, models.RequestStatus.AwaitingAgreement, models.RequestStatus.Approved:
+			report.ActiveMentorships++
+		}
+	}
+
+	return report, nil
+}
+
+// RedeemCode stores a mentorship request paid for by the sponsor owning a
+// sponsorship code, reserving the credits of its first session, and returns
+// the sponsor. Either all of it happens or, for a code that cannot cover a
+// new mentorship, none of it.
+func (s *SponsorService) RedeemCode(ctx context.Context, code string, request *models.MentorshipRequest) (*models.Sponsor, error) {
+	sponsor, err := s.sponsorRepo.CreateSponsoredRequest(ctx, normalizeSponsorCode(code), request, sessionCreditCost, time.Now())
+	if errors.Is(err, repository.ErrSponsorNotFound) {
+		return nil, errors.New("invalid sponsorship code")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sponsor, nil
+}
+
+// CreateSession stores a newly scheduled session. For a sponsored mentorship
+// the session's credits are reserved in the same transaction, so a session is
+// never booked that its sponsor cannot pay for; other mentorships need no
+// reservation.
+func (s *SponsorService) CreateSession(ctx context.Context, session *models.MentorshipSession) error {
+	err := s.sponsorRepo.CreateSponsoredSession(ctx, session, sessionCreditCost)
+	if errors.Is(err, repository.ErrSponsorshipNotFound) {
+		return s.mentorshipRepo.CreateSession(ctx, session)
+	}
+	return err
+}
+
+// ReleaseCredits hands the credits reserved for a mentorship that has ended
+// back to its sponsor
+func (s *SponsorService) ReleaseCredits(ctx context.Context, requestID int) error {
+	return s.sponsorRepo.ReleaseCredits(ctx, requestID)
+}
+
+// GetMentorshipSponsorship returns the sponsor of a mentorship to either participant
+func (s *SponsorService) GetMentorshipSponsorship(ctx context.Context, userID, requestID int) (*models.Sponsorship, error) {
+	request, err := s.mentorshipRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || !isParticipant(request, userID) {
+		return nil, errors.New("unauthorized: user not part of this mentorship")
+	}
+	return s.sponsorRepo.GetSponsorship(ctx, requestID)
+}
+
+// RecordSessionUsage draws a completed session's credits from the mentorship's
+// sponsor, if it has one. Sessions were reserved for when they were scheduled,
+// so this only fails for sessions booked before reservations existed that the
+// budget can no longer cover. When this uses up the budget both participants
+// are told that further sessions are no longer covered.
+func (s *SponsorService) RecordSessionUsage(ctx context.Context, request *models.MentorshipRequest, session *models.MentorshipSession) error {
+	sponsorship, err := s.sponsorRepo.GetSponsorship(ctx, request.ID)
+	if errors.Is(err, repository.ErrSponsorshipNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	usage := &models.CreditUsage{
+		SponsorID: sponsorship.SponsorID,
+		RequestID: request.ID,
+		SessionID: session.ID,
+		Credits:   sessionCreditCost,
+	}
+	sponsor, err := s.sponsorRepo.ConsumeCredits(ctx, usage)
+	if err != nil {
+		return err
+	}
+
+	// Only the session that crossed the limit announces it
+	if usage.ID == 0 || sponsor.UsedCredits < sponsor.BudgetCredits || sponsor.UsedCredits-usage.Credits >= sponsor.BudgetCredits {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s has used all of its sponsorship credits. Further sessions are no longer covered.", sponsor.Name)
+	for _, participant := range []int{request.MentorID, request.MenteeID} {
+		if err := s.notifier.Notify(ctx, participant, models.NotificationType.SponsorshipUsedUp, "Sponsorship credits used up", message); err != nil {
+			log.Printf("Failed to notify user %d that sponsor %d is used up: %v", participant, sponsor.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSponsor(sponsor *models.Sponsor) error {
+	sponsor.Name = strings.TrimSpace(sponsor.Name)
+	sponsor.ContactEmail = strings.TrimSpace(sponsor.ContactEmail)
+	if sponsor.Name == "" {
+		return errors.New("sponsor name is required")
+	}
+	if sponsor.BudgetCredits < 0 {
+		return errors.New("budget cannot be negative")
+	}
+	return nil
+}
+
+func normalizeSponsorCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func generateSponsorCode() (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate sponsorship code: %w", err)
+	}
+	return "SPONSOR-" + strings.ToUpper(hex.EncodeToString(suffix)), nil
+}
@@ -17,20 +17,26 @@ import (
 )
 
 type UserService struct {
-	userRepo    *repository.UserRepository
-	profileRepo *repository.ProfileRepository
-	emailSvc    *email.EmailService
+	userRepo     *repository.UserRepository
+	profileRepo  *repository.ProfileRepository
+	feedbackRepo *repository.FeedbackRepository
+	skills       ISkillService
+	emailSvc     *email.EmailService
 }
 
 func NewUserService(
 	userRepo *repository.UserRepository,
 	profileRepo *repository.ProfileRepository,
+	feedbackRepo *repository.FeedbackRepository,
+	skills ISkillService,
 	emailSvc *email.EmailService,
 ) IUserService {
 	return &UserService{
-		userRepo:    userRepo,
-		profileRepo: profileRepo,
-		emailSvc:    emailSvc,
+		userRepo:     userRepo,
+		profileRepo:  profileRepo,
+		feedbackRepo: feedbackRepo,
+		skills:       skills,
+		emailSvc:     emailSvc,
 	}
 }
 
@@ -115,11 +121,13 @@ func (s *UserService) RegisterMentor(ctx context.Context, input RegisterMentorIn
 	}
 
 	profile := &models.Profile{
-		FirstName: input.FirstName,
-		LastName:  input.LastName,
-		Bio:       input.Bio,
-		Skills:    input.Skills,
-		Rate:      input.Rate,
+		FirstName:   input.FirstName,
+		LastName:    input.LastName,
+		Bio:         input.Bio,
+		Skills:      input.Skills,
+		Specialties: input.Specialties,
+		Experience:  input.Experience,
+		Rate:        input.Rate,
 	}
 
 	// Create user and profile
@@ -128,6 +136,51 @@ func (s *UserService) RegisterMentor(ctx context.Context, input RegisterMentorIn
 		return nil, err
 	}
 
+	// Link the free-text skills and specialties to the taxonomy
+	if err := s.skills.AddProfileSkillNames(ctx, user.Id, input.Skills, input.Specialties); err != nil {
+		log.Printf("Failed to link skills for mentor %d: %v", user.Id, err)
+	}
+
+	return user, nil
+}
+
+// RegisterEmployer creates an employer account that can post jobs once an
+// admin approves it
+func (s *UserService) RegisterEmployer(ctx context.Context, input RegisterEmployerInput) (*models.User, error) {
+	existingUser, err := s.userRepo.GetUserByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser != nil {
+		return nil, errors.New("email already registered")
+	}
+
+	if err := validateEmailDomain(input.Email); err != nil {
+		return nil, err
+	}
+
+	// Like mentors, employers skip email verification but need admin approval
+	user := &models.User{
+		Username:      input.Username,
+		Email:         input.Email,
+		Role:          models.UserRole.Employer,
+		EmailVerified: true,
+		IsApproved:    false,
+	}
+
+	if err := user.SetPassword(input.Password); err != nil {
+		return nil, err
+	}
+
+	err = s.userRepo.CreateUserWithProfile(ctx, user, &models.Profile{
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+		Bio:       input.Bio,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -244,13 +297,33 @@ func (s *UserService) GetPublicProfile(ctx context.Context, userID int) (*models
 		return nil, errors.New("profile not found")
 	}
 
+	rating, err := s.profileRepo.GetRatingSummary(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only reviews the mentee made public and a moderator approved are shown
+	testimonials, err := s.feedbackRepo.ListTestimonials(ctx, userID, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	skills, err := s.skills.GetProfileSkills(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	publicProfile := &models.PublicProfile{
 		FirstName:      profile.FirstName,
 		LastName:       profile.LastName,
 		Bio:            profile.Bio,
 		Skills:         profile.Skills,
+		SkillLevels:    skills,
 		Rate:           profile.Rate,
 		ProfilePicture: profile.ProfilePicture,
+		AverageRating:  rating.AverageRating,
+		RatingCount:    rating.RatingCount,
+		Testimonials:   testimonials,
 	}
 
 	return publicProfile, nil
@@ -0,0 +1,6 @@
+-- File: migrations/000004_add_calendar_feeds.down.sql
+
+DROP TRIGGER IF EXISTS update_calendar_feeds_updated_at ON calendar_feeds;
+
+DROP TABLE IF EXISTS calendar_busy_blocks;
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,32 @@
+-- File: migrations/000004_add_calendar_feeds.up.sql
+
+-- External calendars mentors sync free/busy information from
+CREATE TABLE calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    mentor_id INTEGER UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    feed_type VARCHAR(20) NOT NULL DEFAULT 'ics' CHECK (feed_type IN ('ics', 'caldav')),
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_synced_at TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Busy blocks imported from a feed; replaced wholesale on every sync
+CREATE TABLE calendar_busy_blocks (
+    id SERIAL PRIMARY KEY,
+    feed_id INTEGER NOT NULL REFERENCES calendar_feeds(id) ON DELETE CASCADE,
+    mentor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT calendar_busy_blocks_time_check CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_calendar_busy_blocks_mentor_time ON calendar_busy_blocks(mentor_id, start_time, end_time);
+
+CREATE TRIGGER update_calendar_feeds_updated_at
+    BEFORE UPDATE ON calendar_feeds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
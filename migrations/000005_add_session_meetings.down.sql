@@ -0,0 +1,8 @@
+-- File: migrations/000005_add_session_meetings.down.sql
+
+DROP INDEX IF EXISTS idx_mentorship_sessions_reminders;
+
+ALTER TABLE mentorship_sessions DROP COLUMN IF EXISTS reminder_sent_at;
+ALTER TABLE mentorship_sessions DROP COLUMN IF EXISTS sequence;
+ALTER TABLE mentorship_sessions DROP COLUMN IF EXISTS meeting_url;
+ALTER TABLE mentorship_sessions DROP COLUMN IF EXISTS meeting_provider;
@@ -0,0 +1,17 @@
+-- File: migrations/000005_add_session_meetings.up.sql
+
+-- Sessions were being written with a title the table never had
+ALTER TABLE mentorship_sessions ADD COLUMN IF NOT EXISTS title VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Join details produced by the meeting provider
+ALTER TABLE mentorship_sessions ADD COLUMN meeting_provider VARCHAR(50);
+ALTER TABLE mentorship_sessions ADD COLUMN meeting_url TEXT;
+
+-- Calendar invite revision, bumped on every reschedule
+ALTER TABLE mentorship_sessions ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
+
+-- When the participants were last reminded about the session
+ALTER TABLE mentorship_sessions ADD COLUMN reminder_sent_at TIMESTAMP;
+
+CREATE INDEX idx_mentorship_sessions_reminders ON mentorship_sessions(start_time)
+    WHERE status = 'scheduled' AND reminder_sent_at IS NULL;
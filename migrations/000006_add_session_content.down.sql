@@ -0,0 +1,9 @@
+-- File: migrations/000006_add_session_content.down.sql
+
+DROP TRIGGER IF EXISTS update_session_action_items_updated_at ON session_action_items;
+DROP TRIGGER IF EXISTS update_session_private_notes_updated_at ON session_private_notes;
+DROP TRIGGER IF EXISTS update_session_contents_updated_at ON session_contents;
+
+DROP TABLE IF EXISTS session_action_items;
+DROP TABLE IF EXISTS session_private_notes;
+DROP TABLE IF EXISTS session_contents;
@@ -0,0 +1,65 @@
+-- File: migrations/000006_add_session_content.up.sql
+
+-- Agenda and shared notes, one row per session. Versions guard against
+-- one participant silently overwriting the other's edits.
+CREATE TABLE session_contents (
+    session_id INTEGER PRIMARY KEY REFERENCES mentorship_sessions(id) ON DELETE CASCADE,
+    agenda TEXT NOT NULL DEFAULT '',
+    agenda_version INTEGER NOT NULL DEFAULT 0,
+    agenda_updated_by INTEGER REFERENCES users(id),
+    shared_notes TEXT NOT NULL DEFAULT '',
+    shared_notes_version INTEGER NOT NULL DEFAULT 0,
+    shared_notes_updated_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Notes only visible to the participant who wrote them
+CREATE TABLE session_private_notes (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES mentorship_sessions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT session_private_notes_unique UNIQUE (session_id, user_id)
+);
+
+-- Action items belong to the mentorship so open ones carry into later sessions
+CREATE TABLE session_action_items (
+    id SERIAL PRIMARY KEY,
+    request_id INTEGER NOT NULL REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES mentorship_sessions(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    due_date DATE,
+    done BOOLEAN NOT NULL DEFAULT false,
+    completed_at TIMESTAMP,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_session_action_items_request ON session_action_items(request_id, done);
+
+CREATE TRIGGER update_session_contents_updated_at
+    BEFORE UPDATE ON session_contents
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_session_private_notes_updated_at
+    BEFORE UPDATE ON session_private_notes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_session_action_items_updated_at
+    BEFORE UPDATE ON session_action_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Existing free-text session notes become the shared notes
+INSERT INTO session_contents (session_id, shared_notes, shared_notes_version)
+SELECT id, notes, 1
+FROM mentorship_sessions
+WHERE notes IS NOT NULL AND notes <> '';
@@ -0,0 +1,11 @@
+-- File: migrations/000007_unify_session_feedback.down.sql
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE profiles DROP COLUMN IF EXISTS rating_sum;
+
+DROP TRIGGER IF EXISTS update_session_feedback_updated_at ON session_feedback;
+DROP INDEX IF EXISTS idx_session_feedback_mentor;
+
+ALTER TABLE session_feedback DROP CONSTRAINT IF EXISTS session_feedback_unique;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS mentor_id;
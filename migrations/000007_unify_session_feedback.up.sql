@@ -0,0 +1,42 @@
+-- File: migrations/000007_unify_session_feedback.up.sql
+
+-- The mentor being reviewed, so ratings can be aggregated without joins
+ALTER TABLE session_feedback ADD COLUMN mentor_id INTEGER REFERENCES users(id);
+ALTER TABLE session_feedback ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE session_feedback sf
+SET mentor_id = mr.mentor_id
+FROM mentorship_sessions ms
+JOIN mentorship_requests mr ON ms.request_id = mr.id
+WHERE sf.session_id = ms.id;
+
+ALTER TABLE session_feedback ALTER COLUMN mentor_id SET NOT NULL;
+
+-- Keep only the latest feedback per participant per session
+DELETE FROM session_feedback a
+USING session_feedback b
+WHERE a.session_id = b.session_id AND a.user_id = b.user_id AND a.id < b.id;
+
+ALTER TABLE session_feedback
+    ADD CONSTRAINT session_feedback_unique UNIQUE (session_id, user_id);
+
+CREATE INDEX idx_session_feedback_mentor ON session_feedback(mentor_id, created_at DESC);
+
+CREATE TRIGGER update_session_feedback_updated_at
+    BEFORE UPDATE ON session_feedback
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Denormalized rating counters; only mentee feedback about the mentor counts
+ALTER TABLE profiles ADD COLUMN rating_sum INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE profiles ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE profiles p
+SET rating_sum = agg.rating_sum, rating_count = agg.rating_count
+FROM (
+    SELECT mentor_id, SUM(rating) AS rating_sum, COUNT(*) AS rating_count
+    FROM session_feedback
+    WHERE user_id <> mentor_id
+    GROUP BY mentor_id
+) agg
+WHERE p.user_id = agg.mentor_id;
@@ -0,0 +1,12 @@
+-- File: migrations/000008_add_review_moderation.down.sql
+
+DROP INDEX IF EXISTS idx_session_feedback_moderation;
+
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS mentor_replied_at;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS mentor_reply;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS moderation_note;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS moderation_flags;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS moderation_status;
+ALTER TABLE session_feedback DROP COLUMN IF EXISTS is_public;
@@ -0,0 +1,19 @@
+-- File: migrations/000008_add_review_moderation.up.sql
+
+-- Mentees opt in to showing a review on the mentor's public profile
+ALTER TABLE session_feedback ADD COLUMN is_public BOOLEAN NOT NULL DEFAULT false;
+
+-- Admin moderation; only approved public reviews are shown as testimonials
+ALTER TABLE session_feedback ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (moderation_status IN ('pending', 'approved', 'hidden', 'flagged'));
+ALTER TABLE session_feedback ADD COLUMN moderation_flags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE session_feedback ADD COLUMN moderation_note TEXT NOT NULL DEFAULT '';
+ALTER TABLE session_feedback ADD COLUMN moderated_by INTEGER REFERENCES users(id);
+ALTER TABLE session_feedback ADD COLUMN moderated_at TIMESTAMP;
+
+-- The mentor's public response to a review
+ALTER TABLE session_feedback ADD COLUMN mentor_reply TEXT NOT NULL DEFAULT '';
+ALTER TABLE session_feedback ADD COLUMN mentor_replied_at TIMESTAMP;
+
+CREATE INDEX idx_session_feedback_moderation ON session_feedback(moderation_status, created_at)
+    WHERE is_public = true;
@@ -0,0 +1,8 @@
+-- File: migrations/000009_add_mentorship_goals.down.sql
+
+DROP TRIGGER IF EXISTS update_goal_milestones_updated_at ON goal_milestones;
+DROP TRIGGER IF EXISTS update_mentorship_goals_updated_at ON mentorship_goals;
+
+DROP TABLE IF EXISTS goal_checkins;
+DROP TABLE IF EXISTS goal_milestones;
+DROP TABLE IF EXISTS mentorship_goals;
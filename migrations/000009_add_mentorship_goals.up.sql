@@ -0,0 +1,58 @@
+-- File: migrations/000009_add_mentorship_goals.up.sql
+
+-- SMART goals the mentee is working towards within a mentorship
+CREATE TABLE mentorship_goals (
+    id SERIAL PRIMARY KEY,
+    request_id INTEGER NOT NULL REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    specific TEXT NOT NULL DEFAULT '',
+    measurable TEXT NOT NULL DEFAULT '',
+    achievable TEXT NOT NULL DEFAULT '',
+    relevant TEXT NOT NULL DEFAULT '',
+    time_bound TEXT NOT NULL DEFAULT '',
+    target_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'achieved', 'abandoned')),
+    achieved_at TIMESTAMP,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE goal_milestones (
+    id SERIAL PRIMARY KEY,
+    goal_id INTEGER NOT NULL REFERENCES mentorship_goals(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0 AND progress <= 100),
+    due_date DATE,
+    completed_at TIMESTAMP,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Progress updates, optionally recorded during a session
+CREATE TABLE goal_checkins (
+    id SERIAL PRIMARY KEY,
+    goal_id INTEGER NOT NULL REFERENCES mentorship_goals(id) ON DELETE CASCADE,
+    session_id INTEGER REFERENCES mentorship_sessions(id) ON DELETE SET NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    progress INTEGER NOT NULL CHECK (progress >= 0 AND progress <= 100),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_mentorship_goals_request ON mentorship_goals(request_id);
+CREATE INDEX idx_goal_milestones_goal ON goal_milestones(goal_id, position);
+CREATE INDEX idx_goal_checkins_goal ON goal_checkins(goal_id, created_at);
+
+CREATE TRIGGER update_mentorship_goals_updated_at
+    BEFORE UPDATE ON mentorship_goals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_goal_milestones_updated_at
+    BEFORE UPDATE ON goal_milestones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- File: migrations/000010_add_cohorts.down.sql
+
+DROP TRIGGER IF EXISTS update_cohort_sessions_updated_at ON cohort_sessions;
+DROP TRIGGER IF EXISTS update_cohorts_updated_at ON cohorts;
+
+DROP TABLE IF EXISTS cohort_announcements;
+DROP TABLE IF EXISTS cohort_attendance;
+DROP TABLE IF EXISTS cohort_sessions;
+DROP TABLE IF EXISTS cohort_members;
+DROP TABLE IF EXISTS cohorts;
@@ -0,0 +1,78 @@
+-- File: migrations/000010_add_cohorts.up.sql
+
+-- A cohort is one run of a program that a group of mentees starts together
+CREATE TABLE cohorts (
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER NOT NULL REFERENCES mentorship_programs(id) ON DELETE CASCADE,
+    mentor_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    enrollment_opens_at TIMESTAMP NOT NULL,
+    enrollment_closes_at TIMESTAMP NOT NULL,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'cancelled')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (enrollment_closes_at > enrollment_opens_at),
+    CHECK (ends_on >= starts_on)
+);
+
+CREATE TABLE cohort_members (
+    cohort_id INTEGER NOT NULL REFERENCES cohorts(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'enrolled'
+        CHECK (status IN ('enrolled', 'withdrawn')),
+    enrolled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cohort_id, mentee_id)
+);
+
+-- Group sessions attended by the whole cohort
+CREATE TABLE cohort_sessions (
+    id SERIAL PRIMARY KEY,
+    cohort_id INTEGER NOT NULL REFERENCES cohorts(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    topic TEXT NOT NULL DEFAULT '',
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'completed', 'cancelled')),
+    meeting_url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_time > start_time)
+);
+
+CREATE TABLE cohort_attendance (
+    session_id INTEGER NOT NULL REFERENCES cohort_sessions(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('present', 'absent', 'excused')),
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (session_id, mentee_id)
+);
+
+CREATE TABLE cohort_announcements (
+    id SERIAL PRIMARY KEY,
+    cohort_id INTEGER NOT NULL REFERENCES cohorts(id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES users(id),
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cohorts_program ON cohorts(program_id);
+CREATE INDEX idx_cohorts_enrollment ON cohorts(enrollment_closes_at) WHERE status = 'active';
+CREATE INDEX idx_cohort_members_mentee ON cohort_members(mentee_id);
+CREATE INDEX idx_cohort_sessions_cohort ON cohort_sessions(cohort_id, start_time);
+CREATE INDEX idx_cohort_announcements_cohort ON cohort_announcements(cohort_id, created_at);
+
+CREATE TRIGGER update_cohorts_updated_at
+    BEFORE UPDATE ON cohorts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_cohort_sessions_updated_at
+    BEFORE UPDATE ON cohort_sessions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
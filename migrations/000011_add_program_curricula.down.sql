@@ -0,0 +1,10 @@
+-- File: migrations/000011_add_program_curricula.down.sql
+
+DROP TRIGGER IF EXISTS update_curriculum_modules_updated_at ON curriculum_modules;
+DROP TRIGGER IF EXISTS update_curriculum_versions_updated_at ON curriculum_versions;
+
+DROP TABLE IF EXISTS module_completions;
+DROP TABLE IF EXISTS mentee_curricula;
+DROP TABLE IF EXISTS module_resources;
+DROP TABLE IF EXISTS curriculum_modules;
+DROP TABLE IF EXISTS curriculum_versions;
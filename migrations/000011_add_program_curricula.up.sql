@@ -0,0 +1,73 @@
+-- File: migrations/000011_add_program_curricula.up.sql
+
+-- Each program's curriculum is versioned; published versions are never edited
+-- so mentees keep the curriculum they started with
+CREATE TABLE curriculum_versions (
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER NOT NULL REFERENCES mentorship_programs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'published')),
+    published_at TIMESTAMP,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (program_id, version)
+);
+
+-- At most one draft per program
+CREATE UNIQUE INDEX idx_curriculum_versions_draft ON curriculum_versions(program_id) WHERE status = 'draft';
+
+CREATE TABLE curriculum_modules (
+    id SERIAL PRIMARY KEY,
+    version_id INTEGER NOT NULL REFERENCES curriculum_versions(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    title VARCHAR(255) NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    objectives TEXT[] NOT NULL DEFAULT '{}',
+    assignment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE module_resources (
+    id SERIAL PRIMARY KEY,
+    module_id INTEGER NOT NULL REFERENCES curriculum_modules(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('link', 'file')),
+    title VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    file_key VARCHAR(255) NOT NULL DEFAULT '',
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The curriculum version a mentorship follows, fixed the first time it is opened
+CREATE TABLE mentee_curricula (
+    request_id INTEGER PRIMARY KEY REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    version_id INTEGER NOT NULL REFERENCES curriculum_versions(id),
+    pinned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE module_completions (
+    request_id INTEGER NOT NULL REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    module_id INTEGER NOT NULL REFERENCES curriculum_modules(id) ON DELETE CASCADE,
+    completed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (request_id, module_id)
+);
+
+CREATE INDEX idx_curriculum_modules_version ON curriculum_modules(version_id, position);
+CREATE INDEX idx_module_resources_module ON module_resources(module_id, position);
+CREATE INDEX idx_mentee_curricula_version ON mentee_curricula(version_id);
+
+CREATE TRIGGER update_curriculum_versions_updated_at
+    BEFORE UPDATE ON curriculum_versions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_curriculum_modules_updated_at
+    BEFORE UPDATE ON curriculum_modules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- File: migrations/000012_add_assignments.down.sql
+
+DROP TRIGGER IF EXISTS update_assignments_updated_at ON assignments;
+
+DROP TABLE IF EXISTS submission_reviews;
+DROP TABLE IF EXISTS assignment_submissions;
+DROP TABLE IF EXISTS assignments;
@@ -0,0 +1,60 @@
+-- File: migrations/000012_add_assignments.up.sql
+
+-- Assignments belong either to a program (every mentee gets them) or to a
+-- single mentorship
+CREATE TABLE assignments (
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER REFERENCES mentorship_programs(id) ON DELETE CASCADE,
+    request_id INTEGER REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    title VARCHAR(255) NOT NULL,
+    instructions TEXT NOT NULL DEFAULT '',
+    due_at TIMESTAMP,
+    rubric JSONB NOT NULL DEFAULT '[]',
+    max_resubmissions INTEGER NOT NULL DEFAULT 2 CHECK (max_resubmissions >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((program_id IS NULL) <> (request_id IS NULL))
+);
+
+-- One row per submission round of a mentorship
+CREATE TABLE assignment_submissions (
+    id SERIAL PRIMARY KEY,
+    assignment_id INTEGER NOT NULL REFERENCES assignments(id) ON DELETE CASCADE,
+    request_id INTEGER NOT NULL REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id),
+    round INTEGER NOT NULL CHECK (round > 0),
+    body TEXT NOT NULL DEFAULT '',
+    links TEXT[] NOT NULL DEFAULT '{}',
+    file_key VARCHAR(255) NOT NULL DEFAULT '',
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'submitted'
+        CHECK (status IN ('submitted', 'changes_requested', 'approved')),
+    late BOOLEAN NOT NULL DEFAULT false,
+    submitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (assignment_id, request_id, round)
+);
+
+CREATE TABLE submission_reviews (
+    id SERIAL PRIMARY KEY,
+    submission_id INTEGER NOT NULL UNIQUE REFERENCES assignment_submissions(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id),
+    scores JSONB NOT NULL DEFAULT '[]',
+    total_score INTEGER NOT NULL DEFAULT 0,
+    max_score INTEGER NOT NULL DEFAULT 0,
+    comment TEXT NOT NULL DEFAULT '',
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('changes_requested', 'approved')),
+    reviewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_assignments_program ON assignments(program_id) WHERE program_id IS NOT NULL;
+CREATE INDEX idx_assignments_request ON assignments(request_id) WHERE request_id IS NOT NULL;
+CREATE INDEX idx_assignment_submissions_request ON assignment_submissions(request_id, assignment_id);
+CREATE INDEX idx_assignment_submissions_pending ON assignment_submissions(submitted_at) WHERE status = 'submitted';
+
+CREATE TRIGGER update_assignments_updated_at
+    BEFORE UPDATE ON assignments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
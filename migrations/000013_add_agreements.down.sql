@@ -0,0 +1,5 @@
+-- File: migrations/000013_add_agreements.down.sql
+
+DROP TABLE IF EXISTS agreement_acceptances;
+DROP TABLE IF EXISTS mentorship_agreements;
+DROP TABLE IF EXISTS agreement_templates;
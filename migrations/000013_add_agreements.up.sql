@@ -0,0 +1,38 @@
+-- File: migrations/000013_add_agreements.up.sql
+
+-- Agreement templates are versioned and never edited. A NULL program_id marks
+-- the platform-wide code of conduct; a program_id overrides it for that program.
+CREATE TABLE agreement_templates (
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER REFERENCES mentorship_programs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_agreement_templates_default_version ON agreement_templates(version) WHERE program_id IS NULL;
+CREATE UNIQUE INDEX idx_agreement_templates_program_version ON agreement_templates(program_id, version) WHERE program_id IS NOT NULL;
+
+-- The agreement version a mentorship must accept, fixed when the mentor approves the request
+CREATE TABLE mentorship_agreements (
+    request_id INTEGER PRIMARY KEY REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    template_id INTEGER NOT NULL REFERENCES agreement_templates(id),
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Evidence that a participant accepted the agreement
+CREATE TABLE agreement_acceptances (
+    id SERIAL PRIMARY KEY,
+    request_id INTEGER NOT NULL REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    template_id INTEGER NOT NULL REFERENCES agreement_templates(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    accepted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (request_id, user_id)
+);
+
+CREATE INDEX idx_mentorship_agreements_template ON mentorship_agreements(template_id);
+CREATE INDEX idx_agreement_acceptances_template ON agreement_acceptances(template_id);
@@ -0,0 +1,10 @@
+-- File: migrations/000014_add_payments.down.sql
+
+DROP TRIGGER IF EXISTS update_invoices_updated_at ON invoices;
+DROP TRIGGER IF EXISTS ledger_entries_balanced ON ledger_entries;
+DROP FUNCTION IF EXISTS check_ledger_balance();
+
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS invoices;
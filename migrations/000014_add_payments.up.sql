@@ -0,0 +1,80 @@
+-- File: migrations/000014_add_payments.up.sql
+
+-- One invoice per paid mentorship, issued when the request is approved
+CREATE TABLE invoices (
+    id SERIAL PRIMARY KEY,
+    number VARCHAR(20) UNIQUE,
+    request_id INTEGER NOT NULL UNIQUE REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id),
+    mentor_id INTEGER NOT NULL REFERENCES users(id),
+    program_id INTEGER NOT NULL REFERENCES mentorship_programs(id),
+    description TEXT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    platform_fee_cents BIGINT NOT NULL DEFAULT 0 CHECK (platform_fee_cents >= 0),
+    refunded_cents BIGINT NOT NULL DEFAULT 0 CHECK (refunded_cents >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'paid', 'partially_refunded', 'refunded', 'void')),
+    issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    paid_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (refunded_cents <= amount_cents)
+);
+
+-- Every attempt to move money through the payment provider
+CREATE TABLE payments (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('charge', 'refund')),
+    provider VARCHAR(50) NOT NULL,
+    provider_ref VARCHAR(255) NOT NULL DEFAULT '',
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Double-entry ledger: debits are positive, credits negative, and the entries
+-- of a transaction always sum to zero
+CREATE TABLE ledger_transactions (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER REFERENCES invoices(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ledger_entries (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES ledger_transactions(id) ON DELETE CASCADE,
+    account VARCHAR(64) NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents <> 0)
+);
+
+CREATE INDEX idx_invoices_mentee ON invoices(mentee_id);
+CREATE INDEX idx_invoices_mentor ON invoices(mentor_id);
+CREATE INDEX idx_payments_invoice ON payments(invoice_id);
+CREATE INDEX idx_ledger_transactions_invoice ON ledger_transactions(invoice_id);
+CREATE INDEX idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account);
+
+CREATE OR REPLACE FUNCTION check_ledger_balance()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (SELECT COALESCE(SUM(amount_cents), 0) FROM ledger_entries WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_entries_balanced
+    AFTER INSERT OR UPDATE ON ledger_entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+    EXECUTE FUNCTION check_ledger_balance();
+
+CREATE TRIGGER update_invoices_updated_at
+    BEFORE UPDATE ON invoices
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- File: migrations/000015_add_sponsorships.down.sql
+
+DROP TRIGGER IF EXISTS update_sponsors_updated_at ON sponsors;
+
+DROP TABLE IF EXISTS sponsor_credit_usage;
+DROP TABLE IF EXISTS sponsorships;
+DROP TABLE IF EXISTS sponsors;
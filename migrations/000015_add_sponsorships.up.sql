@@ -0,0 +1,41 @@
+-- File: migrations/000015_add_sponsorships.up.sql
+
+-- Teams that pay for mentorships with a budget of session credits
+CREATE TABLE sponsors (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    contact_email VARCHAR(255) NOT NULL DEFAULT '',
+    budget_credits INTEGER NOT NULL CHECK (budget_credits >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    expires_at TIMESTAMP,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The sponsor covering a mentorship, chosen by the code the mentee applied
+CREATE TABLE sponsorships (
+    request_id INTEGER PRIMARY KEY REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    sponsor_id INTEGER NOT NULL REFERENCES sponsors(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Credits drawn from a sponsor's budget, one row per completed session
+CREATE TABLE sponsor_credit_usage (
+    id SERIAL PRIMARY KEY,
+    sponsor_id INTEGER NOT NULL REFERENCES sponsors(id),
+    request_id INTEGER NOT NULL REFERENCES mentorship_requests(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL UNIQUE REFERENCES mentorship_sessions(id) ON DELETE CASCADE,
+    credits INTEGER NOT NULL CHECK (credits > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sponsorships_sponsor ON sponsorships(sponsor_id);
+CREATE INDEX idx_sponsor_credit_usage_sponsor ON sponsor_credit_usage(sponsor_id);
+CREATE INDEX idx_sponsor_credit_usage_request ON sponsor_credit_usage(request_id);
+
+CREATE TRIGGER update_sponsors_updated_at
+    BEFORE UPDATE ON sponsors
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- File: migrations/000016_add_mentor_search.down.sql
+
+DROP INDEX IF EXISTS idx_profiles_rate;
+DROP INDEX IF EXISTS idx_profiles_search_vector;
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE profiles DROP COLUMN IF EXISTS specialties;
@@ -0,0 +1,16 @@
+-- File: migrations/000016_add_mentor_search.up.sql
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS specialties TEXT NOT NULL DEFAULT '';
+
+-- Weighted document for mentor search: names rank highest, then skills and
+-- specialties, then bio, then experience
+ALTER TABLE profiles ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(skills, '') || ' ' || coalesce(specialties, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(bio, '')), 'C') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(experience, '')), 'D')
+    ) STORED;
+
+CREATE INDEX idx_profiles_search_vector ON profiles USING GIN (search_vector);
+CREATE INDEX idx_profiles_rate ON profiles(rate);
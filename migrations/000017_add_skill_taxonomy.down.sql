@@ -0,0 +1,9 @@
+-- File: migrations/000017_add_skill_taxonomy.down.sql
+
+DROP TRIGGER IF EXISTS update_skills_updated_at ON skills;
+DROP TRIGGER IF EXISTS update_skill_categories_updated_at ON skill_categories;
+
+DROP TABLE IF EXISTS profile_skills;
+DROP TABLE IF EXISTS skill_synonyms;
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS skill_categories;
@@ -0,0 +1,95 @@
+-- File: migrations/000017_add_skill_taxonomy.up.sql
+
+CREATE TABLE skill_categories (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Skills are looked up by slug; specialties are broad areas offered at mentor registration
+CREATE TABLE skills (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER REFERENCES skill_categories(id) ON DELETE SET NULL,
+    name VARCHAR(64) NOT NULL,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    is_specialty BOOLEAN NOT NULL DEFAULT false,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Alternative names resolving to a skill, e.g. "golang" for Go
+CREATE TABLE skill_synonyms (
+    id SERIAL PRIMARY KEY,
+    skill_id INTEGER NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE profile_skills (
+    profile_id INTEGER NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    skill_id INTEGER NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    level VARCHAR(20) NOT NULL DEFAULT 'intermediate'
+        CHECK (level IN ('beginner', 'intermediate', 'advanced', 'expert')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (profile_id, skill_id)
+);
+
+CREATE INDEX idx_skills_category ON skills(category_id);
+CREATE INDEX idx_skills_slug_prefix ON skills(slug text_pattern_ops);
+CREATE INDEX idx_skill_synonyms_skill ON skill_synonyms(skill_id);
+CREATE INDEX idx_skill_synonyms_slug_prefix ON skill_synonyms(slug text_pattern_ops);
+CREATE INDEX idx_profile_skills_skill ON profile_skills(skill_id);
+
+CREATE TRIGGER update_skill_categories_updated_at
+    BEFORE UPDATE ON skill_categories
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_skills_updated_at
+    BEFORE UPDATE ON skills
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Same normalisation as the application's skill slugs
+CREATE FUNCTION skill_slug(term TEXT) RETURNS TEXT AS $$
+    SELECT rtrim(left(trim(BOTH '-' FROM regexp_replace(lower(trim(term)), '[^a-z0-9+#.]+', '-', 'g')), 64), '-')
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Specialties previously offered at mentor registration
+INSERT INTO skills (name, slug, is_specialty)
+SELECT name, skill_slug(name), true
+FROM unnest(ARRAY[
+    'Backend Development', 'Frontend Development', 'DevOps', 'Cloud Architecture',
+    'Cloud Native Technologies', 'Mobile Development', 'Data Science', 'AI', 'NLP', 'ML'
+]) AS name;
+
+-- Move the comma-separated skills and specialties of existing profiles into the taxonomy
+CREATE TEMP TABLE migrated_skill_terms AS
+SELECT p.id AS profile_id, left(trim(t.term), 64) AS name, false AS is_specialty
+FROM profiles p, unnest(string_to_array(COALESCE(p.skills, ''), ',')) AS t(term)
+UNION ALL
+SELECT p.id, left(trim(t.term), 64), true
+FROM profiles p, unnest(string_to_array(p.specialties, ',')) AS t(term);
+
+DELETE FROM migrated_skill_terms WHERE skill_slug(name) = '';
+
+INSERT INTO skills (name, slug, is_specialty)
+SELECT min(name), skill_slug(name), bool_or(is_specialty)
+FROM migrated_skill_terms
+GROUP BY skill_slug(name)
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO profile_skills (profile_id, skill_id)
+SELECT DISTINCT t.profile_id, s.id
+FROM migrated_skill_terms t
+JOIN skills s ON s.slug = skill_slug(t.name)
+ON CONFLICT DO NOTHING;
+
+DROP TABLE migrated_skill_terms;
+DROP FUNCTION skill_slug(TEXT);
@@ -0,0 +1,3 @@
+-- File: migrations/000018_add_mentor_recommendations.down.sql
+
+DROP TABLE IF EXISTS mentor_recommendation_feedback;
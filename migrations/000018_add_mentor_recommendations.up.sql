@@ -0,0 +1,11 @@
+-- File: migrations/000018_add_mentor_recommendations.up.sql
+
+-- Mentee feedback on recommended mentors. Dismissed mentors are hidden for a
+-- while; mentors marked not interested are hidden until the mentee undoes it.
+CREATE TABLE mentor_recommendation_feedback (
+    mentee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mentor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('dismissed', 'not_interested')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (mentee_id, mentor_id)
+);
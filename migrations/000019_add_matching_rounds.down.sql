@@ -0,0 +1,8 @@
+-- File: migrations/000019_add_matching_rounds.down.sql
+
+DROP TRIGGER IF EXISTS update_matching_rounds_updated_at ON matching_rounds;
+
+DROP TABLE IF EXISTS matching_pairs;
+DROP TABLE IF EXISTS matching_round_mentees;
+DROP TABLE IF EXISTS matching_round_mentors;
+DROP TABLE IF EXISTS matching_rounds;
@@ -0,0 +1,58 @@
+-- File: migrations/000019_add_matching_rounds.up.sql
+
+-- A matching round pairs mentees with mentors in bulk. Participants join while
+-- it is open; running the matcher proposes pairs for admins to review and publish.
+CREATE TABLE matching_rounds (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'matched', 'published')),
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    matched_at TIMESTAMP,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Mentors taking part, the program their matches are enrolled in and how many
+-- mentees they take. preferences optionally ranks mentees, best first.
+CREATE TABLE matching_round_mentors (
+    round_id INTEGER NOT NULL REFERENCES matching_rounds(id) ON DELETE CASCADE,
+    mentor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    program_id INTEGER NOT NULL REFERENCES mentorship_programs(id) ON DELETE CASCADE,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    team VARCHAR(100) NOT NULL DEFAULT '',
+    preferences INTEGER[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (round_id, mentor_id)
+);
+
+-- Mentees taking part with their mentors ranked, best first
+CREATE TABLE matching_round_mentees (
+    round_id INTEGER NOT NULL REFERENCES matching_rounds(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team VARCHAR(100) NOT NULL DEFAULT '',
+    preferences INTEGER[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (round_id, mentee_id)
+);
+
+-- Proposed pairs, one per matched mentee. request_id is set on publish.
+CREATE TABLE matching_pairs (
+    id SERIAL PRIMARY KEY,
+    round_id INTEGER NOT NULL REFERENCES matching_rounds(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mentor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('algorithm', 'manual')),
+    request_id INTEGER REFERENCES mentorship_requests(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (round_id, mentee_id)
+);
+
+CREATE INDEX idx_matching_pairs_mentor ON matching_pairs(round_id, mentor_id);
+
+CREATE TRIGGER update_matching_rounds_updated_at
+    BEFORE UPDATE ON matching_rounds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- File: migrations/000020_add_featured_mentors.down.sql
+
+DELETE FROM admin_settings WHERE settings_key = 'featured_mentors_mode';
+
+DROP TRIGGER IF EXISTS update_featured_mentors_updated_at ON featured_mentors;
+
+DROP TABLE IF EXISTS featured_mentors;
@@ -0,0 +1,28 @@
+-- File: migrations/000020_add_featured_mentors.up.sql
+
+-- Mentors curated onto the home page for a period, lowest position first
+CREATE TABLE featured_mentors (
+    id SERIAL PRIMARY KEY,
+    mentor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    headline VARCHAR(255) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMP,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_featured_mentors_window ON featured_mentors(starts_at, ends_at);
+
+CREATE TRIGGER update_featured_mentors_updated_at
+    BEFORE UPDATE ON featured_mentors
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- manual shows only curated mentors; automatic fills the remaining places
+-- with a daily rotation of top-rated, available mentors
+INSERT INTO admin_settings (settings_key, settings_value)
+VALUES ('featured_mentors_mode', 'manual')
+ON CONFLICT (settings_key) DO NOTHING;
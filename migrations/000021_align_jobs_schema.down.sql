@@ -0,0 +1,18 @@
+-- File: migrations/000021_align_jobs_schema.down.sql
+
+DROP TRIGGER IF EXISTS update_jobs_updated_at ON jobs;
+
+DROP INDEX IF EXISTS idx_jobs_created_by;
+DROP INDEX IF EXISTS idx_jobs_board;
+DROP INDEX IF EXISTS idx_jobs_search_vector;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS is_featured,
+    DROP COLUMN IF EXISTS remote_policy,
+    DROP COLUMN IF EXISTS experience_level,
+    DROP COLUMN IF EXISTS job_type;
+
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ALTER COLUMN status DROP NOT NULL;
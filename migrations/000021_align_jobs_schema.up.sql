@@ -0,0 +1,34 @@
+-- File: migrations/000021_align_jobs_schema.up.sql
+
+-- Bring the jobs table in line with models.Job
+UPDATE jobs SET status = 'active' WHERE status IS NULL;
+ALTER TABLE jobs ALTER COLUMN status SET NOT NULL;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check CHECK (status IN ('active', 'expired', 'closed'));
+
+ALTER TABLE jobs
+    ADD COLUMN job_type VARCHAR(20) NOT NULL DEFAULT 'full-time'
+        CHECK (job_type IN ('full-time', 'part-time', 'contract')),
+    ADD COLUMN experience_level VARCHAR(20) NOT NULL DEFAULT 'mid'
+        CHECK (experience_level IN ('entry', 'mid', 'senior')),
+    ADD COLUMN remote_policy VARCHAR(20) NOT NULL DEFAULT 'on-site'
+        CHECK (remote_policy IN ('remote', 'hybrid', 'on-site')),
+    ADD COLUMN is_featured BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN created_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- Weighted document for the job board's keyword filter: titles rank highest,
+-- then the company, then the description and requirements
+ALTER TABLE jobs ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(company, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(description, '') || ' ' || coalesce(requirements, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_jobs_search_vector ON jobs USING GIN (search_vector);
+CREATE INDEX idx_jobs_board ON jobs(status, is_featured DESC, created_at DESC);
+CREATE INDEX idx_jobs_created_by ON jobs(created_by);
+
+CREATE TRIGGER update_jobs_updated_at
+    BEFORE UPDATE ON jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- File: migrations/000022_add_job_applications.down.sql
+
+DROP TABLE IF EXISTS job_application_events;
+
+DROP TRIGGER IF EXISTS update_job_applications_updated_at ON job_applications;
+
+DROP TABLE IF EXISTS job_applications;
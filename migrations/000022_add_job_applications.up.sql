@@ -0,0 +1,43 @@
+-- File: migrations/000022_add_job_applications.up.sql
+
+-- Applications to job postings, moved through the review pipeline
+-- pending -> reviewed -> interview -> accepted/rejected, or withdrawn by the applicant
+CREATE TABLE job_applications (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'reviewed', 'interview', 'accepted', 'rejected', 'withdrawn')),
+    cover_letter TEXT NOT NULL DEFAULT '',
+    resume_url TEXT NOT NULL DEFAULT '',
+    resume_key VARCHAR(255) NOT NULL DEFAULT '',
+    resume_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    resume_content_type VARCHAR(255) NOT NULL DEFAULT '',
+    resume_size_bytes BIGINT NOT NULL DEFAULT 0,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One live application per job and applicant; withdrawn ones may be followed by a new one
+CREATE UNIQUE INDEX idx_job_applications_live ON job_applications(job_id, user_id) WHERE status <> 'withdrawn';
+CREATE INDEX idx_job_applications_job_status ON job_applications(job_id, status);
+CREATE INDEX idx_job_applications_user ON job_applications(user_id, applied_at DESC);
+
+CREATE TRIGGER update_job_applications_updated_at
+    BEFORE UPDATE ON job_applications
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Status changes and reviewer notes on an application; a note without a status
+-- change has the same from and to status
+CREATE TABLE job_application_events (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_application_events_application ON job_application_events(application_id, created_at);
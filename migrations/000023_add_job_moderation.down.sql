@@ -0,0 +1,16 @@
+-- File: migrations/000023_add_job_moderation.down.sql
+
+DROP TABLE IF EXISTS job_revisions;
+
+DROP INDEX IF EXISTS idx_jobs_expires_at;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS moderation_note,
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS renewal_count,
+    DROP COLUMN IF EXISTS expires_at;
+
+UPDATE jobs SET status = 'closed' WHERE status IN ('pending', 'rejected');
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check CHECK (status IN ('active', 'expired', 'closed'));
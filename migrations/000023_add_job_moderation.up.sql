@@ -0,0 +1,30 @@
+-- File: migrations/000023_add_job_moderation.up.sql
+
+-- Postings by mentors and employers wait in moderation as pending until an
+-- admin approves (active) or rejects them
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'active', 'expired', 'closed', 'rejected'));
+
+-- Approved postings expire at expires_at unless renewed; postings from
+-- before moderation existed have none and never expire
+ALTER TABLE jobs
+    ADD COLUMN expires_at TIMESTAMP,
+    ADD COLUMN renewal_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN moderated_at TIMESTAMP,
+    ADD COLUMN moderation_note TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_jobs_expires_at ON jobs(expires_at) WHERE status = 'active';
+
+-- Edit history of a posting: each revision holds the fields that changed,
+-- as {"field": {"from": ..., "to": ...}}
+CREATE TABLE job_revisions (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_job_revisions_job ON job_revisions(job_id, created_at);
@@ -0,0 +1,6 @@
+-- File: migrations/000024_add_job_alerts.down.sql
+
+DROP TABLE IF EXISTS saved_jobs;
+
+DROP TRIGGER IF EXISTS update_saved_job_searches_updated_at ON saved_job_searches;
+DROP TABLE IF EXISTS saved_job_searches;
@@ -0,0 +1,38 @@
+-- File: migrations/000024_add_job_alerts.up.sql
+
+-- Job board filters a user saved to be alerted about. Instant searches are
+-- alerted as soon as a matching job is published; daily ones get a digest of
+-- the jobs published since last_alerted_at.
+CREATE TABLE saved_job_searches (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    job_type VARCHAR(20) NOT NULL DEFAULT '',
+    experience_level VARCHAR(20) NOT NULL DEFAULT '',
+    remote_policy VARCHAR(20) NOT NULL DEFAULT '',
+    frequency VARCHAR(20) NOT NULL DEFAULT 'daily'
+        CHECK (frequency IN ('instant', 'daily')),
+    last_alerted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_saved_job_searches_user ON saved_job_searches(user_id);
+CREATE INDEX idx_saved_job_searches_due ON saved_job_searches(frequency, last_alerted_at);
+
+CREATE TRIGGER update_saved_job_searches_updated_at
+    BEFORE UPDATE ON saved_job_searches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Jobs a user bookmarked to come back to
+CREATE TABLE saved_jobs (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, job_id)
+);
+
+CREATE INDEX idx_saved_jobs_user ON saved_jobs(user_id, created_at DESC);
@@ -0,0 +1,5 @@
+-- File: migrations/000025_add_job_external_ids.down.sql
+
+DROP INDEX IF EXISTS idx_jobs_external_id;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS external_id;
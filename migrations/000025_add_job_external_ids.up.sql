@@ -0,0 +1,7 @@
+-- File: migrations/000025_add_job_external_ids.up.sql
+
+-- Identifier of a posting in the recruiting team's own spreadsheets; bulk
+-- imports update the job with the same external ID instead of adding another
+ALTER TABLE jobs ADD COLUMN external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_jobs_external_id ON jobs(external_id) WHERE external_id IS NOT NULL;
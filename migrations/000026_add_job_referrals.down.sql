@@ -0,0 +1,7 @@
+-- File: migrations/000026_add_job_referrals.down.sql
+
+DROP INDEX IF EXISTS idx_job_applications_referrer;
+
+ALTER TABLE job_applications DROP COLUMN IF EXISTS referrer_id;
+
+DROP TABLE IF EXISTS job_referrals;
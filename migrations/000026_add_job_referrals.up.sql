@@ -0,0 +1,22 @@
+-- File: migrations/000026_add_job_referrals.up.sql
+
+-- A mentor's referral of one of their mentees to a job. Its status is not
+-- stored: it follows the mentee's application for the job once there is one.
+-- A mentee is referred to a job at most once; the first mentor is credited.
+CREATE TABLE job_referrals (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    mentor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mentee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (job_id, mentee_id)
+);
+
+CREATE INDEX idx_job_referrals_mentor ON job_referrals(mentor_id, created_at DESC);
+CREATE INDEX idx_job_referrals_mentee ON job_referrals(mentee_id, created_at DESC);
+
+-- The mentor who referred the applicant, recorded when the application is made
+ALTER TABLE job_applications ADD COLUMN referrer_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_job_applications_referrer ON job_applications(referrer_id) WHERE referrer_id IS NOT NULL;
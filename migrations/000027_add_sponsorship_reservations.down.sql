@@ -0,0 +1,3 @@
+-- File: migrations/000027_add_sponsorship_reservations.down.sql
+
+ALTER TABLE sponsorships DROP COLUMN IF EXISTS reserved_credits;
@@ -0,0 +1,7 @@
+-- File: migrations/000027_add_sponsorship_reservations.up.sql
+
+-- Credits a sponsor holds for a mentorship's upcoming sessions. Held credits
+-- count against the budget until the sessions use them or the mentorship ends,
+-- so a sponsor never covers more sessions than its budget pays for.
+ALTER TABLE sponsorships
+    ADD COLUMN reserved_credits INTEGER NOT NULL DEFAULT 0 CHECK (reserved_credits >= 0);
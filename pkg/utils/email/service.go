@@ -29,3 +29,15 @@ func (s *EmailService) SendPasswordResetEmail(toEmail, token string) error {
     log.Printf("Sending password reset email to %s with token %s", toEmail, token)
     return nil
 }
+
+func (s *EmailService) SendNotificationEmail(toEmail, subject, body string) error {
+    // Implement actual email sending logic here
+    log.Printf("Sending notification email to %s: %s", toEmail, subject)
+    return nil
+}
+
+func (s *EmailService) SendCalendarInvite(toEmail, subject, body, invite string) error {
+    // Implement actual email sending logic here; invite is a text/calendar attachment
+    log.Printf("Sending calendar invite to %s: %s (%d bytes)", toEmail, subject, len(invite))
+    return nil
+}
@@ -0,0 +1,106 @@
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	prodID        = "-//NEXUS Mentorship Platform//EN"
+	utcDateTime   = "20060102T150405Z"
+	maxLineOctets = 75
+)
+
+// Invite describes a single calendar event sent to session participants
+type Invite struct {
+	UID         string
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Organizer   string
+	Attendees   []string
+	Cancelled   bool
+}
+
+// String renders the invite as an iTIP REQUEST (or CANCEL) calendar object
+func (i Invite) String() string {
+	method, status := "REQUEST", "CONFIRMED"
+	if i.Cancelled {
+		method, status = "CANCEL", "CANCELLED"
+	}
+
+	var b strings.Builder
+	write := func(line string) {
+		b.WriteString(fold(line))
+		b.WriteString("\r\n")
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:" + prodID)
+	write("METHOD:" + method)
+	write("BEGIN:VEVENT")
+	write("UID:" + i.UID)
+	write(fmt.Sprintf("SEQUENCE:%d", i.Sequence))
+	write("DTSTAMP:" + time.Now().UTC().Format(utcDateTime))
+	write("DTSTART:" + i.Start.UTC().Format(utcDateTime))
+	write("DTEND:" + i.End.UTC().Format(utcDateTime))
+	write("SUMMARY:" + escapeText(i.Summary))
+	if i.Description != "" {
+		write("DESCRIPTION:" + escapeText(i.Description))
+	}
+	if i.Location != "" {
+		write("LOCATION:" + escapeText(i.Location))
+	}
+	if i.URL != "" {
+		write("URL:" + i.URL)
+	}
+	if i.Organizer != "" {
+		write("ORGANIZER:mailto:" + i.Organizer)
+	}
+	for _, attendee := range i.Attendees {
+		write("ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE:mailto:" + attendee)
+	}
+	write("STATUS:" + status)
+	write("END:VEVENT")
+	write("END:VCALENDAR")
+
+	return b.String()
+}
+
+// escapeText escapes TEXT values as described in RFC 5545 section 3.3.11
+func escapeText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// fold splits content lines longer than 75 octets without breaking UTF-8 sequences
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
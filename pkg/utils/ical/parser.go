@@ -0,0 +1,430 @@
+// Package ical reads and writes the small subset of iCalendar (RFC 5545)
+// the platform needs: busy periods from external calendars and session invites.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a half-open [Start, End) interval of time
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// maxOccurrences bounds recurrence expansion for rules without COUNT or UNTIL
+const maxOccurrences = 1000
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+type event struct {
+	uid       string
+	start     time.Time
+	end       time.Time
+	allDay    bool
+	duration  time.Duration
+	hasEnd    bool
+	hasDur    bool
+	rrule     string
+	exdates   map[int64]bool
+	cancelled bool
+	free      bool
+}
+
+// RecurrenceError lists the recurring events whose RRULE could not be
+// expanded. ParseBusy returns it along with the busy periods it did read,
+// which only include the first occurrence of those events.
+type RecurrenceError struct {
+	Events []string
+}
+
+func (e *RecurrenceError) Error() string {
+	return fmt.Sprintf("%d recurring events could not be expanded: %s", len(e.Events), strings.Join(e.Events, "; "))
+}
+
+// ParseBusy extracts busy periods from an iCalendar document. VFREEBUSY
+// FREEBUSY entries and opaque, non-cancelled VEVENTs are both honoured;
+// simple RRULEs are expanded. Only periods overlapping [from, to) are
+// returned, sorted and merged. Floating times are interpreted in loc.
+// Events with a rule that cannot be expanded are reported in a
+// *RecurrenceError returned together with the periods.
+func ParseBusy(r io.Reader, from, to time.Time, loc *time.Location) ([]Period, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []Period
+	var stack []string
+	var current *event
+	var unexpanded []string
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			if strings.EqualFold(prop.value, "VEVENT") {
+				current = &event{exdates: make(map[int64]bool)}
+			}
+			continue
+		case "END":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			if strings.EqualFold(prop.value, "VEVENT") && current != nil {
+				occurrences, err := current.occurrences(from, to)
+				if err != nil {
+					unexpanded = append(unexpanded, fmt.Sprintf("%s (RRULE:%s): %v", current.uid, current.rrule, err))
+				}
+				periods = append(periods, occurrences...)
+				current = nil
+			}
+			continue
+		}
+
+		if len(stack) == 0 {
+			continue
+		}
+
+		switch stack[len(stack)-1] {
+		case "VEVENT":
+			if current == nil {
+				continue
+			}
+			if err := current.apply(prop, loc); err != nil {
+				return nil, err
+			}
+		case "VFREEBUSY":
+			if prop.name != "FREEBUSY" {
+				continue
+			}
+			fbType := strings.ToUpper(prop.params["FBTYPE"])
+			if fbType == "FREE" {
+				continue
+			}
+			for _, raw := range strings.Split(prop.value, ",") {
+				p, err := parsePeriod(raw, loc)
+				if err != nil {
+					return nil, err
+				}
+				periods = append(periods, p)
+			}
+		}
+	}
+
+	periods = Merge(clip(periods, from, to))
+	if len(unexpanded) > 0 {
+		return periods, &RecurrenceError{Events: unexpanded}
+	}
+	return periods, nil
+}
+
+// Merge sorts periods and joins any that overlap or touch
+func Merge(periods []Period) []Period {
+	if len(periods) == 0 {
+		return periods
+	}
+
+	sorted := make([]Period, len(periods))
+	copy(sorted, periods)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []Period{sorted[0]}
+	for _, p := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !p.Start.After(last.End) {
+			if p.End.After(last.End) {
+				last.End = p.End
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+func clip(periods []Period, from, to time.Time) []Period {
+	var out []Period
+	for _, p := range periods {
+		if !p.End.After(p.Start) {
+			continue
+		}
+		if p.End.After(from) && p.Start.Before(to) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// unfold joins continuation lines as described in RFC 5545 section 3.1
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+func parseProperty(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	// The value starts at the first colon outside a quoted parameter value
+	inQuotes := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		} else if c == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("malformed calendar line: %q", line)
+	}
+
+	prop.value = line[colon+1:]
+	parts := strings.Split(line[:colon], ";")
+	prop.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		prop.params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return prop, nil
+}
+
+func (e *event) apply(prop property, loc *time.Location) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		e.uid = prop.value
+	case "DTSTART":
+		e.start, e.allDay, err = parseDateTime(prop.value, prop.params, loc)
+	case "DTEND":
+		e.end, _, err = parseDateTime(prop.value, prop.params, loc)
+		e.hasEnd = true
+	case "DURATION":
+		e.duration, err = parseDuration(prop.value)
+		e.hasDur = true
+	case "RRULE":
+		e.rrule = prop.value
+	case "EXDATE":
+		for _, raw := range strings.Split(prop.value, ",") {
+			t, _, perr := parseDateTime(raw, prop.params, loc)
+			if perr != nil {
+				return perr
+			}
+			e.exdates[t.Unix()] = true
+		}
+	case "STATUS":
+		e.cancelled = strings.EqualFold(prop.value, "CANCELLED")
+	case "TRANSP":
+		e.free = strings.EqualFold(prop.value, "TRANSPARENT")
+	}
+	return err
+}
+
+func (e *event) length() time.Duration {
+	switch {
+	case e.hasEnd:
+		return e.end.Sub(e.start)
+	case e.hasDur:
+		return e.duration
+	case e.allDay:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// occurrences expands the event into the periods it is busy that may overlap
+// [from, to). An event whose rule cannot be expanded yields its first
+// occurrence along with the rule's error.
+func (e *event) occurrences(from, to time.Time) ([]Period, error) {
+	if e.cancelled || e.free || e.start.IsZero() {
+		return nil, nil
+	}
+
+	length := e.length()
+	if length <= 0 {
+		return nil, nil
+	}
+
+	if e.rrule == "" {
+		return []Period{{Start: e.start, End: e.start.Add(length)}}, nil
+	}
+
+	rule, err := parseRRule(e.rrule)
+	if err != nil {
+		return []Period{{Start: e.start, End: e.start.Add(length)}}, err
+	}
+
+	var periods []Period
+	// Occurrences starting up to one length before from still overlap it
+	rule.each(e.start, from.Add(-length), to, func(start time.Time) {
+		if e.exdates[start.Unix()] {
+			return
+		}
+		end := start.Add(length)
+		if end.After(from) {
+			periods = append(periods, Period{Start: start, End: end})
+		}
+	})
+	return periods, nil
+}
+
+// parseDateTime handles DATE, floating DATE-TIME, UTC and TZID-qualified values
+func parseDateTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q: %w", value, err)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q: %w", value, err)
+		}
+		return t, false, nil
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q: %w", value, err)
+	}
+	return t, false, nil
+}
+
+// parsePeriod parses "start/end" or "start/duration" as used by FREEBUSY
+func parsePeriod(raw string, loc *time.Location) (Period, error) {
+	parts := strings.SplitN(strings.TrimSpace(raw), "/", 2)
+	if len(parts) != 2 {
+		return Period{}, fmt.Errorf("invalid period %q", raw)
+	}
+
+	start, _, err := parseDateTime(parts[0], nil, loc)
+	if err != nil {
+		return Period{}, err
+	}
+
+	if strings.HasPrefix(parts[1], "P") || strings.HasPrefix(parts[1], "+P") {
+		d, err := parseDuration(parts[1])
+		if err != nil {
+			return Period{}, err
+		}
+		return Period{Start: start, End: start.Add(d)}, nil
+	}
+
+	end, _, err := parseDateTime(parts[1], nil, loc)
+	if err != nil {
+		return Period{}, err
+	}
+	return Period{Start: start, End: end}, nil
+}
+
+// parseDuration parses RFC 5545 durations such as P1W, P1DT2H or PT30M
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	num := ""
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			num += string(c)
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		num = ""
+
+		switch {
+		case c == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
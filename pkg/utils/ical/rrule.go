@@ -0,0 +1,277 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// rrule is the subset of RFC 5545 recurrence rules we expand: FREQ with
+// INTERVAL, COUNT, UNTIL, WKST and BYDAY. BYDAY filters daily and weekly
+// rules by weekday and picks weekdays of the month, optionally by ordinal
+// (2TU, -1FR), for monthly rules. Rules using any other BY part are rejected
+// rather than expanded wrongly.
+type rrule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	wkst     time.Weekday
+	byDay    []weekdayNum
+}
+
+// weekdayNum is a BYDAY entry: a weekday and, for monthly rules, which one of
+// the month it is (1 the first, -1 the last, 0 every one)
+type weekdayNum struct {
+	ordinal int
+	day     time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseRRule(value string) (*rrule, error) {
+	rule := &rrule{interval: 1, wkst: time.Monday}
+
+	for _, part := range strings.Split(value, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, val := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		switch key {
+		case "FREQ":
+			switch val {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				rule.freq = val
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency %q", val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence interval %q", val)
+			}
+			rule.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence count %q", val)
+			}
+			rule.count = n
+		case "UNTIL":
+			t, _, err := parseDateTime(val, nil, time.UTC)
+			if err != nil {
+				return nil, err
+			}
+			rule.until = t
+		case "WKST":
+			day, ok := weekdayCodes[val]
+			if !ok {
+				return nil, fmt.Errorf("invalid week start %q", val)
+			}
+			rule.wkst = day
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.byDay = append(rule.byDay, day)
+			}
+		case "BYMONTHDAY", "BYMONTH", "BYYEARDAY", "BYWEEKNO", "BYSETPOS", "BYHOUR", "BYMINUTE", "BYSECOND":
+			return nil, fmt.Errorf("unsupported recurrence rule part %s", key)
+		}
+	}
+
+	if rule.freq == "" {
+		return nil, fmt.Errorf("recurrence rule %q has no FREQ", value)
+	}
+	if len(rule.byDay) > 0 && rule.freq == "YEARLY" {
+		return nil, fmt.Errorf("unsupported recurrence rule part BYDAY for %s rules", rule.freq)
+	}
+	for _, day := range rule.byDay {
+		if day.ordinal != 0 && rule.freq != "MONTHLY" {
+			return nil, fmt.Errorf("weekday ordinals are only valid in MONTHLY rules, not %s", rule.freq)
+		}
+	}
+	return rule, nil
+}
+
+// parseWeekdayNum reads a BYDAY entry such as MO, 2TU or -1FR
+func parseWeekdayNum(code string) (weekdayNum, error) {
+	code = strings.TrimSpace(code)
+	split := strings.IndexFunc(code, unicode.IsLetter)
+	if split < 0 {
+		return weekdayNum{}, fmt.Errorf("invalid weekday %q", code)
+	}
+
+	day, ok := weekdayCodes[code[split:]]
+	if !ok {
+		return weekdayNum{}, fmt.Errorf("invalid weekday %q", code)
+	}
+
+	ordinal := 0
+	if prefix := code[:split]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return weekdayNum{}, fmt.Errorf("invalid weekday %q", code)
+		}
+		ordinal = n
+	}
+	return weekdayNum{ordinal: ordinal, day: day}, nil
+}
+
+// each calls fn with every occurrence start in [from, to), ending early at
+// COUNT or UNTIL. Rules without COUNT skip straight to the interval before
+// from, so events that started long ago still reach the window.
+func (r *rrule) each(dtstart, from, to time.Time, fn func(time.Time)) {
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if !r.until.IsZero() && t.After(r.until) {
+			return false
+		}
+		if r.count > 0 && emitted >= r.count {
+			return false
+		}
+		if !t.Before(to) {
+			return false
+		}
+		emitted++
+		if !t.Before(from) {
+			fn(t)
+		}
+		return true
+	}
+
+	// COUNT is counted from DTSTART, so counted rules are walked from the start
+	first := 0
+	if r.count == 0 {
+		first = r.intervalsBefore(dtstart, from)
+	}
+	limit := maxOccurrences
+	if r.count > limit {
+		limit = r.count
+	}
+
+	for i := first; i < first+limit; i++ {
+		switch r.freq {
+		case "DAILY":
+			t := dtstart.AddDate(0, 0, i*r.interval)
+			if len(r.byDay) > 0 && !containsWeekday(r.byDay, t.Weekday()) {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		case "WEEKLY":
+			if len(r.byDay) == 0 {
+				if !emit(dtstart.AddDate(0, 0, 7*i*r.interval)) {
+					return
+				}
+				continue
+			}
+			offset := (int(dtstart.Weekday()) - int(r.wkst) + 7) % 7
+			weekStart := dtstart.AddDate(0, 0, 7*i*r.interval-offset)
+			for d := 0; d < 7; d++ {
+				t := weekStart.AddDate(0, 0, d)
+				if t.Before(dtstart) || !containsWeekday(r.byDay, t.Weekday()) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		case "MONTHLY":
+			if len(r.byDay) > 0 {
+				first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(i*r.interval), 1,
+					dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+				for _, t := range weekdaysInMonth(first, r.byDay) {
+					if t.Before(dtstart) {
+						continue
+					}
+					if !emit(t) {
+						return
+					}
+				}
+				continue
+			}
+			t := dtstart.AddDate(0, i*r.interval, 0)
+			if t.Day() != dtstart.Day() {
+				// Months without this day are skipped rather than rolled over
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		case "YEARLY":
+			t := dtstart.AddDate(i*r.interval, 0, 0)
+			if t.Day() != dtstart.Day() {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		}
+	}
+}
+
+// intervalsBefore returns how many whole intervals of the rule lie between
+// dtstart and from, less one to stay clear of DST shifts and short months
+func (r *rrule) intervalsBefore(dtstart, from time.Time) int {
+	if !from.After(dtstart) {
+		return 0
+	}
+
+	var units int
+	switch r.freq {
+	case "DAILY":
+		units = int(from.Sub(dtstart).Hours() / 24)
+	case "WEEKLY":
+		units = int(from.Sub(dtstart).Hours() / (24 * 7))
+	case "MONTHLY":
+		units = (from.Year()-dtstart.Year())*12 + int(from.Month()) - int(dtstart.Month())
+	case "YEARLY":
+		units = from.Year() - dtstart.Year()
+	}
+	return max(units/r.interval-1, 0)
+}
+
+// weekdaysInMonth returns the days of the month beginning at first that
+// match byDay, in order, at first's time of day
+func weekdaysInMonth(first time.Time, byDay []weekdayNum) []time.Time {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	var days []time.Time
+	for d := 1; d <= daysInMonth; d++ {
+		t := first.AddDate(0, 0, d-1)
+		fromStart := (d-1)/7 + 1
+		fromEnd := -((daysInMonth-d)/7 + 1)
+		for _, w := range byDay {
+			if w.day == t.Weekday() && (w.ordinal == 0 || w.ordinal == fromStart || w.ordinal == fromEnd) {
+				days = append(days, t)
+				break
+			}
+		}
+	}
+	return days
+}
+
+func containsWeekday(days []weekdayNum, day time.Weekday) bool {
+	for _, d := range days {
+		if d.day == day {
+			return true
+		}
+	}
+	return false
+}
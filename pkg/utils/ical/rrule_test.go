@@ -0,0 +1,166 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func expand(t *testing.T, rule string, dtstart, from, to time.Time) []time.Time {
+	t.Helper()
+	r, err := parseRRule(rule)
+	if err != nil {
+		t.Fatalf("parseRRule(%q): %v", rule, err)
+	}
+	var starts []time.Time
+	r.each(dtstart, from, to, func(start time.Time) {
+		starts = append(starts, start)
+	})
+	return starts
+}
+
+func TestRRuleExpansion(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		from    time.Time
+		to      time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "daily standup that started years ago",
+			rule:    "FREQ=DAILY",
+			dtstart: date(2015, time.March, 2, 9),
+			from:    date(2026, time.October, 19, 0),
+			to:      date(2026, time.October, 22, 0),
+			want:    []time.Time{date(2026, time.October, 19, 9), date(2026, time.October, 20, 9), date(2026, time.October, 21, 9)},
+		},
+		{
+			name:    "weekdays only",
+			rule:    "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: date(2026, time.October, 16, 9),
+			from:    date(2026, time.October, 16, 0),
+			to:      date(2026, time.October, 21, 0),
+			want:    []time.Time{date(2026, time.October, 16, 9), date(2026, time.October, 19, 9), date(2026, time.October, 20, 9)},
+		},
+		{
+			name:    "old weekly rule with a week start",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,TU;WKST=SU",
+			dtstart: date(2020, time.January, 5, 10),
+			from:    date(2026, time.October, 18, 0),
+			to:      date(2026, time.November, 1, 0),
+			want:    []time.Time{date(2026, time.October, 18, 10), date(2026, time.October, 20, 10)},
+		},
+		{
+			name:    "second Tuesday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=2TU",
+			dtstart: date(2026, time.January, 13, 15),
+			from:    date(2026, time.September, 1, 0),
+			to:      date(2026, time.December, 1, 0),
+			want:    []time.Time{date(2026, time.September, 8, 15), date(2026, time.October, 13, 15), date(2026, time.November, 10, 15)},
+		},
+		{
+			name:    "last Friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: date(2026, time.January, 30, 16),
+			from:    date(2026, time.October, 1, 0),
+			to:      date(2026, time.December, 1, 0),
+			want:    []time.Time{date(2026, time.October, 30, 16), date(2026, time.November, 27, 16)},
+		},
+		{
+			name:    "count is measured from dtstart",
+			rule:    "FREQ=DAILY;COUNT=5",
+			dtstart: date(2026, time.October, 15, 9),
+			from:    date(2026, time.October, 18, 0),
+			to:      date(2026, time.October, 30, 0),
+			want:    []time.Time{date(2026, time.October, 18, 9), date(2026, time.October, 19, 9)},
+		},
+		{
+			name:    "until",
+			rule:    "FREQ=WEEKLY;UNTIL=20261027T090000Z",
+			dtstart: date(2026, time.October, 6, 9),
+			from:    date(2026, time.October, 1, 0),
+			to:      date(2026, time.December, 1, 0),
+			want:    []time.Time{date(2026, time.October, 6, 9), date(2026, time.October, 13, 9), date(2026, time.October, 20, 9), date(2026, time.October, 27, 9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expand(t, tt.rule, tt.dtstart, tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRRuleRejectsUnsupportedRules(t *testing.T) {
+	for _, rule := range []string{
+		"FREQ=MONTHLY;BYMONTHDAY=15",
+		"FREQ=MONTHLY;BYDAY=TU;BYSETPOS=2",
+		"FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+		"FREQ=WEEKLY;BYDAY=2TU",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;BYDAY=6TU",
+	} {
+		if _, err := parseRRule(rule); err == nil {
+			t.Errorf("parseRRule(%q) succeeded, want an error", rule)
+		}
+	}
+}
+
+func TestParseBusyReportsUnexpandedRules(t *testing.T) {
+	doc := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"DTSTART:20150302T090000Z",
+		"DTEND:20150302T091500Z",
+		"RRULE:FREQ=DAILY",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:board-meeting",
+		"DTSTART:20261015T140000Z",
+		"DTEND:20261015T150000Z",
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=15",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	from, to := date(2026, time.October, 15, 0), date(2026, time.October, 17, 0)
+	periods, err := ParseBusy(strings.NewReader(doc), from, to, time.UTC)
+
+	var unexpanded *RecurrenceError
+	if !errors.As(err, &unexpanded) {
+		t.Fatalf("got error %v, want a *RecurrenceError", err)
+	}
+	if len(unexpanded.Events) != 1 || !strings.HasPrefix(unexpanded.Events[0], "board-meeting (RRULE:FREQ=MONTHLY;BYMONTHDAY=15)") {
+		t.Errorf("got unexpanded events %q, want only board-meeting", unexpanded.Events)
+	}
+
+	want := []Period{
+		{Start: date(2026, time.October, 15, 9), End: date(2026, time.October, 15, 9).Add(15 * time.Minute)},
+		{Start: date(2026, time.October, 15, 14), End: date(2026, time.October, 15, 15)},
+		{Start: date(2026, time.October, 16, 9), End: date(2026, time.October, 16, 9).Add(15 * time.Minute)},
+	}
+	if len(periods) != len(want) {
+		t.Fatalf("got periods %v, want %v", periods, want)
+	}
+	for i := range periods {
+		if !periods[i].Start.Equal(want[i].Start) || !periods[i].End.Equal(want[i].End) {
+			t.Fatalf("got periods %v, want %v", periods, want)
+		}
+	}
+}